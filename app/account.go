@@ -16,6 +16,10 @@ type Profile struct {
 	PostsCount  int
 	Followers   int
 	Following   int
+	Locked      bool // new followers need approval
+	// FollowRequests counts pending follow requests; only known for the
+	// authenticated user's own profile.
+	FollowRequests int
 }
 
 type BlockedUser struct {
@@ -24,6 +28,28 @@ type BlockedUser struct {
 	DisplayName string
 }
 
+// AccountSummary is an account as it appears in account lists, such as the
+// people who liked a rant.
+type AccountSummary struct {
+	ID          string
+	Username    string // acct, without the leading @
+	DisplayName string
+}
+
+// Relationship is how the authenticated user and another account relate.
+type Relationship struct {
+	ID             string
+	Following      bool
+	FollowedBy     bool
+	Requested      bool // follow request pending on a locked account
+	Muting         bool
+	Blocking       bool
+	DomainBlocking bool
+	ShowingReblogs bool
+	Notifying      bool
+	Note           string // private note on the account
+}
+
 // AccountService provides information about the authenticated user.
 type AccountService interface {
 	// CurrentAccountID returns the account ID of the authenticated user.
@@ -44,18 +70,65 @@ type AccountService interface {
 	// UnblockUser unblocks a user by account ID.
 	UnblockUser(ctx context.Context, accountID string) error
 
-	// FollowUser follows a user by account ID.
-	FollowUser(ctx context.Context, accountID string) error
+	// ListDomainBlocks returns the domains the authenticated user blocks.
+	ListDomainBlocks(ctx context.Context, limit int) ([]string, error)
+
+	// BlockDomain hides everything from a remote domain and removes
+	// followers from it.
+	BlockDomain(ctx context.Context, domain string) error
+
+	// UnblockDomain lifts a domain block.
+	UnblockDomain(ctx context.Context, domain string) error
+
+	// FollowUser follows a user by account ID and returns the resulting
+	// relationship, which is only Requested for locked accounts.
+	FollowUser(ctx context.Context, accountID string) (Relationship, error)
+
+	// UnfollowUser unfollows a user, or withdraws a pending follow request.
+	UnfollowUser(ctx context.Context, accountID string) (Relationship, error)
 
-	// UnfollowUser unfollows a user by account ID.
-	UnfollowUser(ctx context.Context, accountID string) error
+	// LookupRelationships returns relationships for account IDs, keyed by ID.
+	LookupRelationships(ctx context.Context, accountIDs []string) (map[string]Relationship, error)
 
-	// LookupFollowing returns follow-state for account IDs.
-	LookupFollowing(ctx context.Context, accountIDs []string) (map[string]bool, error)
+	// ResolveAccount looks up the account at a profile URL, fetching it from
+	// its home server when needed. It fails with ErrNotFound when the URL is
+	// not an account.
+	ResolveAccount(ctx context.Context, profileURL string) (AccountSummary, error)
 
 	// ProfileByID returns profile details for a specific account.
 	ProfileByID(ctx context.Context, accountID string) (Profile, error)
 
 	// PostsByAccount returns posts for an account, newest first.
 	PostsByAccount(ctx context.Context, accountID string, limit int, maxID string) ([]domain.Rant, error)
+
+	// Followers returns a page of the accounts following accountID and the
+	// maxID of the next page ("" when exhausted).
+	Followers(ctx context.Context, accountID string, limit int, maxID string) ([]AccountSummary, string, error)
+
+	// Following returns a page of the accounts accountID follows and the
+	// maxID of the next page ("" when exhausted).
+	Following(ctx context.Context, accountID string, limit int, maxID string) ([]AccountSummary, string, error)
+
+	// FollowRequests returns a page of accounts waiting for the user to
+	// approve their follow request, and the maxID of the next page.
+	FollowRequests(ctx context.Context, limit int, maxID string) ([]AccountSummary, string, error)
+
+	// AuthorizeFollowRequest accepts the follow request from accountID.
+	AuthorizeFollowRequest(ctx context.Context, accountID string) error
+
+	// RejectFollowRequest declines the follow request from accountID.
+	RejectFollowRequest(ctx context.Context, accountID string) error
+
+	// FollowedTags returns a page of the hashtags the user follows and the
+	// maxID of the next page ("" when exhausted).
+	FollowedTags(ctx context.Context, limit int, maxID string) ([]Tag, string, error)
+
+	// LookupTag returns a hashtag, with or without '#', and its follow state.
+	LookupTag(ctx context.Context, name string) (Tag, error)
+
+	// FollowTag follows a hashtag and returns its new state.
+	FollowTag(ctx context.Context, name string) (Tag, error)
+
+	// UnfollowTag unfollows a hashtag and returns its new state.
+	UnfollowTag(ctx context.Context, name string) (Tag, error)
 }
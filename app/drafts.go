@@ -0,0 +1,54 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Draft is unsent compose content kept across cancels, failed posts and
+// crashes, together with the context needed to resume it.
+type Draft struct {
+	ID            string
+	Content       string
+	RantID        string // set when editing an existing rant
+	ParentID      string // set when replying
+	ParentAuthor  string
+	ParentSummary string
+	Hashtags      string   // Tags appended on send, including any inherited from the parent
+	Visibility    string   // Kept from a redrafted rant; empty for the default
+	SpoilerText   string   // Content warning kept from a redrafted rant
+	MediaIDs      []string // Attachments kept from a redrafted rant
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewDraftID returns a fresh draft ID. IDs sort by creation time.
+func NewDraftID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return time.Now().UTC().Format("20060102T150405.000000") + "-" + hex.EncodeToString(b[:])
+}
+
+// IsEdit reports whether the draft edits an existing rant.
+func (d Draft) IsEdit() bool { return d.RantID != "" }
+
+// IsReply reports whether the draft replies to another rant.
+func (d Draft) IsReply() bool { return d.ParentID != "" }
+
+// PostOptions returns the settings the draft is posted with.
+func (d Draft) PostOptions() PostOptions {
+	return PostOptions{Visibility: d.Visibility, SpoilerText: d.SpoilerText, MediaIDs: d.MediaIDs}
+}
+
+// DraftStore persists drafts locally.
+type DraftStore interface {
+	// ListDrafts returns all drafts, most recently updated first.
+	ListDrafts() ([]Draft, error)
+
+	// SaveDraft creates or replaces a draft. An empty ID creates a new draft.
+	SaveDraft(d Draft) (Draft, error)
+
+	// DeleteDraft removes a draft. Deleting a missing draft is not an error.
+	DeleteDraft(id string) error
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// InstanceConfig holds the server limits that shape what can be posted.
+type InstanceConfig struct {
+	MaxCharacters            int
+	CharactersReservedPerURL int
+	MaxMediaAttachments      int
+	ImageSizeLimit           int64 // bytes
+	VideoSizeLimit           int64 // bytes
+	MaxPollOptions           int
+	MaxPollOptionCharacters  int
+	MinPollExpiration        time.Duration
+	MaxPollExpiration        time.Duration
+}
+
+// DefaultInstanceConfig returns stock Mastodon limits, used until the
+// instance has answered and for any value it leaves out.
+func DefaultInstanceConfig() InstanceConfig {
+	return InstanceConfig{
+		MaxCharacters:            500,
+		CharactersReservedPerURL: 23,
+		MaxMediaAttachments:      4,
+		ImageSizeLimit:           16 * 1024 * 1024,
+		VideoSizeLimit:           99 * 1024 * 1024,
+		MaxPollOptions:           4,
+		MaxPollOptionCharacters:  50,
+		MinPollExpiration:        5 * time.Minute,
+		MaxPollExpiration:        30 * 24 * time.Hour,
+	}
+}
+
+// PostLength is the length the server counts for content once the missing
+// tags have been appended. Empty content counts as zero.
+func (c InstanceConfig) PostLength(content string, tags []string) int {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return 0
+	}
+	return domain.CountCharacters(domain.AppendHashtags(content, tags), c.CharactersReservedPerURL)
+}
+
+// CheckLength returns domain.ErrRantTooLong when content, with tags
+// appended, does not fit.
+func (c InstanceConfig) CheckLength(content string, tags []string) error {
+	if n := c.PostLength(content, tags); n > c.MaxCharacters {
+		return fmt.Errorf("%w (%d/%d)", domain.ErrRantTooLong, n, c.MaxCharacters)
+	}
+	return nil
+}
+
+// InstanceService reports the limits of the connected instance.
+type InstanceService interface {
+	// InstanceConfig returns the instance limits. When the instance cannot
+	// be reached it returns the best known limits along with the error.
+	InstanceConfig(ctx context.Context) (InstanceConfig, error)
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"time"
+)
+
+// MarkerTimeline names a timeline whose read position the server keeps.
+type MarkerTimeline string
+
+const (
+	MarkerHome          MarkerTimeline = "home"
+	MarkerNotifications MarkerTimeline = "notifications"
+)
+
+// Marker is the saved read position in a timeline.
+type Marker struct {
+	LastReadID string
+	Version    int // bumped by the server on every save
+	UpdatedAt  time.Time
+}
+
+// MarkerService saves and restores read positions, shared with the user's
+// other clients.
+type MarkerService interface {
+	// Markers returns the saved positions of the timelines, keyed by
+	// timeline. Timelines without a saved position are left out.
+	Markers(ctx context.Context, timelines ...MarkerTimeline) (map[MarkerTimeline]Marker, error)
+
+	// SaveMarker records lastReadID as the newest read post in timeline.
+	SaveMarker(ctx context.Context, timeline MarkerTimeline, lastReadID string) (Marker, error)
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// Notification is one entry of the user's notifications timeline.
+type Notification struct {
+	ID        string
+	Type      string // mention, reblog, favourite, follow, follow_request, poll, update, ...
+	Account   AccountSummary
+	Rant      *domain.Rant // The post it is about; nil for follows
+	CreatedAt time.Time
+}
+
+// NotificationService reads the authenticated user's notifications.
+type NotificationService interface {
+	// Notifications returns a page of notifications, newest first, and the
+	// maxID of the next page ("" when exhausted).
+	Notifications(ctx context.Context, limit int, maxID string) ([]Notification, string, error)
+}
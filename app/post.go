@@ -8,21 +8,46 @@ import (
 
 // PostService publishes, edits, and deletes rants on a social backend.
 type PostService interface {
-	// Post publishes a new rant with the given content and hashtag.
-	Post(ctx context.Context, content string, hashtag string) (domain.Rant, error)
+	// Post publishes a new rant. hashtag lists the tags (comma or space
+	// separated, '#' optional) appended when the content lacks them; empty
+	// appends none.
+	Post(ctx context.Context, content string, hashtag string, opts PostOptions) (domain.Rant, error)
 
-	// Edit updates an existing rant's content.
+	// Edit updates an existing rant's content, appending hashtag as Post does.
 	Edit(ctx context.Context, id string, content string, hashtag string) (domain.Rant, error)
 
 	// Delete removes a rant by ID.
 	Delete(ctx context.Context, id string) error
 
+	// Source returns the text a rant was written with, and the settings it
+	// was posted with, for redrafting.
+	Source(ctx context.Context, id string) (StatusSource, error)
+
 	// Like toggles the favorite status of a rant.
 	Like(ctx context.Context, id string) error
 
 	// Unlike removes the favorite status of a rant.
 	Unlike(ctx context.Context, id string) error
 
-	// Reply publishes a new rant as a reply to another.
-	Reply(ctx context.Context, parentID string, content string, hashtag string) (domain.Rant, error)
+	// Reply publishes a new rant as a reply to another, appending hashtag as
+	// Post does.
+	Reply(ctx context.Context, parentID string, content string, hashtag string, opts PostOptions) (domain.Rant, error)
+}
+
+// PostOptions are what a redrafted rant keeps from the one it replaces. The
+// zero value posts with the configured visibility, no content warning and no
+// media.
+type PostOptions struct {
+	Visibility  string   // public, unlisted, private or direct; empty for the default
+	SpoilerText string   // Content warning
+	MediaIDs    []string // Attachments already on the server
+}
+
+// StatusSource is the plain text behind a published rant.
+type StatusSource struct {
+	ID          string
+	Text        string
+	SpoilerText string // Content warning; empty when there is none
+	Visibility  string
+	MediaIDs    []string
 }
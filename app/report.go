@@ -0,0 +1,43 @@
+package app
+
+import "context"
+
+// ReportCategory is why an account is reported to the moderators.
+type ReportCategory string
+
+const (
+	ReportSpam      ReportCategory = "spam"
+	ReportLegal     ReportCategory = "legal"
+	ReportViolation ReportCategory = "violation" // breaks the instance rules in RuleIDs
+	ReportOther     ReportCategory = "other"
+)
+
+// ReportCategories lists the categories in the order they are offered.
+var ReportCategories = []ReportCategory{ReportSpam, ReportLegal, ReportViolation, ReportOther}
+
+// Rule is one of the instance rules a report can cite.
+type Rule struct {
+	ID   string
+	Text string
+	Hint string
+}
+
+// Report is a report of an account, and optionally some of its rants, to the
+// moderators of the instance.
+type Report struct {
+	AccountID string
+	StatusIDs []string
+	Category  ReportCategory
+	RuleIDs   []string // only sent for ReportViolation
+	Comment   string
+	Forward   bool // also send the report to the account's remote instance
+}
+
+// ReportService files moderation reports.
+type ReportService interface {
+	// Rules returns the rules of the instance, for violation reports.
+	Rules(ctx context.Context) ([]Rule, error)
+
+	// Report files a report with the instance moderators.
+	Report(ctx context.Context, r Report) error
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+)
+
+// ScheduledPost is a rant queued on the server for later publication.
+type ScheduledPost struct {
+	ID          string
+	ScheduledAt time.Time
+	Content     string
+	InReplyToID string
+	MediaCount  int
+}
+
+// ScheduleService manages posts that publish at a future time.
+type ScheduleService interface {
+	// Schedule queues a new rant (or a reply when inReplyToID is set),
+	// appending hashtag and applying opts as PostService.Post does.
+	Schedule(ctx context.Context, content, hashtag, inReplyToID string, at time.Time, opts PostOptions) (ScheduledPost, error)
+
+	// ListScheduled returns a page of pending scheduled posts after maxID
+	// (empty for the first page), soonest first, and the maxID of the next
+	// page, or "" on the last.
+	ListScheduled(ctx context.Context, limit int, maxID string) ([]ScheduledPost, string, error)
+
+	// Reschedule moves a scheduled post to a new time.
+	Reschedule(ctx context.Context, id string, at time.Time) (ScheduledPost, error)
+
+	// CancelScheduled deletes a scheduled post before it publishes.
+	CancelScheduled(ctx context.Context, id string) error
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound means a URL resolved to nothing the user's instance knows.
+var ErrNotFound = errors.New("not found on your instance")
+
+// Suggestion is a completion candidate for a mention or a hashtag.
+type Suggestion struct {
+	Value string // Text to insert, with its sigil: "@alice@example.social", "#golang"
+	Label string // Extra context such as a display name or recent usage
+}
+
+// SearchService looks up accounts and hashtags for autocompletion.
+type SearchService interface {
+	// SearchAccounts returns accounts whose handle or name starts with query.
+	SearchAccounts(ctx context.Context, query string, limit int) ([]Suggestion, error)
+
+	// SearchHashtags returns hashtags that start with query.
+	SearchHashtags(ctx context.Context, query string, limit int) ([]Suggestion, error)
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// Tag is a hashtag and whether the user follows it, so that its posts land
+// in the home timeline.
+type Tag struct {
+	Name      string // without the leading #
+	Following bool
+}
+
+// TagMode selects which hashtags are appended to outgoing posts.
+type TagMode string
+
+const (
+	// TagsNone appends nothing.
+	TagsNone TagMode = "none"
+	// TagsActive appends the active custom hashtag.
+	TagsActive TagMode = "active"
+	// TagsList appends a fixed list of hashtags.
+	TagsList TagMode = "list"
+)
+
+// TagPolicy decides the hashtags added to posts, replies and edits of an
+// account. Tags already in the text are never added twice.
+type TagPolicy struct {
+	Mode TagMode
+	Tags []string // "#tag" form; used by TagsList
+	// InheritReplyTags adds the hashtags of the rant being replied to.
+	InheritReplyTags bool
+}
+
+// DefaultTagPolicy appends #terminalrant to every post.
+func DefaultTagPolicy() TagPolicy {
+	return TagPolicy{Mode: TagsList, Tags: []string{domain.AppHashTag}}
+}
+
+// ParseTagPolicy parses "none", "active" or a comma/space separated list of
+// hashtags such as "terminalrant, #golang".
+func ParseTagPolicy(s string) (TagPolicy, error) {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case string(TagsNone), string(TagsActive):
+		return TagPolicy{Mode: TagMode(v)}, nil
+	case "":
+		return TagPolicy{}, fmt.Errorf("empty tag policy: use none, active or a list of hashtags")
+	}
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		if len(domain.ParseHashtags(f)) == 0 {
+			return TagPolicy{}, fmt.Errorf("invalid hashtag %q in tag policy", f)
+		}
+	}
+	return TagPolicy{Mode: TagsList, Tags: domain.ParseHashtags(s)}, nil
+}
+
+// String returns the policy in the form ParseTagPolicy accepts.
+func (p TagPolicy) String() string {
+	if p.Mode == TagsList {
+		return strings.Join(p.Tags, ",")
+	}
+	return string(p.Mode)
+}
+
+// TagsFor returns the hashtags to add to a post. active is the active
+// custom hashtag, with or without '#'; parentContent is the text of the rant
+// being replied to, or "" for new posts and edits.
+func (p TagPolicy) TagsFor(active, parentContent string) []string {
+	var tags []string
+	switch p.Mode {
+	case TagsActive:
+		tags = domain.ParseHashtags(active)
+	case TagsList:
+		tags = append(tags, p.Tags...)
+	}
+	if p.InheritReplyTags && parentContent != "" {
+		tags = domain.ParseHashtags(strings.Join(append(tags, domain.HashtagsIn(parentContent)...), " "))
+	}
+	return tags
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
@@ -17,12 +18,72 @@ type TimelineService interface {
 	// FetchHomePage returns a page from the authenticated home timeline.
 	FetchHomePage(ctx context.Context, limit int, maxID string) ([]domain.Rant, error)
 
-	// FetchPublicPage returns a page from the public timeline.
+	// FetchPublicPage returns a page from the federated public timeline.
 	FetchPublicPage(ctx context.Context, limit int, maxID string) ([]domain.Rant, error)
 
+	// FetchLocalPage returns a page from the public timeline of posts made
+	// on the instance itself.
+	FetchLocalPage(ctx context.Context, limit int, maxID string) ([]domain.Rant, error)
+
 	// FetchTrendingPage returns trending posts.
 	FetchTrendingPage(ctx context.Context, limit int, maxID string) ([]domain.Rant, error)
 
+	// FetchBookmarksPage returns a page of the user's bookmarks and the maxID
+	// of the next page ("" when exhausted).
+	FetchBookmarksPage(ctx context.Context, limit int, maxID string) ([]domain.Rant, string, error)
+
+	// FetchFavouritesPage returns a page of the user's favourites and the
+	// maxID of the next page ("" when exhausted).
+	FetchFavouritesPage(ctx context.Context, limit int, maxID string) ([]domain.Rant, string, error)
+
+	// FetchStatus returns a single rant by ID.
+	FetchStatus(ctx context.Context, id string) (domain.Rant, error)
+
+	// ResolveStatus returns the instance's own copy of a post on any server,
+	// given the post's URL, fetching it over federation when needed. It
+	// fails with ErrNotFound when the URL is not a post.
+	ResolveStatus(ctx context.Context, statusURL string) (domain.Rant, error)
+
 	// FetchThread returns the context of a rant (ancestors and replies).
 	FetchThread(ctx context.Context, id string) (ancestors, descendants []domain.Rant, err error)
+
+	// FetchHistory returns the revisions of a rant, oldest first. The last
+	// revision is the current text; an unedited rant has just one.
+	FetchHistory(ctx context.Context, id string) ([]Revision, error)
+
+	// FavouritedBy returns a page of the accounts that liked a rant and the
+	// maxID of the next page ("" when exhausted).
+	FavouritedBy(ctx context.Context, id string, limit int, maxID string) ([]AccountSummary, string, error)
+
+	// RebloggedBy returns a page of the accounts that boosted a rant and the
+	// maxID of the next page ("" when exhausted).
+	RebloggedBy(ctx context.Context, id string, limit int, maxID string) ([]AccountSummary, string, error)
+
+	// TrendingTags returns the hashtags trending on the instance.
+	TrendingTags(ctx context.Context, limit int) ([]TrendingTag, error)
+
+	// TrendingLinks returns the links trending on the instance.
+	TrendingLinks(ctx context.Context, limit int) ([]TrendingLink, error)
+}
+
+// TrendingTag is a hashtag with its recent daily usage.
+type TrendingTag struct {
+	Name     string // without the leading #
+	Uses     []int  // posts per day, oldest first
+	Accounts int    // distinct accounts over the whole history
+}
+
+// TrendingLink is a link many posts are sharing.
+type TrendingLink struct {
+	URL         string
+	Title       string
+	Description string
+	Provider    string
+	Uses        []int // posts per day, oldest first
+}
+
+// Revision is one version of an edited rant.
+type Revision struct {
+	Content   string // Plain text, HTML stripped
+	CreatedAt time.Time
 }
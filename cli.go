@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/infra/archive"
+	"github.com/CrestNiraj12/terminalrant/infra/format"
+)
+
+// Exit codes for scripting subcommands.
+const (
+	exitOK    = 0
+	exitError = 1 // the request failed (network, API, auth)
+	exitUsage = 2 // bad arguments; nothing was sent
+)
+
+const (
+	defaultTimelineLimit = 20
+	maxTimelineLimit     = 200
+	timelinePageSize     = 40 // Mastodon caps a single timeline page at 40.
+)
+
+// cliServices are the app services a subcommand may use.
+type cliServices struct {
+	Timeline app.TimelineService
+	Post     app.PostService
+	Account  app.AccountService
+	Instance app.InstanceService // optional; stock limits apply without it
+	Search   app.SearchService
+	Tags     app.TagPolicy // zero value means app.DefaultTagPolicy
+	Hashtag  string
+}
+
+// postTags returns the hashtags the tag policy adds to a post, fetching the
+// parent rant when a reply inherits its tags.
+func (svc cliServices) postTags(ctx context.Context, parentID string) ([]string, error) {
+	policy := svc.Tags
+	if policy.Mode == "" {
+		policy = app.DefaultTagPolicy()
+	}
+	parentContent := ""
+	if parentID != "" && policy.InheritReplyTags {
+		parent, err := svc.Timeline.FetchStatus(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching parent rant: %w", err)
+		}
+		parentContent = parent.Content
+	}
+	return policy.TagsFor(svc.Hashtag, parentContent), nil
+}
+
+// checkLength rejects content that does not fit the instance limit, so a
+// long message fails before anything is sent.
+func (svc cliServices) checkLength(ctx context.Context, content string, tags []string) error {
+	limits := app.DefaultInstanceConfig()
+	if svc.Instance != nil {
+		// On failure the service still returns cached or default limits.
+		limits, _ = svc.Instance.InstanceConfig(ctx)
+	}
+	return limits.CheckLength(content, tags)
+}
+
+// cliInput is where subcommands read message bodies from.
+type cliInput struct {
+	Stdin       io.Reader
+	StdinIsTerm bool
+	DefaultTag  string
+}
+
+// cliAction performs a parsed subcommand and writes its result to out.
+type cliAction func(ctx context.Context, svc cliServices, out io.Writer) error
+
+type subcommand struct {
+	name    string
+	args    string
+	summary string
+	parse   func(fs *flag.FlagSet, args []string, in cliInput) (cliAction, error)
+}
+
+var subcommands = []subcommand{
+	{name: "post", args: "[-m text]", summary: "publish a rant from -m or stdin", parse: parsePostCmd},
+	{name: "reply", args: "<id> [-m text]", summary: "reply to a rant from -m or stdin", parse: parseReplyCmd},
+	{name: "timeline", args: "[--tag X] [--home] [--limit N] [--format F]", summary: "print recent rants", parse: parseTimelineCmd},
+	{name: "thread", args: "<id> [--format F]", summary: "print a rant with its ancestors and replies", parse: parseThreadCmd},
+	{name: "profile", args: "[<account-id>] [--limit N] [--format F]", summary: "print an account's rants (default: yours)", parse: parseProfileCmd},
+	{name: "export", args: "[--dir PATH] [--bookmarks] [--favourites] [--no-media]", summary: "back up your rants to a local archive", parse: parseExportCmd},
+	{name: "whoami", args: "", summary: "print the signed-in account", parse: parseWhoamiCmd},
+	{name: "complete", args: "<@prefix|#prefix> [--limit N]", summary: "print mention or hashtag completions for editors", parse: parseCompleteCmd},
+}
+
+func findSubcommand(name string) (subcommand, bool) {
+	for _, c := range subcommands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return subcommand{}, false
+}
+
+// usageError marks argument problems so they map to exitUsage.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string { return e.msg }
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// parseSubcommand parses args (starting with the subcommand name) into an
+// action. Argument errors are returned as usageError.
+func parseSubcommand(args []string, in cliInput, errOut io.Writer) (cliAction, error) {
+	if len(args) == 0 {
+		return nil, usageErrorf("missing subcommand")
+	}
+	cmd, ok := findSubcommand(args[0])
+	if !ok {
+		return nil, usageErrorf("unknown subcommand: %s", args[0])
+	}
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.Usage = func() {
+		fmt.Fprintf(errOut, "Usage: terminalrant %s %s\n", cmd.name, cmd.args)
+		fs.PrintDefaults()
+	}
+	action, err := cmd.parse(fs, args[1:], in)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		var ue usageError
+		if !errors.As(err, &ue) {
+			err = usageError{msg: err.Error()}
+		}
+		return nil, err
+	}
+	return action, nil
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments, e.g. `reply 123 -m hi` as well as `reply -m hi 123`.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// formatFlag registers the shared --format flag.
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", string(format.Text), "output format: "+strings.Join(format.Names, ", "))
+}
+
+// limitFlag registers the shared --limit flag.
+func limitFlag(fs *flag.FlagSet) *int {
+	return fs.Int("limit", defaultTimelineLimit, fmt.Sprintf("number of rants (1-%d)", maxTimelineLimit))
+}
+
+func checkLimit(limit int) error {
+	if limit < 1 || limit > maxTimelineLimit {
+		return usageErrorf("--limit must be between 1 and %d", maxTimelineLimit)
+	}
+	return nil
+}
+
+// readMessage returns the -m value or, when empty, the whole of stdin.
+func readMessage(flagValue string, in cliInput) (string, error) {
+	msg := strings.TrimSpace(flagValue)
+	if msg != "" {
+		return msg, nil
+	}
+	if in.Stdin == nil || in.StdinIsTerm {
+		return "", usageErrorf("nothing to post: pass -m or pipe text on stdin")
+	}
+	data, err := io.ReadAll(in.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("reading stdin: %w", err)
+	}
+	msg = strings.TrimSpace(string(data))
+	if msg == "" {
+		return "", usageErrorf("nothing to post: stdin was empty")
+	}
+	return msg, nil
+}
+
+func parsePostCmd(fs *flag.FlagSet, args []string, in cliInput) (cliAction, error) {
+	message := fs.String("m", "", "rant text (reads stdin when omitted)")
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, usageErrorf("unexpected argument: %s", strings.Join(rest, " "))
+	}
+	content, err := readMessage(*message, in)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		tags, err := svc.postTags(ctx, "")
+		if err != nil {
+			return err
+		}
+		if err := svc.checkLength(ctx, content, tags); err != nil {
+			return err
+		}
+		r, err := svc.Post.Post(ctx, content, strings.Join(tags, " "), app.PostOptions{})
+		if err != nil {
+			return err
+		}
+		printPosted(out, r)
+		return nil
+	}, nil
+}
+
+func parseReplyCmd(fs *flag.FlagSet, args []string, in cliInput) (cliAction, error) {
+	message := fs.String("m", "", "reply text (reads stdin when omitted)")
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 1 {
+		return nil, usageErrorf("reply needs exactly one rant id")
+	}
+	parentID := rest[0]
+	content, err := readMessage(*message, in)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		tags, err := svc.postTags(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if err := svc.checkLength(ctx, content, tags); err != nil {
+			return err
+		}
+		r, err := svc.Post.Reply(ctx, parentID, content, strings.Join(tags, " "), app.PostOptions{})
+		if err != nil {
+			return err
+		}
+		printPosted(out, r)
+		return nil
+	}, nil
+}
+
+func parseTimelineCmd(fs *flag.FlagSet, args []string, in cliInput) (cliAction, error) {
+	tag := fs.String("tag", in.DefaultTag, "hashtag to read")
+	home := fs.Bool("home", false, "read the home timeline instead of a hashtag")
+	limit := limitFlag(fs)
+	formatName := formatFlag(fs)
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, usageErrorf("unexpected argument: %s", strings.Join(rest, " "))
+	}
+	if err := checkLimit(*limit); err != nil {
+		return nil, err
+	}
+	outFormat, err := format.Parse(*formatName)
+	if err != nil {
+		return nil, err
+	}
+	hashtag := strings.TrimPrefix(strings.TrimSpace(*tag), "#")
+	if !*home && hashtag == "" {
+		return nil, usageErrorf("--tag cannot be empty")
+	}
+	n := *limit
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		fetch := func(pageSize int, maxID string) ([]domain.Rant, error) {
+			if *home {
+				return svc.Timeline.FetchHomePage(ctx, pageSize, maxID)
+			}
+			return svc.Timeline.FetchByHashtagPage(ctx, hashtag, pageSize, maxID)
+		}
+		rants, err := fetchUpTo(n, fetch)
+		if err != nil {
+			return err
+		}
+		return format.WriteRants(out, outFormat, rants)
+	}, nil
+}
+
+// fetchUpTo pages through a timeline until n rants are collected or the
+// timeline runs out.
+func fetchUpTo(n int, fetch func(limit int, maxID string) ([]domain.Rant, error)) ([]domain.Rant, error) {
+	var out []domain.Rant
+	maxID := ""
+	for len(out) < n {
+		page, err := fetch(min(timelinePageSize, n-len(out)), maxID)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		out = append(out, page...)
+		maxID = page[len(page)-1].ID
+	}
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out, nil
+}
+
+func parseThreadCmd(fs *flag.FlagSet, args []string, _ cliInput) (cliAction, error) {
+	formatName := formatFlag(fs)
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 1 {
+		return nil, usageErrorf("thread needs exactly one rant id")
+	}
+	outFormat, err := format.Parse(*formatName)
+	if err != nil {
+		return nil, err
+	}
+	id := rest[0]
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		root, err := svc.Timeline.FetchStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		ancestors, descendants, err := svc.Timeline.FetchThread(ctx, id)
+		if err != nil {
+			return err
+		}
+		thread := make([]domain.Rant, 0, len(ancestors)+1+len(descendants))
+		thread = append(thread, ancestors...)
+		thread = append(thread, root)
+		thread = append(thread, descendants...)
+		return format.WriteRants(out, outFormat, thread)
+	}, nil
+}
+
+func parseProfileCmd(fs *flag.FlagSet, args []string, _ cliInput) (cliAction, error) {
+	limit := limitFlag(fs)
+	formatName := formatFlag(fs)
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 1 {
+		return nil, usageErrorf("profile takes at most one account id")
+	}
+	if err := checkLimit(*limit); err != nil {
+		return nil, err
+	}
+	outFormat, err := format.Parse(*formatName)
+	if err != nil {
+		return nil, err
+	}
+	accountID := ""
+	if len(rest) == 1 {
+		accountID = rest[0]
+	}
+	n := *limit
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		id := accountID
+		if id == "" {
+			current, err := svc.Account.CurrentAccountID(ctx)
+			if err != nil {
+				return err
+			}
+			id = current
+		}
+		rants, err := fetchUpTo(n, func(pageSize int, maxID string) ([]domain.Rant, error) {
+			return svc.Account.PostsByAccount(ctx, id, pageSize, maxID)
+		})
+		if err != nil {
+			return err
+		}
+		return format.WriteRants(out, outFormat, rants)
+	}, nil
+}
+
+func parseExportCmd(fs *flag.FlagSet, args []string, _ cliInput) (cliAction, error) {
+	dir := fs.String("dir", "terminalrant-archive", "archive directory (re-runs resume into it)")
+	bookmarks := fs.Bool("bookmarks", false, "also export bookmarks")
+	favourites := fs.Bool("favourites", false, "also export favourites")
+	noMedia := fs.Bool("no-media", false, "skip downloading media attachments")
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, usageErrorf("unexpected argument: %s", strings.Join(rest, " "))
+	}
+	if strings.TrimSpace(*dir) == "" {
+		return nil, usageErrorf("--dir cannot be empty")
+	}
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		accountID, err := svc.Account.CurrentAccountID(ctx)
+		if err != nil {
+			return err
+		}
+		sources := []archive.Source{{
+			Name: archive.Posts,
+			Fetch: func(ctx context.Context, limit int, maxID string) ([]domain.Rant, string, error) {
+				rants, err := svc.Account.PostsByAccount(ctx, accountID, limit, maxID)
+				if err != nil || len(rants) == 0 {
+					return rants, "", err
+				}
+				return rants, rants[len(rants)-1].ID, nil
+			},
+		}}
+		if *bookmarks {
+			sources = append(sources, archive.Source{Name: archive.Bookmarks, Fetch: svc.Timeline.FetchBookmarksPage})
+		}
+		if *favourites {
+			sources = append(sources, archive.Source{Name: archive.Favourites, Fetch: svc.Timeline.FetchFavouritesPage})
+		}
+		exp := &archive.Exporter{Dir: *dir, Progress: out, NoMedia: *noMedia}
+		stats, err := exp.Run(ctx, sources)
+		if err != nil {
+			return err
+		}
+		if !*noMedia {
+			fmt.Fprintf(out, "media: %d downloaded, %d already saved, %d failed\n", stats.MediaSaved, stats.MediaSkipped, stats.MediaFailed)
+		}
+		fmt.Fprintf(out, "archive written to %s\n", *dir)
+		return nil
+	}, nil
+}
+
+func parseWhoamiCmd(fs *flag.FlagSet, args []string, _ cliInput) (cliAction, error) {
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, usageErrorf("unexpected argument: %s", strings.Join(rest, " "))
+	}
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		p, err := svc.Account.CurrentProfile(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "@%s", p.Username)
+		if p.DisplayName != "" && p.DisplayName != p.Username {
+			fmt.Fprintf(out, " (%s)", p.DisplayName)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "id: %s\n", p.ID)
+		if p.URL != "" {
+			fmt.Fprintf(out, "url: %s\n", p.URL)
+		}
+		return nil
+	}, nil
+}
+
+// maxCompletions caps `complete --limit`; the search endpoints return at
+// most 40 results.
+const maxCompletions = 40
+
+// parseCompleteCmd prints one completion per line as "value<TAB>label", the
+// hook $EDITOR setups use for @mention and #hashtag completion.
+func parseCompleteCmd(fs *flag.FlagSet, args []string, _ cliInput) (cliAction, error) {
+	limit := fs.Int("limit", 8, fmt.Sprintf("number of completions (1-%d)", maxCompletions))
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 1 {
+		return nil, usageErrorf("complete needs exactly one @ or # prefix")
+	}
+	prefix := rest[0]
+	if len(prefix) < 2 || (prefix[0] != '@' && prefix[0] != '#') {
+		return nil, usageErrorf("prefix must start with @ or # and name something: %q", prefix)
+	}
+	if *limit < 1 || *limit > maxCompletions {
+		return nil, usageErrorf("--limit must be between 1 and %d", maxCompletions)
+	}
+	n := *limit
+	return func(ctx context.Context, svc cliServices, out io.Writer) error {
+		var (
+			items []app.Suggestion
+			err   error
+		)
+		if prefix[0] == '@' {
+			items, err = svc.Search.SearchAccounts(ctx, prefix[1:], n)
+		} else {
+			items, err = svc.Search.SearchHashtags(ctx, prefix[1:], n)
+		}
+		if err != nil {
+			return err
+		}
+		for _, it := range items {
+			if it.Label == "" {
+				fmt.Fprintln(out, it.Value)
+				continue
+			}
+			fmt.Fprintf(out, "%s\t%s\n", it.Value, it.Label)
+		}
+		return nil
+	}, nil
+}
+
+// printPosted writes the new rant's ID and URL on one tab-separated line so
+// scripts can pick up the ID for a follow-up reply.
+func printPosted(out io.Writer, r domain.Rant) {
+	if r.URL != "" {
+		fmt.Fprintf(out, "%s\t%s\n", r.ID, r.URL)
+		return
+	}
+	fmt.Fprintln(out, r.ID)
+}
+
+// subcommandUsage lists the scripting subcommands for the top-level help.
+func subcommandUsage() string {
+	var b strings.Builder
+	for _, c := range subcommands {
+		line := strings.TrimSpace(c.name + " " + c.args)
+		b.WriteString(usageLine(line, c.summary))
+	}
+	return b.String()
+}
+
+const usageColumn = 52
+
+func usageLine(cmd, summary string) string {
+	if len(cmd) > usageColumn {
+		return fmt.Sprintf("\n       terminalrant %s\n       %s %s", cmd, strings.Repeat(" ", usageColumn+len("terminalrant ")), summary)
+	}
+	return fmt.Sprintf("\n       terminalrant %-*s %s", usageColumn, cmd, summary)
+}
+
+// runSubcommand executes a parsed action and maps the outcome to an exit code.
+func runSubcommand(ctx context.Context, name string, action cliAction, svc cliServices, out, errOut io.Writer) int {
+	if err := action(ctx, svc, out); err != nil {
+		fmt.Fprintf(errOut, "terminalrant %s: %v\n", name, err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+type fakePostService struct {
+	app.PostService
+	gotContent string
+	gotParent  string
+	gotHashtag string
+	err        error
+}
+
+func (f *fakePostService) Post(_ context.Context, content, hashtag string, _ app.PostOptions) (domain.Rant, error) {
+	f.gotContent = content
+	f.gotHashtag = hashtag
+	return domain.Rant{ID: "101", URL: "https://example/101"}, f.err
+}
+
+func (f *fakePostService) Reply(_ context.Context, parentID, content, hashtag string, _ app.PostOptions) (domain.Rant, error) {
+	f.gotParent = parentID
+	f.gotContent = content
+	f.gotHashtag = hashtag
+	return domain.Rant{ID: "102"}, f.err
+}
+
+type fakeTimelineService struct {
+	app.TimelineService
+	pages  [][]domain.Rant
+	maxIDs []string
+	limits []int
+	status domain.Rant
+}
+
+func (f *fakeTimelineService) FetchStatus(_ context.Context, id string) (domain.Rant, error) {
+	return f.status, nil
+}
+
+func (f *fakeTimelineService) FetchByHashtagPage(_ context.Context, _ string, limit int, maxID string) ([]domain.Rant, error) {
+	f.maxIDs = append(f.maxIDs, maxID)
+	f.limits = append(f.limits, limit)
+	if len(f.pages) == 0 {
+		return nil, nil
+	}
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page, nil
+}
+
+func TestParseSubcommand_PostReadsFlagOrStdin(t *testing.T) {
+	post := &fakePostService{}
+	svc := cliServices{Post: post}
+
+	action, err := parseSubcommand([]string{"post", "-m", "  from flag  "}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var out bytes.Buffer
+	if code := runSubcommand(context.Background(), "post", action, svc, &out, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if post.gotContent != "from flag" {
+		t.Fatalf("unexpected content: %q", post.gotContent)
+	}
+	if out.String() != "101\thttps://example/101\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	action, err = parseSubcommand([]string{"post"}, cliInput{Stdin: strings.NewReader("piped rant\n")}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse stdin failed: %v", err)
+	}
+	_ = runSubcommand(context.Background(), "post", action, svc, &bytes.Buffer{}, &bytes.Buffer{})
+	if post.gotContent != "piped rant" {
+		t.Fatalf("unexpected stdin content: %q", post.gotContent)
+	}
+}
+
+func TestParseSubcommand_ReplyAcceptsFlagsAfterID(t *testing.T) {
+	post := &fakePostService{}
+	action, err := parseSubcommand([]string{"reply", "55", "-m", "me too"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	_ = runSubcommand(context.Background(), "reply", action, cliServices{Post: post}, &bytes.Buffer{}, &bytes.Buffer{})
+	if post.gotParent != "55" || post.gotContent != "me too" {
+		t.Fatalf("unexpected reply call: parent=%q content=%q", post.gotParent, post.gotContent)
+	}
+}
+
+func TestPostAndReply_FollowTagPolicy(t *testing.T) {
+	post := &fakePostService{}
+	timeline := &fakeTimelineService{status: domain.Rant{ID: "55", Content: "tests are red #ci #golang"}}
+	svc := cliServices{Post: post, Timeline: timeline, Hashtag: "rust"}
+
+	action, _ := parseSubcommand([]string{"post", "-m", "hi"}, cliInput{}, &bytes.Buffer{})
+	_ = runSubcommand(context.Background(), "post", action, svc, &bytes.Buffer{}, &bytes.Buffer{})
+	if post.gotHashtag != domain.AppHashTag {
+		t.Fatalf("expected default policy tags, got %q", post.gotHashtag)
+	}
+
+	svc.Tags = app.TagPolicy{Mode: app.TagsActive, InheritReplyTags: true}
+	action, _ = parseSubcommand([]string{"reply", "55", "-m", "same"}, cliInput{}, &bytes.Buffer{})
+	_ = runSubcommand(context.Background(), "reply", action, svc, &bytes.Buffer{}, &bytes.Buffer{})
+	if post.gotHashtag != "#rust #ci #golang" {
+		t.Fatalf("expected active tag plus inherited tags, got %q", post.gotHashtag)
+	}
+
+	svc.Tags = app.TagPolicy{Mode: app.TagsNone}
+	action, _ = parseSubcommand([]string{"post", "-m", "work"}, cliInput{}, &bytes.Buffer{})
+	_ = runSubcommand(context.Background(), "post", action, svc, &bytes.Buffer{}, &bytes.Buffer{})
+	if post.gotHashtag != "" {
+		t.Fatalf("expected no tags, got %q", post.gotHashtag)
+	}
+}
+
+type fakeInstanceService struct {
+	cfg app.InstanceConfig
+}
+
+func (f fakeInstanceService) InstanceConfig(context.Context) (app.InstanceConfig, error) {
+	return f.cfg, nil
+}
+
+func TestPostCmd_RejectsRantOverInstanceLimit(t *testing.T) {
+	post := &fakePostService{}
+	limits := app.DefaultInstanceConfig()
+	limits.MaxCharacters = 30
+	svc := cliServices{Post: post, Instance: fakeInstanceService{cfg: limits}}
+
+	action, err := parseSubcommand([]string{"post", "-m", "this rant is a little too long"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var errOut bytes.Buffer
+	if code := runSubcommand(context.Background(), "post", action, svc, &bytes.Buffer{}, &errOut); code == exitOK {
+		t.Fatalf("expected over-limit post to fail")
+	}
+	if post.gotContent != "" {
+		t.Fatalf("post service should not be called, got %q", post.gotContent)
+	}
+	if !strings.Contains(errOut.String(), domain.ErrRantTooLong.Error()) {
+		t.Fatalf("expected too-long error, got %q", errOut.String())
+	}
+}
+
+type fakeSearchService struct {
+	gotQuery string
+}
+
+func (f *fakeSearchService) SearchAccounts(_ context.Context, query string, _ int) ([]app.Suggestion, error) {
+	f.gotQuery = query
+	return []app.Suggestion{{Value: "@alice@example.social", Label: "Alice"}}, nil
+}
+
+func (f *fakeSearchService) SearchHashtags(_ context.Context, query string, _ int) ([]app.Suggestion, error) {
+	f.gotQuery = query
+	return []app.Suggestion{{Value: "#golang"}}, nil
+}
+
+func TestCompleteCmd_PrintsSuggestionsForEditors(t *testing.T) {
+	search := &fakeSearchService{}
+	svc := cliServices{Search: search}
+
+	action, err := parseSubcommand([]string{"complete", "@ali"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var out bytes.Buffer
+	if code := runSubcommand(context.Background(), "complete", action, svc, &out, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if search.gotQuery != "ali" || out.String() != "@alice@example.social\tAlice\n" {
+		t.Fatalf("unexpected completion: query=%q out=%q", search.gotQuery, out.String())
+	}
+
+	action, _ = parseSubcommand([]string{"complete", "--limit", "3", "#go"}, cliInput{}, &bytes.Buffer{})
+	out.Reset()
+	_ = runSubcommand(context.Background(), "complete", action, svc, &out, &bytes.Buffer{})
+	if out.String() != "#golang\n" {
+		t.Fatalf("unexpected hashtag completion: %q", out.String())
+	}
+
+	for _, bad := range [][]string{{"complete"}, {"complete", "ali"}, {"complete", "#"}} {
+		if _, err := parseSubcommand(bad, cliInput{}, &bytes.Buffer{}); err == nil {
+			t.Fatalf("expected usage error for %v", bad)
+		}
+	}
+}
+
+func TestParseSubcommand_UsageErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		in   cliInput
+	}{
+		{name: "post without input on terminal", args: []string{"post"}, in: cliInput{Stdin: strings.NewReader("x"), StdinIsTerm: true}},
+		{name: "post with empty stdin", args: []string{"post"}, in: cliInput{Stdin: strings.NewReader("  \n")}},
+		{name: "reply missing id", args: []string{"reply", "-m", "hi"}},
+		{name: "thread extra args", args: []string{"thread", "1", "2"}},
+		{name: "timeline bad limit", args: []string{"timeline", "--limit", "0"}, in: cliInput{DefaultTag: "terminalrant"}},
+		{name: "timeline unknown flag", args: []string{"timeline", "--bogus"}},
+		{name: "timeline bad format", args: []string{"timeline", "--format", "yaml"}, in: cliInput{DefaultTag: "terminalrant"}},
+		{name: "profile too many ids", args: []string{"profile", "1", "2"}},
+		{name: "whoami extra arg", args: []string{"whoami", "me"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseSubcommand(tc.args, tc.in, &bytes.Buffer{})
+			var ue usageError
+			if !errors.As(err, &ue) {
+				t.Fatalf("expected usage error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTimelineSubcommand_PagesUntilLimit(t *testing.T) {
+	page := func(ids ...string) []domain.Rant {
+		out := make([]domain.Rant, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, domain.Rant{ID: id, Username: "u" + id, Content: "rant " + id})
+		}
+		return out
+	}
+	timeline := &fakeTimelineService{pages: [][]domain.Rant{page("9", "8"), page("7", "6")}}
+
+	action, err := parseSubcommand([]string{"timeline", "--tag", "#go", "--limit", "3"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var out bytes.Buffer
+	if code := runSubcommand(context.Background(), "timeline", action, cliServices{Timeline: timeline}, &out, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if got := strings.Join(timeline.maxIDs, ","); got != ",8" {
+		t.Fatalf("unexpected paging max_ids: %q", got)
+	}
+	if strings.Contains(out.String(), "rant 6") || !strings.Contains(out.String(), "rant 7") {
+		t.Fatalf("expected output trimmed to limit, got %q", out.String())
+	}
+}
+
+func TestTimelineSubcommand_NDJSONFormat(t *testing.T) {
+	timeline := &fakeTimelineService{pages: [][]domain.Rant{{{ID: "9", Username: "u9"}, {ID: "8", Username: "u8"}}}}
+
+	action, err := parseSubcommand([]string{"timeline", "--format", "ndjson", "--limit", "2"}, cliInput{DefaultTag: "terminalrant"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var out bytes.Buffer
+	if code := runSubcommand(context.Background(), "timeline", action, cliServices{Timeline: timeline}, &out, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"schema_version":1,"id":"9"`) {
+		t.Fatalf("unexpected ndjson output: %q", out.String())
+	}
+}
+
+type fakeAccountService struct {
+	app.AccountService
+	posts []domain.Rant
+}
+
+func (f *fakeAccountService) CurrentAccountID(context.Context) (string, error) { return "me", nil }
+
+func (f *fakeAccountService) PostsByAccount(_ context.Context, accountID string, _ int, maxID string) ([]domain.Rant, error) {
+	if accountID != "me" || maxID != "" {
+		return nil, nil
+	}
+	return f.posts, nil
+}
+
+func TestExportSubcommand_WritesArchive(t *testing.T) {
+	dir := t.TempDir()
+	account := &fakeAccountService{posts: []domain.Rant{{ID: "2", Content: "second"}, {ID: "1", Content: "first"}}}
+
+	action, err := parseSubcommand([]string{"export", "--dir", dir, "--no-media"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var out bytes.Buffer
+	if code := runSubcommand(context.Background(), "export", action, cliServices{Account: account}, &out, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "posts: 2 rants (2 new)") {
+		t.Fatalf("unexpected progress output: %q", out.String())
+	}
+	for _, name := range []string{"archive.json", "index.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected %s in archive: %v", name, err)
+		}
+	}
+}
+
+func TestRunSubcommand_FailureExitCode(t *testing.T) {
+	post := &fakePostService{err: errors.New("boom")}
+	action, err := parseSubcommand([]string{"post", "-m", "x"}, cliInput{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	var errOut bytes.Buffer
+	if code := runSubcommand(context.Background(), "post", action, cliServices{Post: post}, &bytes.Buffer{}, &errOut); code != exitError {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "boom") {
+		t.Fatalf("expected error on stderr, got %q", errOut.String())
+	}
+}
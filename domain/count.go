@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	countURLRe     = regexp.MustCompile(`https?://[^\s<>"]+`)
+	countMentionRe = regexp.MustCompile(`(^|[^\w/@])@(\w+)@[\w.-]+\w`)
+)
+
+// CountCharacters counts text the way Mastodon does: every http(s) URL
+// counts as urlLength characters, and a remote mention (@user@domain) counts
+// only its local @user part.
+func CountCharacters(text string, urlLength int) int {
+	text = countURLRe.ReplaceAllStringFunc(text, func(u string) string {
+		// Trailing punctuation is not part of the link.
+		trimmed := strings.TrimRight(u, ".,;:!?)]}'")
+		return strings.Repeat("x", urlLength) + u[len(trimmed):]
+	})
+	text = countMentionRe.ReplaceAllString(text, "$1@$2")
+	return utf8.RuneCountInString(text)
+}
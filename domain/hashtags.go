@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var hashtagRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#])#([\p{L}\p{N}_]+)`)
+
+// ParseHashtags turns a list such as "go, #Rust terminalrant" into
+// ["#go", "#Rust", "#terminalrant"], dropping duplicates (case-insensitively)
+// and anything that is not a valid tag name.
+func ParseHashtags(list string) []string {
+	fields := strings.FieldsFunc(list, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	var out []string
+	for _, f := range fields {
+		name := strings.TrimPrefix(f, "#")
+		if name == "" || strings.IndexFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		}) >= 0 {
+			continue
+		}
+		out = appendHashtag(out, "#"+name)
+	}
+	return out
+}
+
+// HashtagsIn returns the hashtags used in content, in order of appearance.
+func HashtagsIn(content string) []string {
+	var out []string
+	for _, m := range hashtagRe.FindAllStringSubmatch(content, -1) {
+		out = appendHashtag(out, "#"+m[1])
+	}
+	return out
+}
+
+// MissingHashtags returns the tags that content does not already carry.
+func MissingHashtags(content string, tags []string) []string {
+	present := HashtagsIn(content)
+	var out []string
+	for _, t := range tags {
+		if !hasHashtag(present, t) {
+			out = appendHashtag(out, t)
+		}
+	}
+	return out
+}
+
+// AppendHashtags adds the tags content lacks on a final line of their own.
+func AppendHashtags(content string, tags []string) string {
+	missing := MissingHashtags(content, tags)
+	if len(missing) == 0 {
+		return content
+	}
+	return content + "\n\n" + strings.Join(missing, " ")
+}
+
+func appendHashtag(tags []string, tag string) []string {
+	if hasHashtag(tags, tag) {
+		return tags
+	}
+	return append(tags, tag)
+}
+
+func hasHashtag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
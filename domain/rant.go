@@ -20,11 +20,18 @@ type Rant struct {
 	Username     string // @handle
 	Content      string // Plain text, HTML stripped
 	CreatedAt    time.Time
-	URL          string // Original post URL
-	IsOwn        bool   // True if this rant belongs to the authenticated user
-	Liked        bool   // True if the current user has liked this rant
+	EditedAt     time.Time // Zero unless the rant was edited after posting
+	URL          string    // Original post URL
+	IsOwn        bool      // True if this rant belongs to the authenticated user
+	Liked        bool      // True if the current user has liked this rant
 	LikesCount   int
 	RepliesCount int
 	InReplyToID  string
+	Visibility   string // public, unlisted, private, or direct
 	Media        []MediaAttachment
 }
+
+// IsEdited reports whether the rant was changed after it was posted.
+func (r Rant) IsEdited() bool {
+	return !r.EditedAt.IsZero()
+}
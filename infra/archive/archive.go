@@ -0,0 +1,151 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/infra/format"
+)
+
+const (
+	archiveVersion = 1
+	metadataFile   = "archive.json"
+	indexFile      = "index.html"
+	mediaDir       = "media"
+)
+
+// Collection names.
+const (
+	Posts      = "posts"
+	Bookmarks  = "bookmarks"
+	Favourites = "favourites"
+)
+
+// Archive is the on-disk metadata of an export. Rants are stored once and
+// referenced by ID from each collection.
+type Archive struct {
+	Version       int                    `json:"version"`
+	SchemaVersion int                    `json:"schema_version"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	Collections   map[string]*Collection `json:"collections"`
+	Rants         map[string]*Entry      `json:"rants"`
+}
+
+// Collection tracks which rants belong to a timeline and how far paging got.
+type Collection struct {
+	IDs []string `json:"ids"`
+	// Complete is true once the timeline was paged to its end. Later runs
+	// then stop as soon as they reach already-archived rants.
+	Complete bool `json:"complete"`
+	// Cursor is the max_id to continue from after an interrupted run.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// Entry is a rant plus the archive-relative paths of its downloaded media.
+type Entry struct {
+	format.Rant
+	MediaFiles map[string]string `json:"media_files,omitempty"` // media ID -> path
+}
+
+func newArchive() *Archive {
+	return &Archive{
+		Version:       archiveVersion,
+		SchemaVersion: format.SchemaVersion,
+		Collections:   map[string]*Collection{},
+		Rants:         map[string]*Entry{},
+	}
+}
+
+// Load reads the archive metadata in dir, returning an empty archive when
+// none exists yet.
+func Load(dir string) (*Archive, error) {
+	data, err := os.ReadFile(filepath.Join(dir, metadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return newArchive(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	a := newArchive()
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("parsing archive: %w", err)
+	}
+	if a.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", a.Version)
+	}
+	if a.Collections == nil {
+		a.Collections = map[string]*Collection{}
+	}
+	if a.Rants == nil {
+		a.Rants = map[string]*Entry{}
+	}
+	return a, nil
+}
+
+// Save writes the archive metadata atomically so an interrupted export never
+// leaves a truncated archive.json behind.
+func (a *Archive) Save(dir string) error {
+	a.UpdatedAt = time.Now().UTC()
+	for _, c := range a.Collections {
+		a.sortIDs(c)
+	}
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding archive: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating archive directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, metadataFile+".*")
+	if err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, metadataFile)); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return nil
+}
+
+// collection returns the named collection, creating it if needed.
+func (a *Archive) collection(name string) *Collection {
+	c, ok := a.Collections[name]
+	if !ok {
+		c = &Collection{}
+		a.Collections[name] = c
+	}
+	return c
+}
+
+// sortIDs orders a collection newest first and drops duplicates.
+func (a *Archive) sortIDs(c *Collection) {
+	seen := make(map[string]bool, len(c.IDs))
+	ids := c.IDs[:0]
+	for _, id := range c.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		ei, ej := a.Rants[ids[i]], a.Rants[ids[j]]
+		if ei == nil || ej == nil {
+			return false
+		}
+		return ei.CreatedAt.After(ej.CreatedAt)
+	})
+	c.IDs = ids
+}
@@ -0,0 +1,144 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/infra/format"
+)
+
+const pageSize = 40
+
+// PageFunc fetches one page older than maxID and returns the cursor for the
+// next page ("" when the timeline is exhausted).
+type PageFunc func(ctx context.Context, limit int, maxID string) ([]domain.Rant, string, error)
+
+// Source is a timeline to export into the named collection.
+type Source struct {
+	Name  string
+	Fetch PageFunc
+}
+
+// Stats summarises one export run.
+type Stats struct {
+	Fetched      map[string]int // rants seen per collection
+	New          map[string]int // rants not previously archived per collection
+	MediaSaved   int
+	MediaFailed  int
+	MediaSkipped int
+}
+
+// Exporter writes timelines into a self-contained archive directory.
+type Exporter struct {
+	Dir      string
+	HTTP     *http.Client // used for media downloads; nil uses a default client
+	Progress io.Writer    // optional progress log
+	NoMedia  bool
+}
+
+// Run pages through every source and updates the archive in Dir. Metadata is
+// saved after each page, so an interrupted run resumes where it stopped.
+func (e *Exporter) Run(ctx context.Context, sources []Source) (Stats, error) {
+	stats := Stats{Fetched: map[string]int{}, New: map[string]int{}}
+	a, err := Load(e.Dir)
+	if err != nil {
+		return stats, err
+	}
+	for _, src := range sources {
+		if err := e.exportSource(ctx, a, src, &stats); err != nil {
+			_ = a.Save(e.Dir)
+			return stats, fmt.Errorf("exporting %s: %w", src.Name, err)
+		}
+		e.logf("%s: %d rants (%d new)\n", src.Name, stats.Fetched[src.Name], stats.New[src.Name])
+	}
+	if !e.NoMedia {
+		e.downloadMedia(ctx, a, &stats)
+		if err := a.Save(e.Dir); err != nil {
+			return stats, err
+		}
+	}
+	if err := writeIndex(e.Dir, a); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (e *Exporter) exportSource(ctx context.Context, a *Archive, src Source, stats *Stats) error {
+	c := a.collection(src.Name)
+	known := make(map[string]bool, len(c.IDs))
+	for _, id := range c.IDs {
+		known[id] = true
+	}
+	caughtUp := c.Complete
+	resume := ""
+	if !c.Complete {
+		resume = c.Cursor
+	}
+	c.Complete = false
+
+	maxID := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, next, err := src.Fetch(ctx, pageSize, maxID)
+		if err != nil {
+			return err
+		}
+		allKnown := len(page) > 0
+		for _, r := range page {
+			if !known[r.ID] {
+				allKnown = false
+				known[r.ID] = true
+				c.IDs = append(c.IDs, r.ID)
+				stats.New[src.Name]++
+			}
+			a.put(r)
+		}
+		stats.Fetched[src.Name] += len(page)
+
+		switch {
+		case len(page) == 0 || next == "":
+			c.Complete, c.Cursor = true, ""
+			return a.Save(e.Dir)
+		case allKnown && caughtUp:
+			// Everything older was archived by an earlier complete run.
+			c.Complete, c.Cursor = true, ""
+			return a.Save(e.Dir)
+		case allKnown && resume != "":
+			// Skip the stretch a previous interrupted run already covered.
+			next, resume = resume, ""
+		}
+		c.Cursor = next
+		if err := a.Save(e.Dir); err != nil {
+			return err
+		}
+		maxID = next
+	}
+}
+
+// put stores or refreshes a rant, keeping media already downloaded.
+func (a *Archive) put(r domain.Rant) {
+	entry := &Entry{Rant: format.FromRant(r)}
+	if old, ok := a.Rants[r.ID]; ok {
+		entry.MediaFiles = old.MediaFiles
+	}
+	a.Rants[r.ID] = entry
+}
+
+func (e *Exporter) httpClient() *http.Client {
+	if e.HTTP != nil {
+		return e.HTTP
+	}
+	return &http.Client{Timeout: 60 * time.Second}
+}
+
+func (e *Exporter) logf(msg string, args ...any) {
+	if e.Progress != nil {
+		fmt.Fprintf(e.Progress, msg, args...)
+	}
+}
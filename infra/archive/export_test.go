@@ -0,0 +1,151 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// fakeTimeline serves ids newest first in pages, using the last id as cursor.
+type fakeTimeline struct {
+	ids     []int
+	calls   []string
+	failAt  int // fail on this call number (1-based); 0 never fails
+	mediaTo string
+}
+
+func (f *fakeTimeline) fetch(_ context.Context, limit int, maxID string) ([]domain.Rant, string, error) {
+	f.calls = append(f.calls, maxID)
+	if f.failAt != 0 && len(f.calls) == f.failAt {
+		return nil, "", errors.New("network down")
+	}
+	start := 0
+	if maxID != "" {
+		for i, id := range f.ids {
+			if fmt.Sprint(id) == maxID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+2, len(f.ids))
+	var page []domain.Rant
+	for _, id := range f.ids[start:end] {
+		r := domain.Rant{
+			ID:        fmt.Sprint(id),
+			Username:  "me",
+			Content:   fmt.Sprintf("rant %d", id),
+			CreatedAt: time.Unix(int64(id)*60, 0),
+		}
+		if f.mediaTo != "" && id == f.ids[0] {
+			r.Media = []domain.MediaAttachment{{ID: "m1", Type: "image", URL: f.mediaTo + "/pic.png", Description: "screenshot"}}
+		}
+		page = append(page, r)
+	}
+	next := ""
+	if end < len(f.ids) && len(page) > 0 {
+		next = page[len(page)-1].ID
+	}
+	return page, next, nil
+}
+
+func TestExporter_WritesArchiveMediaAndIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("PNGDATA"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	tl := &fakeTimeline{ids: []int{5, 4, 3}, mediaTo: srv.URL}
+	exp := &Exporter{Dir: dir, HTTP: srv.Client()}
+
+	stats, err := exp.Run(context.Background(), []Source{{Name: Posts, Fetch: tl.fetch}})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if stats.New[Posts] != 3 || stats.MediaSaved != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	a, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	c := a.Collections[Posts]
+	if !c.Complete || strings.Join(c.IDs, ",") != "5,4,3" {
+		t.Fatalf("unexpected collection: %+v", c)
+	}
+	rel := a.Rants["5"].MediaFiles["m1"]
+	data, err := os.ReadFile(filepath.Join(dir, rel))
+	if err != nil || string(data) != "PNGDATA" {
+		t.Fatalf("media not downloaded to %q: %v", rel, err)
+	}
+	index, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if err != nil {
+		t.Fatalf("index missing: %v", err)
+	}
+	if !strings.Contains(string(index), `src="`+rel+`"`) || !strings.Contains(string(index), "rant 3") {
+		t.Fatalf("index does not reference local media or rants:\n%s", index)
+	}
+}
+
+func TestExporter_CompleteArchiveStopsAtKnownRants(t *testing.T) {
+	dir := t.TempDir()
+	exp := &Exporter{Dir: dir, NoMedia: true}
+	tl := &fakeTimeline{ids: []int{6, 5, 4, 3, 2, 1}}
+	if _, err := exp.Run(context.Background(), []Source{{Name: Posts, Fetch: tl.fetch}}); err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+
+	tl = &fakeTimeline{ids: []int{8, 7, 6, 5, 4, 3, 2, 1}}
+	stats, err := exp.Run(context.Background(), []Source{{Name: Posts, Fetch: tl.fetch}})
+	if err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+	if stats.New[Posts] != 2 {
+		t.Fatalf("expected 2 new rants, got %d", stats.New[Posts])
+	}
+	if len(tl.calls) != 2 {
+		t.Fatalf("expected to stop after reaching known rants, made %d calls", len(tl.calls))
+	}
+}
+
+func TestExporter_ResumesAfterInterruption(t *testing.T) {
+	dir := t.TempDir()
+	exp := &Exporter{Dir: dir, NoMedia: true}
+	tl := &fakeTimeline{ids: []int{10, 9, 8, 7, 6, 5, 4, 3}, failAt: 3}
+	if _, err := exp.Run(context.Background(), []Source{{Name: Posts, Fetch: tl.fetch}}); err == nil {
+		t.Fatalf("expected interrupted export to fail")
+	}
+	a, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if c := a.Collections[Posts]; c.Complete || c.Cursor != "7" || len(c.IDs) != 4 {
+		t.Fatalf("expected partial progress saved, got %+v", c)
+	}
+
+	tl = &fakeTimeline{ids: []int{10, 9, 8, 7, 6, 5, 4, 3}}
+	stats, err := exp.Run(context.Background(), []Source{{Name: Posts, Fetch: tl.fetch}})
+	if err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if stats.New[Posts] != 4 {
+		t.Fatalf("expected the remaining 4 rants, got %d", stats.New[Posts])
+	}
+	if strings.Join(tl.calls, ",") != ",7,5" {
+		t.Fatalf("expected resume to jump to saved cursor, calls=%q", tl.calls)
+	}
+	a, _ = Load(dir)
+	if c := a.Collections[Posts]; !c.Complete || len(c.IDs) != 8 {
+		t.Fatalf("expected complete archive, got %+v", c)
+	}
+}
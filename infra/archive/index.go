@@ -0,0 +1,89 @@
+package archive
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+)
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>TerminalRant archive</title>
+<style>
+body { font-family: ui-monospace, Menlo, Consolas, monospace; background: #111; color: #ddd; max-width: 46rem; margin: 2rem auto; padding: 0 1rem; }
+h1, h2 { color: #f80; }
+nav a { color: #8cf; margin-right: 1rem; }
+article { border-bottom: 1px solid #333; padding: 1rem 0; }
+.meta { color: #888; font-size: 0.85rem; }
+.content { white-space: pre-wrap; margin: 0.5rem 0; }
+img { max-width: 100%; border-radius: 4px; }
+a { color: #8cf; }
+</style>
+</head>
+<body>
+<h1>TerminalRant archive</h1>
+<p class="meta">Updated {{.UpdatedAt.Format "2006-01-02 15:04 UTC"}}</p>
+<nav>{{range .Sections}}<a href="#{{.Name}}">{{.Name}} ({{len .Entries}})</a>{{end}}</nav>
+{{range .Sections}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+{{range .Entries}}
+<article id="{{$.Anchor .ID}}">
+<div class="meta">{{.Account.DisplayName}} @{{.Account.Username}} · {{.CreatedAt.Format "2006-01-02 15:04"}}{{if .InReplyToID}} · reply to {{.InReplyToID}}{{end}} · {{.Counts.Likes}} likes · {{.Counts.Replies}} replies</div>
+<div class="content">{{.Content}}</div>
+{{$files := .MediaFiles}}{{range .Media}}{{$local := index $files .ID}}
+<div>{{if $local}}{{if eq .Type "image"}}<a href="{{$local}}"><img src="{{$local}}" alt="{{.Description}}"></a>{{else}}<a href="{{$local}}">{{.Type}}: {{or .Description $local}}</a>{{end}}{{else}}<a href="{{.URL}}">{{.Type}} (remote): {{or .Description .URL}}</a>{{end}}</div>
+{{end}}
+{{if .URL}}<div class="meta"><a href="{{.URL}}">original</a></div>{{end}}
+</article>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+type indexSection struct {
+	Name    string
+	Entries []*Entry
+}
+
+type indexPage struct {
+	*Archive
+	Sections []indexSection
+}
+
+// Anchor returns the element id for a rant.
+func (indexPage) Anchor(id string) string { return "rant-" + id }
+
+// writeIndex renders a static HTML index that only links to files inside
+// the archive, so it can be opened offline.
+func writeIndex(dir string, a *Archive) error {
+	page := indexPage{Archive: a}
+	for _, name := range []string{Posts, Bookmarks, Favourites} {
+		c, ok := a.Collections[name]
+		if !ok {
+			continue
+		}
+		section := indexSection{Name: name}
+		for _, id := range c.IDs {
+			if e := a.Rants[id]; e != nil {
+				section.Entries = append(section.Entries, e)
+			}
+		}
+		page.Sections = append(page.Sections, section)
+	}
+	f, err := os.Create(filepath.Join(dir, indexFile))
+	if err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	if err := indexTemplate.Execute(f, page); err != nil {
+		f.Close()
+		return fmt.Errorf("rendering index: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// downloadMedia fetches every attachment that is not on disk yet. Failures
+// are counted and logged but do not abort the export; they are retried on
+// the next run.
+func (e *Exporter) downloadMedia(ctx context.Context, a *Archive, stats *Stats) {
+	client := e.httpClient()
+	for _, entry := range a.Rants {
+		for _, m := range entry.Media {
+			if m.URL == "" {
+				continue
+			}
+			rel := mediaPath(entry.ID, m.ID, m.URL)
+			if _, err := os.Stat(filepath.Join(e.Dir, rel)); err == nil {
+				stats.MediaSkipped++
+			} else if err := download(ctx, client, m.URL, filepath.Join(e.Dir, rel)); err != nil {
+				stats.MediaFailed++
+				e.logf("media %s: %v\n", m.URL, err)
+				continue
+			} else {
+				stats.MediaSaved++
+			}
+			if entry.MediaFiles == nil {
+				entry.MediaFiles = map[string]string{}
+			}
+			entry.MediaFiles[m.ID] = rel
+		}
+	}
+}
+
+// mediaPath returns the archive-relative file path for an attachment.
+func mediaPath(rantID, mediaID, rawURL string) string {
+	ext := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	if len(ext) > 6 {
+		ext = ""
+	}
+	name := unsafeFileChars.ReplaceAllString(rantID+"-"+mediaID, "_") + unsafeFileChars.ReplaceAllString(ext, "")
+	return path.Join(mediaDir, name)
+}
+
+// download writes url to dst via a temp file so partial downloads are never
+// mistaken for complete ones.
+func download(ctx context.Context, client *http.Client, rawURL, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("download returned %d", resp.StatusCode)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("creating media directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".partial-*")
+	if err != nil {
+		return fmt.Errorf("creating media file: %w", err)
+	}
+	_, copyErr := io.Copy(tmp, resp.Body)
+	closeErr := tmp.Close()
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing media file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing media file: %w", err)
+	}
+	return nil
+}
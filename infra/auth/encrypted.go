@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	encryptedTokenVersion = 1
+	pbkdf2Iterations      = 600_000
+	saltSize              = 16
+)
+
+// PassphraseFunc returns the passphrase protecting the encrypted token file.
+// confirm is set when a new passphrase is being chosen; interactive sources
+// should ask for it twice so a typo cannot lock the user out.
+type PassphraseFunc func(confirm bool) (string, error)
+
+type encryptedTokenFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileTokenProvider stores the token in a file encrypted with
+// AES-256-GCM using a key derived from a passphrase (PBKDF2-SHA256).
+type EncryptedFileTokenProvider struct {
+	path       string
+	passphrase PassphraseFunc
+
+	mu     sync.Mutex
+	cached string
+}
+
+// NewEncryptedFileTokenProvider creates an encrypted-file token store.
+func NewEncryptedFileTokenProvider(path string, passphrase PassphraseFunc) *EncryptedFileTokenProvider {
+	return &EncryptedFileTokenProvider{path: path, passphrase: passphrase}
+}
+
+// AccessToken decrypts and returns the token. The decrypted value is cached
+// in memory so the passphrase is requested at most once per process.
+func (e *EncryptedFileTokenProvider) AccessToken() (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cached != "" {
+		return e.cached, nil
+	}
+
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return "", fmt.Errorf("reading token from %s: %w", e.path, err)
+	}
+	var f encryptedTokenFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", fmt.Errorf("parsing encrypted token file: %w", err)
+	}
+	if f.Version != encryptedTokenVersion {
+		return "", fmt.Errorf("unsupported encrypted token version %d", f.Version)
+	}
+	pass, err := e.passphrase(false)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	gcm, err := newTokenCipher(pass, f.Salt, f.Iterations)
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return "", errors.New("decrypting token: wrong passphrase or corrupted file")
+	}
+	token := strings.TrimSpace(string(plain))
+	if token == "" {
+		return "", fmt.Errorf("encrypted token file %s: %w", e.path, errEmptyToken)
+	}
+	e.cached = token
+	return token, nil
+}
+
+// SaveToken encrypts and writes the token with owner-only permissions. The
+// passphrase is confirmed unless it already unlocked the stored token in
+// this process.
+func (e *EncryptedFileTokenProvider) SaveToken(token string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	token = strings.TrimSpace(token)
+	pass, err := e.passphrase(e.cached == "")
+	if err != nil {
+		return fmt.Errorf("reading passphrase: %w", err)
+	}
+	if pass == "" {
+		return errors.New("passphrase cannot be empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generating salt: %w", err)
+	}
+	gcm, err := newTokenCipher(pass, salt, pbkdf2Iterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating nonce: %w", err)
+	}
+	data, err := json.Marshal(encryptedTokenFile{
+		Version:    encryptedTokenVersion,
+		Iterations: pbkdf2Iterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, []byte(token), nil),
+	})
+	if err != nil {
+		return fmt.Errorf("encoding encrypted token: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o700); err != nil {
+		return fmt.Errorf("creating auth directory: %w", err)
+	}
+	if err := os.WriteFile(e.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing encrypted token: %w", err)
+	}
+	e.cached = token
+	return nil
+}
+
+// DeleteToken removes the encrypted token file and the in-memory copy.
+func (e *EncryptedFileTokenProvider) DeleteToken() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cached = ""
+	if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing encrypted token: %w", err)
+	}
+	return nil
+}
+
+// Describe returns a short label for the encrypted-file backend.
+func (e *EncryptedFileTokenProvider) Describe() string {
+	return "encrypted file " + e.path
+}
+
+func newTokenCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, errors.New("invalid key derivation iterations")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating gcm: %w", err)
+	}
+	return gcm, nil
+}
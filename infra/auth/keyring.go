@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "terminalrant"
+
+// KeyringTokenProvider stores the access token in the OS keyring
+// (Secret Service on Linux, Keychain on macOS, Credential Manager on Windows).
+type KeyringTokenProvider struct {
+	account string
+}
+
+// NewKeyringTokenProvider creates a keyring-backed store. The instance URL is
+// used as the keyring account so multiple instances can coexist.
+func NewKeyringTokenProvider(instanceURL string) *KeyringTokenProvider {
+	return &KeyringTokenProvider{account: strings.TrimRight(strings.TrimSpace(instanceURL), "/")}
+}
+
+// Available reports whether the OS keyring can be reached.
+func (k *KeyringTokenProvider) Available() bool {
+	_, err := keyring.Get(keyringService, k.account)
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// AccessToken reads the token from the keyring.
+func (k *KeyringTokenProvider) AccessToken() (string, error) {
+	token, err := keyring.Get(keyringService, k.account)
+	if err != nil {
+		return "", fmt.Errorf("reading token from keyring: %w", err)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("keyring: %w", errEmptyToken)
+	}
+	return token, nil
+}
+
+// SaveToken writes the token to the keyring.
+func (k *KeyringTokenProvider) SaveToken(token string) error {
+	if err := keyring.Set(keyringService, k.account, strings.TrimSpace(token)); err != nil {
+		return fmt.Errorf("writing token to keyring: %w", err)
+	}
+	return nil
+}
+
+// DeleteToken removes the token from the keyring.
+func (k *KeyringTokenProvider) DeleteToken() error {
+	if err := keyring.Delete(keyringService, k.account); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("removing token from keyring: %w", err)
+	}
+	return nil
+}
+
+// Describe returns a short label for the keyring backend.
+func (k *KeyringTokenProvider) Describe() string {
+	return "OS keyring"
+}
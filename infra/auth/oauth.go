@@ -17,6 +17,8 @@ import (
 	"strings"
 	"github.com/CrestNiraj12/terminalrant/domain"
 	"time"
+
+	"github.com/zalando/go-keyring"
 )
 
 type oauthClientCredentials struct {
@@ -28,11 +30,15 @@ type oauthTokenResponse struct {
 	AccessToken string `json:"access_token"`
 }
 
-// EnsureOAuthLogin guarantees a valid OAuth token exists at tokenPath.
-// It validates an existing token and falls back to browser OAuth login if needed.
-func EnsureOAuthLogin(ctx context.Context, instanceURL, tokenPath, clientPath string, callbackPort int) error {
-	token, err := readToken(tokenPath)
-	if err == nil && token != "" {
+// EnsureOAuthLogin guarantees a valid OAuth token exists in store.
+// It validates an existing token and falls back to browser OAuth login when
+// there is none or the server rejects it. A token that exists but cannot be
+// read (e.g. a wrong passphrase) is an error, so it is never replaced by a
+// new login while still live on the server.
+func EnsureOAuthLogin(ctx context.Context, instanceURL string, store TokenStore, clientPath string, callbackPort int) error {
+	token, err := store.AccessToken()
+	switch {
+	case err == nil:
 		valid, err := validateToken(ctx, instanceURL, token)
 		if err != nil {
 			return err
@@ -40,6 +46,8 @@ func EnsureOAuthLogin(ctx context.Context, instanceURL, tokenPath, clientPath st
 		if valid {
 			return nil
 		}
+	case !noStoredToken(err):
+		return fmt.Errorf("reading stored token: %w", err)
 	}
 
 	creds, err := loadOrCreateOAuthClient(ctx, instanceURL, clientPath, callbackPort)
@@ -52,7 +60,71 @@ func EnsureOAuthLogin(ctx context.Context, instanceURL, tokenPath, clientPath st
 		return err
 	}
 
-	return writeToken(tokenPath, token)
+	return store.SaveToken(token)
+}
+
+// Logout revokes the stored token via /oauth/revoke and deletes the local
+// token and OAuth client credentials. Local credentials are removed even when
+// revocation fails; the revocation error is still returned. A token that
+// exists but cannot be read (e.g. a wrong passphrase) is an error and nothing
+// is removed, so logging out can be retried instead of leaving a live token
+// on the server.
+func Logout(ctx context.Context, instanceURL string, store TokenStore, clientPath string) error {
+	var revokeErr error
+	token, err := store.AccessToken()
+	switch {
+	case err == nil:
+		revokeErr = revokeToken(ctx, instanceURL, clientPath, token)
+	case !noStoredToken(err):
+		return fmt.Errorf("could not read token, not revoked: %w", err)
+	}
+	if err := store.DeleteToken(); err != nil {
+		return err
+	}
+	if err := os.Remove(clientPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing oauth client credentials: %w", err)
+	}
+	return revokeErr
+}
+
+// noStoredToken reports whether an AccessToken error means there is no token
+// to revoke.
+func noStoredToken(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, keyring.ErrNotFound) || errors.Is(err, errEmptyToken)
+}
+
+func revokeToken(ctx context.Context, instanceURL, clientPath, token string) error {
+	data, err := os.ReadFile(clientPath)
+	if err != nil {
+		return fmt.Errorf("reading oauth client credentials: %w", err)
+	}
+	var creds oauthClientCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return fmt.Errorf("parsing oauth client credentials: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("client_id", creds.ClientID)
+	form.Set("client_secret", creds.ClientSecret)
+	form.Set("token", token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, instanceURL+"/oauth/revoke", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("creating token revocation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return fmt.Errorf("revoking oauth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("token revocation failed: %d %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
 }
 
 func validateToken(ctx context.Context, instanceURL, token string) (bool, error) {
@@ -176,3 +176,137 @@ func TestPKCEHelpers(t *testing.T) {
 		t.Fatalf("unexpected code challenge: got %q want %q", challenge, want)
 	}
 }
+
+func TestLogout_RevokesAndDeletesCredentials(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "oauth_token")
+	clientPath := filepath.Join(dir, "oauth_client.json")
+	if err := writeToken(tokenPath, "tok123"); err != nil {
+		t.Fatalf("write token failed: %v", err)
+	}
+	raw, _ := json.Marshal(oauthClientCredentials{ClientID: "cid", ClientSecret: "sec"})
+	if err := os.WriteFile(clientPath, raw, 0o600); err != nil {
+		t.Fatalf("write client creds failed: %v", err)
+	}
+
+	var gotValues url.Values
+	withMockDefaultTransport(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth/revoke" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotValues, _ = url.ParseQuery(string(body))
+		return response(r, http.StatusOK, "{}"), nil
+	}))
+
+	if err := Logout(context.Background(), "http://example.test", NewFileTokenProvider(tokenPath), clientPath); err != nil {
+		t.Fatalf("logout failed: %v", err)
+	}
+	if gotValues.Get("token") != "tok123" || gotValues.Get("client_id") != "cid" || gotValues.Get("client_secret") != "sec" {
+		t.Fatalf("unexpected revoke form: %#v", gotValues)
+	}
+	for _, p := range []string{tokenPath, clientPath} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("expected %s removed", p)
+		}
+	}
+}
+
+func TestLogout_DeletesLocallyWhenRevokeFails(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "oauth_token")
+	clientPath := filepath.Join(dir, "oauth_client.json")
+	if err := writeToken(tokenPath, "tok123"); err != nil {
+		t.Fatalf("write token failed: %v", err)
+	}
+	raw, _ := json.Marshal(oauthClientCredentials{ClientID: "cid", ClientSecret: "sec"})
+	if err := os.WriteFile(clientPath, raw, 0o600); err != nil {
+		t.Fatalf("write client creds failed: %v", err)
+	}
+	withMockDefaultTransport(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return response(r, http.StatusForbidden, "nope"), nil
+	}))
+
+	err := Logout(context.Background(), "http://example.test", NewFileTokenProvider(tokenPath), clientPath)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected revocation error, got %v", err)
+	}
+	if _, err := os.Stat(tokenPath); !os.IsNotExist(err) {
+		t.Fatalf("token should be removed even when revocation fails")
+	}
+}
+
+func TestLogout_KeepsCredentialsWhenTokenUnreadable(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "oauth_token.enc")
+	clientPath := filepath.Join(dir, "oauth_client.json")
+	saver := NewEncryptedFileTokenProvider(tokenPath, func(bool) (string, error) { return "right", nil })
+	if err := saver.SaveToken("tok123"); err != nil {
+		t.Fatalf("save token failed: %v", err)
+	}
+	if err := os.WriteFile(clientPath, []byte(`{"client_id":"cid","client_secret":"sec"}`), 0o600); err != nil {
+		t.Fatalf("write client creds failed: %v", err)
+	}
+	withMockDefaultTransport(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return nil, nil
+	}))
+
+	store := NewEncryptedFileTokenProvider(tokenPath, func(bool) (string, error) { return "wrong", nil })
+	err := Logout(context.Background(), "http://example.test", store, clientPath)
+	if err == nil || !strings.Contains(err.Error(), "could not read token, not revoked") {
+		t.Fatalf("expected not-revoked error, got %v", err)
+	}
+	for _, p := range []string{tokenPath, clientPath} {
+		if _, err := os.Stat(p); err != nil {
+			t.Fatalf("expected %s kept, got %v", p, err)
+		}
+	}
+}
+
+func TestEnsureOAuthLogin_ReportsUnreadableToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "oauth_token.enc")
+	saver := NewEncryptedFileTokenProvider(tokenPath, func(bool) (string, error) { return "right", nil })
+	if err := saver.SaveToken("tok123"); err != nil {
+		t.Fatalf("save token failed: %v", err)
+	}
+	before, err := os.ReadFile(tokenPath)
+	if err != nil {
+		t.Fatalf("read token failed: %v", err)
+	}
+	withMockDefaultTransport(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return nil, nil
+	}))
+
+	store := NewEncryptedFileTokenProvider(tokenPath, func(bool) (string, error) { return "wrong", nil })
+	err = EnsureOAuthLogin(context.Background(), "http://example.test", store, filepath.Join(dir, "oauth_client.json"), 0)
+	if err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected wrong-passphrase error, got %v", err)
+	}
+	after, err := os.ReadFile(tokenPath)
+	if err != nil || string(after) != string(before) {
+		t.Fatalf("expected stored token untouched, got err %v", err)
+	}
+}
+
+func TestLogout_WithoutTokenDeletesClientCredentials(t *testing.T) {
+	dir := t.TempDir()
+	clientPath := filepath.Join(dir, "oauth_client.json")
+	if err := os.WriteFile(clientPath, []byte(`{"client_id":"cid","client_secret":"sec"}`), 0o600); err != nil {
+		t.Fatalf("write client creds failed: %v", err)
+	}
+	withMockDefaultTransport(t, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return nil, nil
+	}))
+
+	store := NewFileTokenProvider(filepath.Join(dir, "oauth_token"))
+	if err := Logout(context.Background(), "http://example.test", store, clientPath); err != nil {
+		t.Fatalf("logout without token failed: %v", err)
+	}
+	if _, err := os.Stat(clientPath); !os.IsNotExist(err) {
+		t.Fatalf("expected client credentials removed")
+	}
+}
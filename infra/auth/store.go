@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Token store backends selectable via configuration.
+const (
+	StoreAuto      = "auto"      // keyring, falling back to encrypted file
+	StoreKeyring   = "keyring"   // OS keyring only
+	StoreEncrypted = "encrypted" // passphrase-protected file only
+	StorePlaintext = "plaintext" // legacy plaintext file (explicit opt-in)
+)
+
+// StoreOptions configures which TokenStore is used and where files live.
+type StoreOptions struct {
+	Backend       string // One of the Store* constants; empty means StoreAuto.
+	InstanceURL   string
+	Account       string // Keyring entry; empty means InstanceURL.
+	PlaintextPath string // Legacy plaintext token path.
+	EncryptedPath string
+	Passphrase    PassphraseFunc
+}
+
+// OpenTokenStore returns the configured TokenStore. In auto mode the OS
+// keyring is preferred and the encrypted file is used when no keyring is
+// reachable (e.g. headless Linux without a Secret Service).
+func OpenTokenStore(opts StoreOptions) (TokenStore, error) {
+	passphrase := opts.Passphrase
+	if passphrase == nil {
+		passphrase = PromptPassphrase
+	}
+	account := opts.Account
+	if account == "" {
+		account = opts.InstanceURL
+	}
+	switch strings.ToLower(strings.TrimSpace(opts.Backend)) {
+	case "", StoreAuto:
+		kr := NewKeyringTokenProvider(account)
+		if kr.Available() {
+			return kr, nil
+		}
+		return NewEncryptedFileTokenProvider(opts.EncryptedPath, passphrase), nil
+	case StoreKeyring:
+		kr := NewKeyringTokenProvider(account)
+		if !kr.Available() {
+			return nil, errors.New("OS keyring is not available")
+		}
+		return kr, nil
+	case StoreEncrypted:
+		return NewEncryptedFileTokenProvider(opts.EncryptedPath, passphrase), nil
+	case StorePlaintext:
+		return NewFileTokenProvider(opts.PlaintextPath), nil
+	default:
+		return nil, fmt.Errorf("unknown token store %q (want auto, keyring, encrypted or plaintext)", opts.Backend)
+	}
+}
+
+// MigratePlaintextToken moves a token from the legacy plaintext file into dst
+// and removes the file. It reports whether a migration happened. When dst is
+// itself a plaintext store, nothing is done.
+func MigratePlaintextToken(plaintextPath string, dst TokenStore) (bool, error) {
+	if _, ok := dst.(*FileTokenProvider); ok {
+		return false, nil
+	}
+	token, err := readToken(plaintextPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("reading legacy token: %w", err)
+	}
+	if token == "" {
+		_ = os.Remove(plaintextPath)
+		return false, nil
+	}
+	if err := dst.SaveToken(token); err != nil {
+		return false, fmt.Errorf("migrating token to %s: %w", dst.Describe(), err)
+	}
+	if err := os.Remove(plaintextPath); err != nil {
+		return true, fmt.Errorf("removing legacy token file: %w", err)
+	}
+	return true, nil
+}
+
+// EnvPassphrase returns a PassphraseFunc that reads the given environment
+// variable and falls back to an interactive prompt when it is unset.
+func EnvPassphrase(envVar string) PassphraseFunc {
+	return func(confirm bool) (string, error) {
+		if v := os.Getenv(envVar); v != "" {
+			return v, nil
+		}
+		return PromptPassphrase(confirm)
+	}
+}
+
+// PromptPassphrase asks for the token passphrase on the controlling terminal.
+// With confirm it is asked for twice and both entries must match.
+func PromptPassphrase(confirm bool) (string, error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return "", errors.New("no terminal available to prompt for token passphrase")
+	}
+	pass, err := readPassphrase(fd, "Token passphrase: ")
+	if err != nil || !confirm {
+		return pass, err
+	}
+	again, err := readPassphrase(fd, "Repeat token passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if again != pass {
+		return "", errors.New("passphrases do not match")
+	}
+	return pass, nil
+}
+
+func readPassphrase(fd uintptr, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	pass, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(pass)), nil
+}
@@ -1,17 +1,36 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 )
 
+// errEmptyToken is wrapped by stores whose token exists but holds nothing.
+var errEmptyToken = errors.New("token is empty")
+
 // TokenProvider supplies an access token for API authentication.
 type TokenProvider interface {
 	AccessToken() (string, error)
 }
 
+// TokenStore is a TokenProvider that can also persist and forget the token.
+type TokenStore interface {
+	TokenProvider
+
+	// SaveToken persists the token, replacing any previous value.
+	SaveToken(token string) error
+
+	// DeleteToken removes the stored token. Missing tokens are not an error.
+	DeleteToken() error
+
+	// Describe returns a short human-readable label for the backend.
+	Describe() string
+}
+
 // FileTokenProvider reads a bearer token from a file on disk.
+// The token is stored in plaintext; prefer the keyring or encrypted stores.
 type FileTokenProvider struct {
 	path string
 }
@@ -30,8 +49,26 @@ func (f *FileTokenProvider) AccessToken() (string, error) {
 
 	token := strings.TrimSpace(string(data))
 	if token == "" {
-		return "", fmt.Errorf("token file %s is empty", f.path)
+		return "", fmt.Errorf("token file %s: %w", f.path, errEmptyToken)
 	}
 
 	return token, nil
 }
+
+// SaveToken writes the token to the file with owner-only permissions.
+func (f *FileTokenProvider) SaveToken(token string) error {
+	return writeToken(f.path, token)
+}
+
+// DeleteToken removes the token file.
+func (f *FileTokenProvider) DeleteToken() error {
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing token file: %w", err)
+	}
+	return nil
+}
+
+// Describe returns a short label for the plaintext backend.
+func (f *FileTokenProvider) Describe() string {
+	return "plaintext file " + f.path
+}
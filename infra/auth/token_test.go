@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/zalando/go-keyring"
 )
 
 func TestFileTokenProvider_AccessToken(t *testing.T) {
@@ -40,3 +43,169 @@ func TestFileTokenProvider_AccessTokenErrors(t *testing.T) {
 		t.Fatalf("expected empty-token error, got: %v", err)
 	}
 }
+
+func TestEncryptedFileTokenProvider_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth", "oauth_token.enc")
+	pass := func(bool) (string, error) { return "correct horse", nil }
+
+	store := NewEncryptedFileTokenProvider(path, pass)
+	if err := store.SaveToken(" secret-token \n"); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file failed: %v", err)
+	}
+	if strings.Contains(string(raw), "secret-token") {
+		t.Fatalf("token must not be stored in plaintext: %s", raw)
+	}
+
+	got, err := NewEncryptedFileTokenProvider(path, pass).AccessToken()
+	if err != nil {
+		t.Fatalf("access token failed: %v", err)
+	}
+	if got != "secret-token" {
+		t.Fatalf("unexpected token: %q", got)
+	}
+
+	wrong := NewEncryptedFileTokenProvider(path, func(bool) (string, error) { return "nope", nil })
+	if _, err := wrong.AccessToken(); err == nil || !strings.Contains(err.Error(), "passphrase") {
+		t.Fatalf("expected wrong-passphrase error, got: %v", err)
+	}
+
+	if err := store.DeleteToken(); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected encrypted file removed, stat err: %v", err)
+	}
+	if _, err := store.AccessToken(); err == nil {
+		t.Fatalf("expected error after delete")
+	}
+}
+
+func TestEncryptedFileTokenProvider_ConfirmsNewPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oauth_token.enc")
+	var asked []bool
+	pass := func(confirm bool) (string, error) {
+		asked = append(asked, confirm)
+		return "correct horse", nil
+	}
+
+	if err := NewEncryptedFileTokenProvider(path, pass).SaveToken("first"); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	store := NewEncryptedFileTokenProvider(path, pass)
+	if _, err := store.AccessToken(); err != nil {
+		t.Fatalf("access token failed: %v", err)
+	}
+	if err := store.SaveToken("second"); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if len(asked) != 3 || !asked[0] || asked[1] || asked[2] {
+		t.Fatalf("expected only the first save to confirm, got %v", asked)
+	}
+}
+
+func TestKeyringTokenProvider_RoundTrip(t *testing.T) {
+	keyring.MockInit()
+	store := NewKeyringTokenProvider("https://example.social/")
+	if !store.Available() {
+		t.Fatalf("mock keyring should be available")
+	}
+	if err := store.SaveToken("kr-token"); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	got, err := store.AccessToken()
+	if err != nil || got != "kr-token" {
+		t.Fatalf("unexpected token got=%q err=%v", got, err)
+	}
+	if err := store.DeleteToken(); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := store.DeleteToken(); err != nil {
+		t.Fatalf("deleting a missing token should not fail: %v", err)
+	}
+}
+
+func TestOpenTokenStore_SelectsBackend(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	opts := StoreOptions{
+		InstanceURL:   "https://example.social",
+		PlaintextPath: filepath.Join(dir, "oauth_token"),
+		EncryptedPath: filepath.Join(dir, "oauth_token.enc"),
+	}
+
+	store, err := OpenTokenStore(opts)
+	if err != nil {
+		t.Fatalf("auto store failed: %v", err)
+	}
+	if _, ok := store.(*KeyringTokenProvider); !ok {
+		t.Fatalf("auto should prefer keyring, got %T", store)
+	}
+
+	keyring.MockInitWithError(errors.New("no secret service"))
+	store, err = OpenTokenStore(opts)
+	if err != nil {
+		t.Fatalf("auto fallback failed: %v", err)
+	}
+	if _, ok := store.(*EncryptedFileTokenProvider); !ok {
+		t.Fatalf("auto should fall back to encrypted file, got %T", store)
+	}
+	opts.Backend = StoreKeyring
+	if _, err := OpenTokenStore(opts); err == nil {
+		t.Fatalf("explicit keyring should fail when unavailable")
+	}
+
+	opts.Backend = StorePlaintext
+	store, err = OpenTokenStore(opts)
+	if err != nil {
+		t.Fatalf("plaintext store failed: %v", err)
+	}
+	if _, ok := store.(*FileTokenProvider); !ok {
+		t.Fatalf("expected plaintext store, got %T", store)
+	}
+
+	opts.Backend = "floppy"
+	if _, err := OpenTokenStore(opts); err == nil {
+		t.Fatalf("expected error for unknown backend")
+	}
+}
+
+func TestMigratePlaintextToken(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "oauth_token")
+	if err := os.WriteFile(plain, []byte("legacy-token\n"), 0o600); err != nil {
+		t.Fatalf("write legacy token failed: %v", err)
+	}
+	dst := NewKeyringTokenProvider("https://example.social")
+
+	migrated, err := MigratePlaintextToken(plain, dst)
+	if err != nil || !migrated {
+		t.Fatalf("expected migration, migrated=%v err=%v", migrated, err)
+	}
+	if _, err := os.Stat(plain); !os.IsNotExist(err) {
+		t.Fatalf("legacy file should be removed after migration")
+	}
+	if got, _ := dst.AccessToken(); got != "legacy-token" {
+		t.Fatalf("unexpected migrated token: %q", got)
+	}
+
+	migrated, err = MigratePlaintextToken(plain, dst)
+	if err != nil || migrated {
+		t.Fatalf("second migration should be a no-op, migrated=%v err=%v", migrated, err)
+	}
+
+	if err := os.WriteFile(plain, []byte("keep-me"), 0o600); err != nil {
+		t.Fatalf("write legacy token failed: %v", err)
+	}
+	migrated, err = MigratePlaintextToken(plain, NewFileTokenProvider(plain))
+	if err != nil || migrated {
+		t.Fatalf("plaintext destination should not migrate, migrated=%v err=%v", migrated, err)
+	}
+	if _, err := os.Stat(plain); err != nil {
+		t.Fatalf("plaintext opt-in must keep the file: %v", err)
+	}
+}
@@ -6,18 +6,43 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
 )
 
 // Config holds application-level configuration.
 type Config struct {
-	InstanceURL       string // e.g. "https://mastodon.social"
-	OAuthTokenPath    string // Path where OAuth access token is stored
-	OAuthClientPath   string // Path where OAuth client credentials are stored
-	OAuthCallbackPort int    // Local callback port for OAuth login
-	Hashtag           string // Hashtag to follow, without the '#'
-	UIStatePath       string // Path where UI state (tab/hashtag) is stored
+	InstanceURL        string // e.g. "https://mastodon.social"
+	OAuthTokenPath     string // Path of the legacy plaintext OAuth token
+	EncryptedTokenPath string // Path of the passphrase-encrypted OAuth token
+	TokenStore         string // Token backend: auto, keyring, encrypted, plaintext
+	KeyringAccount     string // Keyring entry of the token; one per instance and profile
+	OAuthClientPath    string // Path where OAuth client credentials are stored
+	OAuthCallbackPort  int    // Local callback port for OAuth login
+	Hashtag            string // Hashtag to follow, without the '#'
+	UIStatePath        string // Path where UI state (tab/hashtag) is stored
+	DraftsDir          string // Directory where unsent compose drafts are kept
+	InstanceCachePath  string // Path where the instance limits are cached
+	TagPolicyPath      string // Path of the legacy per-account hashtag policies
+	PostTags           string // Tag policy: none, active, a list of tags, or empty for the default
+	ReplyTags          string // Reply tags: inherit, none, or empty for none
+	ConfigPath         string // Config file read, whether or not it exists
+	Profile            string // Selected profile, empty when none is used
+	Visibility         string // Visibility of new posts: public, unlisted, private or direct
+	Editor             string // Editor command; empty means $EDITOR
+	Theme              string // Color theme: default or mono
+	KeyPreset          string // Key layout the [keys] table applies to: default, vim or emacs
+
+	Tabs          []string            // Feed tabs in order
+	MediaPreviews bool                // Show media previews on start
+	MediaAnimate  bool                // Play animated media previews
+	Keys          map[string][]string // Key overrides by action name
+
+	configFound bool
+	profiles    []string
+	settings    map[string]setting
 }
 
 type UIState struct {
@@ -25,26 +50,42 @@ type UIState struct {
 	FeedSource string `json:"feed_source"`
 }
 
-// Load reads configuration from environment variables.
+// Overrides are settings given as command-line flags. They win over the
+// environment and the config file.
+type Overrides struct {
+	ConfigPath string // --config
+	Profile    string // --profile
+	Instance   string // --instance
+	Hashtag    string // --hashtag
+}
+
+// feedTabs are the tab names the tabs setting accepts.
+var feedTabs = []string{"terminalrant", "trending", "following", "local", "federated"}
+
+// Load reads configuration from the config file and environment variables.
+func Load() (Config, error) {
+	return LoadWith(Overrides{})
+}
+
+// LoadWith merges, lowest priority first: built-in defaults, the entry for
+// the instance in tags.json (post_tags and reply_tags only), the config
+// file, the selected profile of the file, environment variables and flags.
+// The config file is config.toml in the auth directory unless
+// TERMINALRANT_CONFIG or --config names another.
 //
+//	TERMINALRANT_CONFIG              — Config file path
+//	TERMINALRANT_PROFILE             — Profile of the config file to use
 //	TERMINALRANT_INSTANCE            — Mastodon instance URL
 //	TERMINALRANT_AUTH_DIR            — Directory for OAuth token/client state
 //	TERMINALRANT_OAUTH_CALLBACK_PORT — Local callback port for OAuth login
 //	TERMINALRANT_HASHTAG             — Hashtag to follow
-func Load() (Config, error) {
-	instance := os.Getenv("TERMINALRANT_INSTANCE")
-	if instance == "" {
-		instance = "https://mastodon.social"
-	}
-	parsed, err := url.Parse(instance)
-	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
-		return Config{}, fmt.Errorf("invalid TERMINALRANT_INSTANCE: must be an absolute URL")
-	}
-	if parsed.Scheme != "https" {
-		return Config{}, fmt.Errorf("invalid TERMINALRANT_INSTANCE: only https is allowed")
-	}
-	instance = strings.TrimRight(parsed.String(), "/")
-
+//	TERMINALRANT_TOKEN_STORE         — auto (default), keyring, encrypted or plaintext
+//	TERMINALRANT_POST_TAGS           — Tags added to posts: none, active or a list
+//	TERMINALRANT_REPLY_TAGS          — inherit or none: whether replies copy the parent's tags
+//	TERMINALRANT_VISIBILITY          — Visibility of new posts
+//	TERMINALRANT_EDITOR              — Editor command, used instead of $EDITOR
+//	TERMINALRANT_THEME               — default or mono
+func LoadWith(o Overrides) (Config, error) {
 	authDir := os.Getenv("TERMINALRANT_AUTH_DIR")
 	if authDir == "" {
 		home, err := os.UserHomeDir()
@@ -54,30 +95,219 @@ func Load() (Config, error) {
 		authDir = filepath.Join(home, ".config", "terminalrant")
 	}
 
-	callbackPort := 45145
-	if p := os.Getenv("TERMINALRANT_OAUTH_CALLBACK_PORT"); p != "" {
-		parsedPort, err := strconv.Atoi(p)
-		if err != nil || parsedPort < 1024 || parsedPort > 65535 {
-			return Config{}, fmt.Errorf("invalid TERMINALRANT_OAUTH_CALLBACK_PORT: must be 1024-65535")
+	path := firstNonEmpty(o.ConfigPath, os.Getenv("TERMINALRANT_CONFIG"))
+	required := path != ""
+	if path == "" {
+		path = filepath.Join(authDir, "config.toml")
+	}
+	file, err := loadConfigFile(path, required)
+	if err != nil {
+		return Config{}, err
+	}
+
+	settings := make(map[string]setting, len(settingSpecs))
+	for _, spec := range settingSpecs {
+		def := spec.def
+		def.kind = spec.kind
+		settings[spec.name] = setting{value: def, source: "default"}
+	}
+	for name, s := range file.settings {
+		settings[name] = s
+	}
+
+	profile := firstNonEmpty(o.Profile, os.Getenv("TERMINALRANT_PROFILE"), file.profile)
+	if profile != "" {
+		values, ok := file.profiles[profile]
+		if !ok {
+			known := "none are defined"
+			if names := file.profileNames(); len(names) > 0 {
+				known = "defined: " + strings.Join(names, ", ")
+			}
+			return Config{}, fmt.Errorf("unknown profile %q in %s (%s)", profile, path, known)
+		}
+		for name, s := range values {
+			settings[name] = s
+		}
+	}
+
+	for _, spec := range settingSpecs {
+		s, ok, err := envSetting(spec)
+		if err != nil {
+			return Config{}, err
+		}
+		if ok {
+			settings[spec.name] = s
 		}
-		callbackPort = parsedPort
 	}
+	if o.Instance != "" {
+		settings["instance"] = setting{value: tomlValue{str: o.Instance}, source: "--instance"}
+	}
+	if o.Hashtag != "" {
+		settings["hashtag"] = setting{value: tomlValue{str: o.Hashtag}, source: "--hashtag"}
+	}
+
+	tagPolicyPath := filepath.Join(authDir, "tags.json")
+	instance := strings.TrimRight(strings.TrimSpace(settings["instance"].value.str), "/")
+	if err := mergeTagPolicyFile(settings, tagPolicyPath, instance); err != nil {
+		return Config{}, err
+	}
+
+	stateDir := authDir
+	if profile != "" {
+		// Each profile signs in and remembers its tabs separately.
+		stateDir = filepath.Join(authDir, "profiles", profile)
+	}
+	cfg, err := resolve(settings, stateDir)
+	if err != nil {
+		return Config{}, err
+	}
+	// config check shows values as they are used, e.g. without a trailing
+	// slash or '#'.
+	for name, value := range map[string]string{
+		"instance":    cfg.InstanceURL,
+		"hashtag":     cfg.Hashtag,
+		"token_store": cfg.TokenStore,
+		"visibility":  cfg.Visibility,
+		"theme":       cfg.Theme,
+		"keymap":      cfg.KeyPreset,
+		"reply_tags":  cfg.ReplyTags,
+	} {
+		s := settings[name]
+		s.value.str = value
+		settings[name] = s
+	}
+	cfg.KeyringAccount = cfg.InstanceURL
+	if profile != "" {
+		cfg.KeyringAccount += "#" + profile
+	}
+	cfg.TagPolicyPath = tagPolicyPath
+	cfg.ConfigPath = path
+	cfg.Profile = profile
+	cfg.Keys = file.keys
+	cfg.configFound = file.found
+	cfg.profiles = file.profileNames()
+	cfg.settings = settings
+	return cfg, nil
+}
 
-	hashtag := os.Getenv("TERMINALRANT_HASHTAG")
+// resolve validates the merged settings and builds the Config, with state
+// files kept in stateDir.
+func resolve(settings map[string]setting, stateDir string) (Config, error) {
+	str := func(name string) string { return strings.TrimSpace(settings[name].value.str) }
+	invalid := func(name, format string, args ...any) error {
+		return fmt.Errorf("invalid %s: %s", settingLabel(name, settings[name]), fmt.Sprintf(format, args...))
+	}
+
+	instance := str("instance")
+	parsed, err := url.Parse(instance)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return Config{}, invalid("instance", "must be an absolute URL")
+	}
+	if parsed.Scheme != "https" {
+		return Config{}, invalid("instance", "only https is allowed")
+	}
+	instance = strings.TrimRight(parsed.String(), "/")
+
+	callbackPort := settings["oauth_callback_port"].value.num
+	if callbackPort < 1024 || callbackPort > 65535 {
+		return Config{}, invalid("oauth_callback_port", "must be 1024-65535")
+	}
+
+	hashtag := strings.TrimPrefix(str("hashtag"), "#")
 	if hashtag == "" {
 		hashtag = "terminalrant"
 	}
 
+	tokenStore := strings.ToLower(str("token_store"))
+	switch tokenStore {
+	case "":
+		tokenStore = "auto"
+	case "auto", "keyring", "encrypted", "plaintext":
+	default:
+		return Config{}, invalid("token_store", "must be auto, keyring, encrypted or plaintext")
+	}
+
+	postTags := str("post_tags")
+	if postTags != "" {
+		if _, err := app.ParseTagPolicy(postTags); err != nil {
+			return Config{}, invalid("post_tags", "%v", err)
+		}
+	}
+	replyTags := strings.ToLower(str("reply_tags"))
+	switch replyTags {
+	case "", "inherit", "none":
+	default:
+		return Config{}, invalid("reply_tags", "must be inherit or none")
+	}
+
+	visibility := strings.ToLower(str("visibility"))
+	switch visibility {
+	case "public", "unlisted", "private", "direct":
+	default:
+		return Config{}, invalid("visibility", "must be public, unlisted, private or direct")
+	}
+
+	theme := strings.ToLower(str("theme"))
+	switch theme {
+	case "default", "mono":
+	default:
+		return Config{}, invalid("theme", "must be default or mono")
+	}
+
+	keyPreset := strings.ToLower(str("keymap"))
+	switch keyPreset {
+	case "default", "vim", "emacs":
+	default:
+		return Config{}, invalid("keymap", "must be default, vim or emacs")
+	}
+
+	tabs := settings["tabs"].value.list
+	if len(tabs) == 0 {
+		return Config{}, invalid("tabs", "must list at least one tab")
+	}
+	seen := make(map[string]bool, len(tabs))
+	for _, tab := range tabs {
+		if !slices.Contains(feedTabs, tab) {
+			return Config{}, invalid("tabs", "unknown tab %q (want %s)", tab, strings.Join(feedTabs, ", "))
+		}
+		if seen[tab] {
+			return Config{}, invalid("tabs", "%q is listed twice", tab)
+		}
+		seen[tab] = true
+	}
+
 	return Config{
-		InstanceURL:       instance,
-		OAuthTokenPath:    filepath.Join(authDir, "oauth_token"),
-		OAuthClientPath:   filepath.Join(authDir, "oauth_client.json"),
-		OAuthCallbackPort: callbackPort,
-		Hashtag:           hashtag,
-		UIStatePath:       filepath.Join(authDir, "ui_state.json"),
+		InstanceURL:        instance,
+		OAuthTokenPath:     filepath.Join(stateDir, "oauth_token"),
+		EncryptedTokenPath: filepath.Join(stateDir, "oauth_token.enc"),
+		TokenStore:         tokenStore,
+		OAuthClientPath:    filepath.Join(stateDir, "oauth_client.json"),
+		OAuthCallbackPort:  callbackPort,
+		Hashtag:            hashtag,
+		UIStatePath:        filepath.Join(stateDir, "ui_state.json"),
+		DraftsDir:          filepath.Join(stateDir, "drafts"),
+		InstanceCachePath:  filepath.Join(stateDir, "instance.json"),
+		PostTags:           postTags,
+		ReplyTags:          replyTags,
+		Visibility:         visibility,
+		Editor:             str("editor"),
+		Theme:              theme,
+		KeyPreset:          keyPreset,
+		Tabs:               tabs,
+		MediaPreviews:      settings["media.previews"].value.flag,
+		MediaAnimate:       settings["media.animate"].value.flag,
 	}, nil
 }
 
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func LoadUIState(path string) (UIState, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -3,7 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/CrestNiraj12/terminalrant/app"
 )
 
 func TestLoad_ParsesEnvAndDefaults(t *testing.T) {
@@ -63,3 +67,221 @@ func TestUIState_LoadAndSave(t *testing.T) {
 		t.Fatalf("expected parse error for invalid json")
 	}
 }
+
+func TestLoadTagPolicy_MergesTagsFileBelowConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TERMINALRANT_AUTH_DIR", dir)
+	t.Setenv("TERMINALRANT_INSTANCE", "https://work.example")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	policy, err := LoadTagPolicy(cfg)
+	if err != nil || !reflect.DeepEqual(policy, app.DefaultTagPolicy()) {
+		t.Fatalf("expected default policy without a file, got %#v, %v", policy, err)
+	}
+
+	file := `{
+		"default": {"tags": "terminalrant, golang"},
+		"accounts": {"work.example": {"tags": "none", "inherit_reply_tags": true}}
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "tags.json"), []byte(file), 0o600); err != nil {
+		t.Fatalf("write tags.json failed: %v", err)
+	}
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	policy, err = LoadTagPolicy(cfg)
+	if err != nil || policy.Mode != app.TagsNone || !policy.InheritReplyTags {
+		t.Fatalf("expected account entry, got %#v, %v", policy, err)
+	}
+	out := cfg.Effective()
+	for _, want := range []string{
+		`post_tags = "none"`,
+		"# tags.json accounts.work.example",
+		`reply_tags = "inherit"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("config check must show %q from tags.json:\n%s", want, out)
+		}
+	}
+
+	t.Setenv("TERMINALRANT_INSTANCE", "https://home.example")
+	cfg, _ = Load()
+	policy, _ = LoadTagPolicy(cfg)
+	if policy.Mode != app.TagsList || !reflect.DeepEqual(policy.Tags, []string{"#terminalrant", "#golang"}) || policy.InheritReplyTags {
+		t.Fatalf("expected file default for other accounts, got %#v", policy)
+	}
+	if !strings.Contains(cfg.Effective(), "# tags.json default") {
+		t.Fatalf("config check must name the tags.json default:\n%s", cfg.Effective())
+	}
+
+	writeConfigFile(t, dir, "post_tags = \"active\"\n")
+	t.Setenv("TERMINALRANT_REPLY_TAGS", "inherit")
+	cfg, _ = Load()
+	policy, _ = LoadTagPolicy(cfg)
+	if policy.Mode != app.TagsActive || !policy.InheritReplyTags {
+		t.Fatalf("expected config file and env to win over tags.json, got %#v", policy)
+	}
+	if got := policy.TagsFor("rust", "parent about #Go and #rust"); !reflect.DeepEqual(got, []string{"#rust", "#Go"}) {
+		t.Fatalf("unexpected reply tags: %v", got)
+	}
+}
+
+func TestLoad_RejectsInvalidTagPolicy(t *testing.T) {
+	t.Setenv("TERMINALRANT_AUTH_DIR", t.TempDir())
+	t.Setenv("TERMINALRANT_POST_TAGS", "not a #valid-tag")
+	if _, err := Load(); err == nil {
+		t.Fatalf("expected error for invalid TERMINALRANT_POST_TAGS")
+	}
+}
+
+func writeConfigFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	return path
+}
+
+func TestLoad_MergesFileProfileEnvAndFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TERMINALRANT_AUTH_DIR", dir)
+	writeConfigFile(t, dir, `
+# Defaults for every profile.
+profile = "work"
+hashtag = "rants"
+visibility = "unlisted"
+editor = "code --wait" # waits for the tab to close
+theme = "mono"
+keymap = "Vim"
+tabs = [
+  "following",
+  "terminalrant", # trailing commas are fine
+]
+
+[media]
+animate = false
+
+[keys]
+like = "L"
+up = ["k", "ctrl+p"]
+"toggle_hints" = ["?", "f1"]
+
+[profiles.work]
+instance = "https://work.example/"
+visibility = "private"
+
+[profiles.home]
+instance = "https://home.example"
+`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if cfg.Profile != "work" || cfg.InstanceURL != "https://work.example" || cfg.Visibility != "private" || cfg.Hashtag != "rants" {
+		t.Fatalf("expected the file's default profile over its top level, got %#v", cfg)
+	}
+	if cfg.Editor != "code --wait" || cfg.Theme != "mono" || cfg.KeyPreset != "vim" || !reflect.DeepEqual(cfg.Tabs, []string{"following", "terminalrant"}) {
+		t.Fatalf("unexpected file settings: %#v", cfg)
+	}
+	if !cfg.MediaPreviews || cfg.MediaAnimate {
+		t.Fatalf("expected previews on and animation off, got %v %v", cfg.MediaPreviews, cfg.MediaAnimate)
+	}
+	if !reflect.DeepEqual(cfg.Keys, map[string][]string{"like": {"L"}, "up": {"k", "ctrl+p"}, "toggle_hints": {"?", "f1"}}) {
+		t.Fatalf("unexpected keys: %v", cfg.Keys)
+	}
+	if cfg.UIStatePath != filepath.Join(dir, "profiles", "work", "ui_state.json") || cfg.KeyringAccount != "https://work.example#work" {
+		t.Fatalf("expected per-profile state, got %q %q", cfg.UIStatePath, cfg.KeyringAccount)
+	}
+	if cfg.TagPolicyPath != filepath.Join(dir, "tags.json") {
+		t.Fatalf("expected shared tag policies, got %q", cfg.TagPolicyPath)
+	}
+
+	t.Setenv("TERMINALRANT_PROFILE", "home")
+	t.Setenv("TERMINALRANT_VISIBILITY", "direct")
+	cfg, err = LoadWith(Overrides{Hashtag: "#flagged"})
+	if err != nil {
+		t.Fatalf("load with overrides failed: %v", err)
+	}
+	if cfg.Profile != "home" || cfg.InstanceURL != "https://home.example" || cfg.Visibility != "direct" || cfg.Hashtag != "flagged" {
+		t.Fatalf("expected env and flags to win, got %#v", cfg)
+	}
+
+	out := cfg.Effective()
+	for _, want := range []string{
+		"# profile: home",
+		"# profiles: home, work",
+		`instance = "https://home.example"`,
+		"config.toml:27",
+		`hashtag = "flagged"`,
+		"# TERMINALRANT_VISIBILITY",
+		"# --hashtag",
+		`token_store = "auto"`,
+		`keymap = "vim"`,
+		"[media]",
+		"animate = false",
+		`up = ["k", "ctrl+p"]`,
+		`toggle_hints = ["?", "f1"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in effective config:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoad_ConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "syntax", content: "hashtag = rants", want: "line 1: unexpected character"},
+		{name: "unclosed list", content: "tabs = [\n  \"following\",\n", want: "array is incomplete"},
+		{name: "float", content: "[media]\npreviews = 1.5", want: "line 2: media.previews must be true or false"},
+		{name: "dotted key", content: "\nmedia.animate = \"no\"", want: "line 2: media.animate must be true or false"},
+		{name: "array of tables", content: "[[profiles]]", want: "line 1: arrays of tables are not supported"},
+		{name: "unknown setting", content: "\ncolour = \"red\"", want: "line 2: unknown setting colour"},
+		{name: "wrong type", content: "[media]\npreviews = \"yes\"", want: "line 2: media.previews must be true or false"},
+		{name: "unknown table", content: "[colors]", want: "line 1: unknown table [colors]"},
+		{name: "duplicate key", content: "hashtag = \"a\"\nhashtag = \"b\"", want: "line 2: hashtag is set twice"},
+		{name: "missing profile", content: "profile = \"work\"", want: "profile \"work\" has no [profiles.work] table"},
+		{name: "invalid value", content: "visibility = \"friends\"", want: "invalid visibility (config.toml:1): must be public, unlisted, private or direct"},
+		{name: "invalid keymap", content: "keymap = \"helix\"", want: "invalid keymap (config.toml:1): must be default, vim or emacs"},
+		{name: "invalid tab", content: "tabs = [\"news\"]", want: "unknown tab \"news\""},
+		{name: "profile tabs", content: "[profiles.a]\ntabs = []", want: "line 2: tabs cannot be set per profile"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("TERMINALRANT_AUTH_DIR", dir)
+			writeConfigFile(t, dir, tc.content)
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TERMINALRANT_AUTH_DIR", dir)
+	cfg, err := Load()
+	if err != nil || cfg.Visibility != "public" || len(cfg.Tabs) != 5 || !cfg.MediaAnimate {
+		t.Fatalf("expected defaults without a config file, got %#v, %v", cfg, err)
+	}
+	if !strings.Contains(cfg.Effective(), "not found, using defaults") {
+		t.Fatalf("expected the missing file to be noted")
+	}
+
+	if _, err := LoadWith(Overrides{ConfigPath: filepath.Join(dir, "other.toml")}); err == nil {
+		t.Fatalf("expected an explicitly named config file to be required")
+	}
+	if _, err := LoadWith(Overrides{Profile: "work"}); err == nil || !strings.Contains(err.Error(), "none are defined") {
+		t.Fatalf("expected unknown profile error, got %v", err)
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// settingSpec describes one config file setting. Settings under a table are
+// named "table.key".
+type settingSpec struct {
+	name string
+	kind valueKind
+	env  string // Environment variable that overrides the file, if any
+	def  tomlValue
+}
+
+// settingSpecs lists every setting, in the order `config check` prints them.
+var settingSpecs = []settingSpec{
+	{name: "instance", kind: kindString, env: "TERMINALRANT_INSTANCE", def: tomlValue{str: "https://mastodon.social"}},
+	{name: "hashtag", kind: kindString, env: "TERMINALRANT_HASHTAG", def: tomlValue{str: "terminalrant"}},
+	{name: "token_store", kind: kindString, env: "TERMINALRANT_TOKEN_STORE", def: tomlValue{str: "auto"}},
+	{name: "oauth_callback_port", kind: kindInt, env: "TERMINALRANT_OAUTH_CALLBACK_PORT", def: tomlValue{kind: kindInt, num: 45145}},
+	{name: "visibility", kind: kindString, env: "TERMINALRANT_VISIBILITY", def: tomlValue{str: "public"}},
+	{name: "post_tags", kind: kindString, env: "TERMINALRANT_POST_TAGS"},
+	{name: "reply_tags", kind: kindString, env: "TERMINALRANT_REPLY_TAGS"},
+	{name: "editor", kind: kindString, env: "TERMINALRANT_EDITOR"},
+	{name: "theme", kind: kindString, env: "TERMINALRANT_THEME", def: tomlValue{str: "default"}},
+	{name: "keymap", kind: kindString, env: "TERMINALRANT_KEYMAP", def: tomlValue{str: "default"}},
+	{name: "tabs", kind: kindList, def: tomlValue{kind: kindList, list: []string{"terminalrant", "trending", "following", "local", "federated"}}},
+	{name: "media.previews", kind: kindBool, def: tomlValue{kind: kindBool, flag: true}},
+	{name: "media.animate", kind: kindBool, def: tomlValue{kind: kindBool, flag: true}},
+}
+
+func findSetting(name string) (settingSpec, bool) {
+	for _, s := range settingSpecs {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return settingSpec{}, false
+}
+
+// setting is a value together with where it came from: "default", a file
+// line such as "config.toml:4", an environment variable or a flag.
+type setting struct {
+	value  tomlValue
+	source string
+}
+
+// configFile is what a config file sets.
+type configFile struct {
+	path     string
+	found    bool
+	profile  string // Profile used when none is picked on the command line
+	settings map[string]setting
+	profiles map[string]map[string]setting
+	keys     map[string][]string
+}
+
+// loadConfigFile reads and validates the config file at path. A missing file
+// is only an error when required.
+func loadConfigFile(path string, required bool) (configFile, error) {
+	f := configFile{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return f, nil
+		}
+		return configFile{}, fmt.Errorf("reading config file: %w", err)
+	}
+	f.found = true
+	doc, err := parseTOML(string(data))
+	if err != nil {
+		return configFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := f.decode(doc); err != nil {
+		return configFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// decode checks every table and key of doc against the known settings.
+func (f *configFile) decode(doc tomlDoc) error {
+	base := filepath.Base(f.path)
+	source := func(v tomlValue) string { return fmt.Sprintf("%s:%d", base, v.line) }
+	f.settings = map[string]setting{}
+	f.profiles = map[string]map[string]setting{}
+	f.keys = map[string][]string{}
+
+	names := make([]string, 0, len(doc.tables))
+	for name := range doc.tables {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return doc.lines[names[i]] < doc.lines[names[j]] })
+
+	for _, table := range names {
+		values := doc.tables[table]
+		switch {
+		case table == "":
+			for _, key := range keysByLine(values) {
+				v := values[key]
+				if key == "profile" {
+					if v.kind != kindString || !validBareKey(v.str) {
+						return fmt.Errorf("line %d: profile must be a quoted profile name", v.line)
+					}
+					f.profile = v.str
+					continue
+				}
+				if err := checkSetting(key, v); err != nil {
+					return err
+				}
+				f.settings[key] = setting{value: v, source: source(v)}
+			}
+		case table == "media":
+			for _, key := range keysByLine(values) {
+				v := values[key]
+				if err := checkSetting("media."+key, v); err != nil {
+					return err
+				}
+				f.settings["media."+key] = setting{value: v, source: source(v)}
+			}
+		case table == "keys":
+			for _, action := range keysByLine(values) {
+				v := values[action]
+				switch v.kind {
+				case kindString:
+					f.keys[action] = []string{v.str}
+				case kindList:
+					f.keys[action] = v.list
+				default:
+					return fmt.Errorf("line %d: keys.%s must be a key or a list of keys", v.line, action)
+				}
+				for _, k := range f.keys[action] {
+					if strings.TrimSpace(k) == "" {
+						return fmt.Errorf("line %d: keys.%s has an empty key", v.line, action)
+					}
+				}
+			}
+		case table == "profiles":
+			if len(values) > 0 {
+				return fmt.Errorf("line %d: settings go under [profiles.NAME], not [profiles]", doc.lines[table])
+			}
+		case strings.HasPrefix(table, "profiles."):
+			name := strings.TrimPrefix(table, "profiles.")
+			if strings.Contains(name, ".") {
+				return fmt.Errorf("line %d: unknown table [%s]", doc.lines[table], table)
+			}
+			profile := map[string]setting{}
+			for _, key := range keysByLine(values) {
+				v := values[key]
+				if strings.Contains(key, ".") || key == "tabs" {
+					return fmt.Errorf("line %d: %s cannot be set per profile", v.line, key)
+				}
+				if err := checkSetting(key, v); err != nil {
+					return err
+				}
+				profile[key] = setting{value: v, source: source(v)}
+			}
+			f.profiles[name] = profile
+		default:
+			return fmt.Errorf("line %d: unknown table [%s]", doc.lines[table], table)
+		}
+	}
+	if f.profile != "" {
+		if _, ok := f.profiles[f.profile]; !ok {
+			return fmt.Errorf("profile %q has no [profiles.%s] table", f.profile, f.profile)
+		}
+	}
+	return nil
+}
+
+// keysByLine orders the keys of a table as they appear in the file, so the
+// first mistake is the one reported.
+func keysByLine(values map[string]tomlValue) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return values[keys[i]].line < values[keys[j]].line })
+	return keys
+}
+
+func checkSetting(name string, v tomlValue) error {
+	spec, ok := findSetting(name)
+	if !ok {
+		return fmt.Errorf("line %d: unknown setting %s", v.line, name)
+	}
+	if v.kind != spec.kind {
+		return fmt.Errorf("line %d: %s must be %s", v.line, name, spec.kind)
+	}
+	return nil
+}
+
+// profileNames lists the profiles the file defines, sorted.
+func (f configFile) profileNames() []string {
+	names := make([]string, 0, len(f.profiles))
+	for name := range f.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// settingLabel names where a setting came from for error messages, e.g.
+// "TERMINALRANT_INSTANCE" or "visibility (config.toml:3)".
+func settingLabel(name string, s setting) string {
+	if strings.HasPrefix(s.source, "TERMINALRANT_") || strings.HasPrefix(s.source, "--") {
+		return s.source
+	}
+	return fmt.Sprintf("%s (%s)", name, s.source)
+}
+
+// Effective renders the merged configuration as a config file, noting where
+// each value came from.
+func (c Config) Effective() string {
+	var b strings.Builder
+	state := "not found, using defaults"
+	if c.configFound {
+		state = "loaded"
+	}
+	fmt.Fprintf(&b, "# config file: %s (%s)\n", c.ConfigPath, state)
+	if c.Profile != "" {
+		fmt.Fprintf(&b, "# profile: %s (state in %s)\n", c.Profile, filepath.Dir(c.UIStatePath))
+	}
+	if len(c.profiles) > 0 {
+		fmt.Fprintf(&b, "# profiles: %s\n", strings.Join(c.profiles, ", "))
+	}
+	table := ""
+	for _, spec := range settingSpecs {
+		if t, key, ok := strings.Cut(spec.name, "."); ok {
+			if t != table {
+				table = t
+				fmt.Fprintf(&b, "\n[%s]\n", t)
+			}
+			writeSetting(&b, key, c.settings[spec.name])
+			continue
+		}
+		writeSetting(&b, spec.name, c.settings[spec.name])
+	}
+	if len(c.Keys) > 0 {
+		b.WriteString("\n[keys]\n")
+		actions := make([]string, 0, len(c.Keys))
+		for action := range c.Keys {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+		for _, action := range actions {
+			writeSetting(&b, action, setting{value: tomlValue{kind: kindList, list: c.Keys[action]}, source: filepath.Base(c.ConfigPath)})
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeSetting(b *strings.Builder, key string, s setting) {
+	if !validBareKey(key) {
+		key = strconv.Quote(key)
+	}
+	line := key + " = " + s.value.String()
+	fmt.Fprintf(b, "%-44s # %s\n", line, s.source)
+}
+
+// envSetting reads the environment override of spec, if set.
+func envSetting(spec settingSpec) (setting, bool, error) {
+	if spec.env == "" {
+		return setting{}, false, nil
+	}
+	raw := strings.TrimSpace(os.Getenv(spec.env))
+	if raw == "" {
+		return setting{}, false, nil
+	}
+	v := tomlValue{kind: spec.kind, str: raw}
+	if spec.kind == kindInt {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return setting{}, false, fmt.Errorf("invalid %s: must be %s", spec.env, spec.kind)
+		}
+		v = tomlValue{kind: kindInt, num: n}
+	}
+	return setting{value: v, source: spec.env}, true, nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// tagPolicyFile is tags.json: a default policy plus per-account overrides
+// keyed by instance host (one account is signed in per instance).
+//
+//	{
+//	  "default":  {"tags": "terminalrant"},
+//	  "accounts": {"work.example": {"tags": "none", "inherit_reply_tags": true}}
+//	}
+type tagPolicyFile struct {
+	Default  *tagPolicyEntry           `json:"default"`
+	Accounts map[string]tagPolicyEntry `json:"accounts"`
+}
+
+type tagPolicyEntry struct {
+	Tags             string `json:"tags"` // none, active or a list of tags
+	InheritReplyTags bool   `json:"inherit_reply_tags"`
+}
+
+// LoadTagPolicy resolves the hashtag policy of the configured account from
+// the post_tags and reply_tags settings, falling back to
+// app.DefaultTagPolicy. tags.json has already been merged into them by Load.
+func LoadTagPolicy(cfg Config) (app.TagPolicy, error) {
+	policy := app.DefaultTagPolicy()
+	if cfg.PostTags != "" {
+		var err error
+		if policy, err = app.ParseTagPolicy(cfg.PostTags); err != nil {
+			return app.TagPolicy{}, fmt.Errorf("invalid post_tags: %w", err)
+		}
+	}
+	policy.InheritReplyTags = cfg.ReplyTags == "inherit"
+	return policy, nil
+}
+
+// mergeTagPolicyFile fills post_tags and reply_tags from the tags.json entry
+// for instanceURL where nothing else sets them, so the file ranks just above
+// the built-in defaults and `config check` shows what it contributed.
+func mergeTagPolicyFile(settings map[string]setting, path, instanceURL string) error {
+	entry, key, ok, err := readTagPolicyEntry(path, instanceURL)
+	if err != nil || !ok {
+		return err
+	}
+	source := filepath.Base(path) + " " + key
+	if s := settings["post_tags"]; s.source == "default" && strings.TrimSpace(entry.Tags) != "" {
+		settings["post_tags"] = setting{value: tomlValue{str: entry.Tags}, source: source}
+	}
+	if s := settings["reply_tags"]; s.source == "default" && entry.InheritReplyTags {
+		settings["reply_tags"] = setting{value: tomlValue{str: "inherit"}, source: source}
+	}
+	return nil
+}
+
+// readTagPolicyEntry returns the entry for instanceURL, falling back to the
+// file's default, and the key it was found under. ok is false when neither
+// exists.
+func readTagPolicyEntry(path, instanceURL string) (entry tagPolicyEntry, key string, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return tagPolicyEntry{}, "", false, nil
+	}
+	if err != nil {
+		return tagPolicyEntry{}, "", false, fmt.Errorf("reading tag policy: %w", err)
+	}
+	var f tagPolicyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return tagPolicyEntry{}, "", false, fmt.Errorf("parsing tag policy: %w", err)
+	}
+	host := instanceURL
+	if u, err := url.Parse(instanceURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	for key, entry := range f.Accounts {
+		if strings.EqualFold(key, host) || strings.EqualFold(strings.TrimRight(key, "/"), instanceURL) {
+			return entry, "accounts." + key, true, nil
+		}
+	}
+	if f.Default != nil {
+		return *f.Default, "default", true, nil
+	}
+	return tagPolicyEntry{}, "", false, nil
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// valueKind is the type a config value is written as.
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindBool
+	kindList  // list of strings
+	kindOther // any TOML value no setting takes, e.g. a float or a date
+)
+
+func (k valueKind) String() string {
+	switch k {
+	case kindInt:
+		return "a whole number"
+	case kindBool:
+		return "true or false"
+	case kindList:
+		return "a list of strings"
+	default:
+		return "a quoted string"
+	}
+}
+
+// tomlValue is one parsed value; only the field matching kind is set.
+type tomlValue struct {
+	kind valueKind
+	str  string
+	num  int
+	flag bool
+	list []string
+	line int // 0 when the value did not come from a file
+}
+
+func (v tomlValue) String() string {
+	switch v.kind {
+	case kindInt:
+		return strconv.Itoa(v.num)
+	case kindBool:
+		return strconv.FormatBool(v.flag)
+	case kindList:
+		quoted := make([]string, len(v.list))
+		for i, s := range v.list {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return strconv.Quote(v.str)
+	}
+}
+
+// tomlDoc is a parsed config file: the keys of each table, by dotted table
+// name ("" for the top level), and the line each table starts on.
+type tomlDoc struct {
+	tables map[string]map[string]tomlValue
+	lines  map[string]int
+}
+
+// parseTOML parses a config file with go-toml's parser, keeping the line of
+// every key so mistakes and `config check` can point at it. Dotted keys and
+// inline tables are filed under the table they name.
+func parseTOML(data string) (tomlDoc, error) {
+	doc := tomlDoc{
+		tables: map[string]map[string]tomlValue{"": {}},
+		lines:  map[string]int{"": 0},
+	}
+	var p unstable.Parser
+	p.Reset([]byte(data))
+	table := ""
+	for p.NextExpression() {
+		expr := p.Expression()
+		line := p.Shape(expr.Raw).Start.Line
+		switch expr.Kind {
+		case unstable.ArrayTable:
+			line = p.Shape(expr.Child().Raw).Start.Line
+			return tomlDoc{}, fmt.Errorf("line %d: arrays of tables are not supported", line)
+		case unstable.Table:
+			name := keyPath(expr.Key())
+			line = p.Shape(expr.Child().Raw).Start.Line
+			if _, dup := doc.tables[name]; dup {
+				return tomlDoc{}, fmt.Errorf("line %d: table [%s] is defined twice", line, name)
+			}
+			doc.addTable(name, line)
+			table = name
+		case unstable.KeyValue:
+			if err := doc.set(table, expr, line); err != nil {
+				return tomlDoc{}, err
+			}
+		}
+	}
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) {
+			return tomlDoc{}, fmt.Errorf("line %d: %s", p.Shape(p.Range(perr.Highlight)).Start.Line, perr.Message)
+		}
+		return tomlDoc{}, err
+	}
+	return doc, nil
+}
+
+func (d *tomlDoc) addTable(name string, line int) {
+	if _, ok := d.tables[name]; !ok {
+		d.tables[name] = map[string]tomlValue{}
+		d.lines[name] = line
+	}
+}
+
+// set files the key/value kv, found in table on line, under its full name.
+func (d *tomlDoc) set(table string, kv *unstable.Node, line int) error {
+	parts := keyParts(kv.Key())
+	for _, part := range parts[:len(parts)-1] {
+		table = joinKey(table, part)
+		d.addTable(table, line)
+	}
+	key := parts[len(parts)-1]
+	value := kv.Value()
+	if value.Kind == unstable.InlineTable {
+		table = joinKey(table, key)
+		d.addTable(table, line)
+		it := value.Children()
+		for it.Next() {
+			if err := d.set(table, it.Node(), line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, dup := d.tables[table][key]; dup {
+		return fmt.Errorf("line %d: %s is set twice", line, key)
+	}
+	v := toValue(value)
+	v.line = line
+	d.tables[table][key] = v
+	return nil
+}
+
+func toValue(n *unstable.Node) tomlValue {
+	switch n.Kind {
+	case unstable.String:
+		return tomlValue{kind: kindString, str: string(n.Data)}
+	case unstable.Bool:
+		return tomlValue{kind: kindBool, flag: string(n.Data) == "true"}
+	case unstable.Integer:
+		num, err := strconv.ParseInt(string(n.Data), 0, 0)
+		if err != nil {
+			return tomlValue{kind: kindOther}
+		}
+		return tomlValue{kind: kindInt, num: int(num)}
+	case unstable.Array:
+		list := []string{}
+		it := n.Children()
+		for it.Next() {
+			if it.Node().Kind != unstable.String {
+				return tomlValue{kind: kindOther}
+			}
+			list = append(list, string(it.Node().Data))
+		}
+		return tomlValue{kind: kindList, list: list}
+	}
+	return tomlValue{kind: kindOther}
+}
+
+func keyParts(it unstable.Iterator) []string {
+	var parts []string
+	for it.Next() {
+		parts = append(parts, string(it.Node().Data))
+	}
+	return parts
+}
+
+func keyPath(it unstable.Iterator) string {
+	return strings.Join(keyParts(it), ".")
+}
+
+func joinKey(table, key string) string {
+	if table == "" {
+		return key
+	}
+	return table + "." + key
+}
+
+// validBareKey reports whether s can be written without quotes as a key.
+func validBareKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
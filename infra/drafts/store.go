@@ -0,0 +1,184 @@
+// Package drafts keeps unsent compose content on disk, one JSON file per
+// draft, so nothing typed is lost to a cancel, a failed post or a crash.
+package drafts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+const fileExt = ".json"
+
+type draftFile struct {
+	ID            string    `json:"id"`
+	Content       string    `json:"content"`
+	RantID        string    `json:"rant_id,omitempty"`
+	ParentID      string    `json:"parent_id,omitempty"`
+	ParentAuthor  string    `json:"parent_author,omitempty"`
+	ParentSummary string    `json:"parent_summary,omitempty"`
+	Hashtags      string    `json:"hashtags,omitempty"`
+	Visibility    string    `json:"visibility,omitempty"`
+	SpoilerText   string    `json:"spoiler_text,omitempty"`
+	MediaIDs      []string  `json:"media_ids,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Store implements app.DraftStore in a directory.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewStore creates a draft store rooted at dir. The directory is created on
+// first save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// ListDrafts returns all drafts, most recently updated first. Unreadable
+// files are skipped so one corrupt draft does not hide the others.
+func (s *Store) ListDrafts() ([]app.Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading drafts: %w", err)
+	}
+	out := make([]app.Draft, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileExt) {
+			continue
+		}
+		d, err := s.read(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		out = append(out, d)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].UpdatedAt.After(out[j].UpdatedAt)
+	})
+	return out, nil
+}
+
+// SaveDraft creates or replaces a draft, keeping the original CreatedAt.
+func (s *Store) SaveDraft(d app.Draft) (app.Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = app.NewDraftID()
+	}
+	path, err := s.path(d.ID)
+	if err != nil {
+		return app.Draft{}, err
+	}
+	now := time.Now().UTC()
+	if old, err := s.read(path); err == nil {
+		d.CreatedAt = old.CreatedAt
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = now
+	}
+	d.UpdatedAt = now
+
+	data, err := json.MarshalIndent(draftFile{
+		ID:            d.ID,
+		Content:       d.Content,
+		RantID:        d.RantID,
+		ParentID:      d.ParentID,
+		ParentAuthor:  d.ParentAuthor,
+		ParentSummary: d.ParentSummary,
+		Hashtags:      d.Hashtags,
+		Visibility:    d.Visibility,
+		SpoilerText:   d.SpoilerText,
+		MediaIDs:      d.MediaIDs,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}, "", "  ")
+	if err != nil {
+		return app.Draft{}, fmt.Errorf("encoding draft: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return app.Draft{}, fmt.Errorf("creating drafts directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".draft-*")
+	if err != nil {
+		return app.Draft{}, fmt.Errorf("writing draft: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return app.Draft{}, fmt.Errorf("writing draft: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return app.Draft{}, fmt.Errorf("writing draft: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return app.Draft{}, fmt.Errorf("writing draft: %w", err)
+	}
+	return d, nil
+}
+
+// DeleteDraft removes a draft. Deleting a missing draft is not an error.
+func (s *Store) DeleteDraft(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting draft: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid draft id %q", id)
+	}
+	return filepath.Join(s.dir, id+fileExt), nil
+}
+
+func (s *Store) read(path string) (app.Draft, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return app.Draft{}, err
+	}
+	var f draftFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return app.Draft{}, fmt.Errorf("parsing draft: %w", err)
+	}
+	return app.Draft{
+		ID:            f.ID,
+		Content:       f.Content,
+		RantID:        f.RantID,
+		ParentID:      f.ParentID,
+		ParentAuthor:  f.ParentAuthor,
+		ParentSummary: f.ParentSummary,
+		Hashtags:      f.Hashtags,
+		Visibility:    f.Visibility,
+		SpoilerText:   f.SpoilerText,
+		MediaIDs:      f.MediaIDs,
+		CreatedAt:     f.CreatedAt,
+		UpdatedAt:     f.UpdatedAt,
+	}, nil
+}
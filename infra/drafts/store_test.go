@@ -0,0 +1,86 @@
+package drafts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+func TestStore_SaveListDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "drafts")
+	s := NewStore(dir)
+
+	list, err := s.ListDrafts()
+	if err != nil || len(list) != 0 {
+		t.Fatalf("expected empty list before first save, got %v, %v", list, err)
+	}
+
+	first, err := s.SaveDraft(app.Draft{Content: "half a rant"})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if first.ID == "" || first.CreatedAt.IsZero() {
+		t.Fatalf("expected ID and timestamps to be assigned, got %#v", first)
+	}
+	time.Sleep(2 * time.Millisecond)
+	reply, err := s.SaveDraft(app.Draft{Content: "agreed", ParentID: "42", ParentAuthor: "alice", Hashtags: "#golang", Visibility: "unlisted", SpoilerText: "spoilers", MediaIDs: []string{"m1"}})
+	if err != nil {
+		t.Fatalf("save reply: %v", err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	first.Content = "a whole rant"
+	updated, err := s.SaveDraft(first)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !updated.CreatedAt.Equal(first.CreatedAt) || !updated.UpdatedAt.After(first.UpdatedAt) {
+		t.Fatalf("expected CreatedAt kept and UpdatedAt bumped")
+	}
+
+	list, err = s.ListDrafts()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != first.ID || list[0].Content != "a whole rant" {
+		t.Fatalf("expected updated draft first, got %#v", list)
+	}
+	if !list[1].IsReply() || list[1].ParentAuthor != "alice" || list[1].Hashtags != "#golang" {
+		t.Fatalf("expected reply context to round-trip, got %#v", list[1])
+	}
+	if opts := list[1].PostOptions(); opts.Visibility != "unlisted" || opts.SpoilerText != "spoilers" || len(opts.MediaIDs) != 1 || opts.MediaIDs[0] != "m1" {
+		t.Fatalf("expected redraft settings to round-trip, got %#v", opts)
+	}
+
+	if err := s.DeleteDraft(reply.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := s.DeleteDraft(reply.ID); err != nil {
+		t.Fatalf("deleting a missing draft should not fail: %v", err)
+	}
+	list, _ = s.ListDrafts()
+	if len(list) != 1 {
+		t.Fatalf("expected one draft after delete, got %d", len(list))
+	}
+}
+
+func TestStore_SkipsCorruptFilesAndRejectsBadIDs(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	if _, err := s.SaveDraft(app.Draft{Content: "ok"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	list, err := s.ListDrafts()
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected corrupt draft skipped, got %d drafts, %v", len(list), err)
+	}
+	if _, err := s.SaveDraft(app.Draft{ID: "../escape", Content: "x"}); err == nil {
+		t.Fatal("expected path-like draft ID to be rejected")
+	}
+}
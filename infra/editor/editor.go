@@ -7,14 +7,18 @@ import (
 	"strings"
 )
 
-// EnvEditor prepares an external editor command using $EDITOR (fallback: "vi").
+// EnvEditor prepares an external editor command using the configured command
+// or $EDITOR (fallback: "vi").
 // It does NOT run the editor itself — callers use tea.Exec with the returned
 // *exec.Cmd so Bubble Tea properly suspends raw terminal mode.
-type EnvEditor struct{}
+type EnvEditor struct {
+	command string
+}
 
-// NewEnvEditor creates an EnvEditor.
-func NewEnvEditor() *EnvEditor {
-	return &EnvEditor{}
+// NewEnvEditor creates an EnvEditor. A non-empty command, such as
+// "code --wait", is used instead of $EDITOR; it is split at spaces.
+func NewEnvEditor(command string) *EnvEditor {
+	return &EnvEditor{command: strings.TrimSpace(command)}
 }
 
 const instructionComment = `<!-- 
@@ -22,18 +26,30 @@ TerminalRant: Edit your rant below.
 
 - SAVE and EXIT to post/update (e.g., :wq in vi).
 - Emptying the file or making NO CHANGES will cancel.
-- The tracked hashtag will be added automatically.
+- Long rants are posted as a numbered thread; a line with only --- starts
+  the next post.
+- To schedule, put front matter above the rant:
+    ---
+    schedule: tomorrow 9am
+    ---
+  A leading --- block holding anything but a schedule line is kept as
+  rant text, so a thread may also start with a --- separator.
 -->
 
 `
 
 // Cmd prepares an *exec.Cmd for the editor and a temp file path.
 // It writes the provided content (and an instruction comment) to the temp file.
-func (e *EnvEditor) Cmd(content string, parentAuthor string) (*exec.Cmd, string, error) {
+// The comment lists tags, the hashtags that will be added when missing.
+func (e *EnvEditor) Cmd(content string, parentAuthor string, tags ...string) (*exec.Cmd, string, error) {
 	editorCmd := os.Getenv("EDITOR")
 	if editorCmd == "" {
 		editorCmd = "vi"
 	}
+	var editorArgs []string
+	if fields := strings.Fields(e.command); len(fields) > 0 {
+		editorCmd, editorArgs = fields[0], fields[1:]
+	}
 
 	tmpFile, err := os.CreateTemp("", "terminalrant-*.md")
 	if err != nil {
@@ -46,13 +62,16 @@ func (e *EnvEditor) Cmd(content string, parentAuthor string) (*exec.Cmd, string,
 	if parentAuthor != "" {
 		instruction = strings.Replace(instruction, "Edit your rant below.", fmt.Sprintf("Replying to %s", parentAuthor), 1)
 	}
+	if len(tags) > 0 {
+		instruction = strings.Replace(instruction, "will cancel.\n", "will cancel.\n- Added on send unless already present: "+strings.Join(tags, " ")+"\n", 1)
+	}
 
 	if _, err := tmpFile.WriteString(instruction + content); err != nil {
 		os.Remove(tmpPath)
 		return nil, "", fmt.Errorf("writing to temp file: %w", err)
 	}
 
-	cmd := exec.Command(editorCmd, "+", tmpPath)
+	cmd := exec.Command(editorCmd, append(editorArgs, "+", tmpPath)...)
 	return cmd, tmpPath, nil
 }
 
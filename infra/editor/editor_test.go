@@ -8,7 +8,7 @@ import (
 
 func TestCmd_UsesEditorAndWritesTemplate(t *testing.T) {
 	t.Setenv("EDITOR", "cat")
-	e := NewEnvEditor()
+	e := NewEnvEditor("")
 
 	cmd, path, err := e.Cmd("hello", "@alice")
 	if err != nil {
@@ -25,10 +25,23 @@ func TestCmd_UsesEditorAndWritesTemplate(t *testing.T) {
 	if !strings.Contains(text, "Replying to @alice") || !strings.Contains(text, "hello") {
 		t.Fatalf("unexpected template content: %q", text)
 	}
+	if strings.Contains(text, "Added on send") {
+		t.Fatalf("no tags line expected without tags: %q", text)
+	}
+
+	_, path, err = e.Cmd("hello", "", "#terminalrant", "#golang")
+	if err != nil {
+		t.Fatalf("cmd with tags failed: %v", err)
+	}
+	defer os.Remove(path)
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "Added on send unless already present: #terminalrant #golang") {
+		t.Fatalf("expected tags in instructions: %q", string(data))
+	}
 }
 
 func TestReadContent_StripsInstructionAndDeletesFile(t *testing.T) {
-	e := NewEnvEditor()
+	e := NewEnvEditor("")
 	f, err := os.CreateTemp("", "terminalrant-test-*.md")
 	if err != nil {
 		t.Fatalf("create temp failed: %v", err)
@@ -48,3 +61,17 @@ func TestReadContent_StripsInstructionAndDeletesFile(t *testing.T) {
 		t.Fatalf("expected temp file to be deleted")
 	}
 }
+
+func TestCmd_ConfiguredCommandOverridesEditor(t *testing.T) {
+	t.Setenv("EDITOR", "vi")
+	e := NewEnvEditor("cat -n")
+
+	cmd, path, err := e.Cmd("hello", "")
+	if err != nil {
+		t.Fatalf("cmd failed: %v", err)
+	}
+	defer os.Remove(path)
+	if len(cmd.Args) != 4 || cmd.Args[0] != "cat" || cmd.Args[1] != "-n" || cmd.Args[3] != path {
+		t.Fatalf("expected configured command with its arguments, got %v", cmd.Args)
+	}
+}
@@ -0,0 +1,223 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// Format selects how rants are written for non-interactive output.
+type Format string
+
+const (
+	Text     Format = "text"
+	JSON     Format = "json"     // a single JSON array of Rant objects
+	NDJSON   Format = "ndjson"   // one Rant object per line
+	Markdown Format = "markdown" // human-readable, paste-friendly
+)
+
+// Names lists the accepted format names in display order.
+var Names = []string{string(Text), string(JSON), string(NDJSON), string(Markdown)}
+
+// Parse converts a user-supplied name into a Format.
+func Parse(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case Text, "":
+		return Text, nil
+	case JSON:
+		return JSON, nil
+	case NDJSON:
+		return NDJSON, nil
+	case Markdown, "md":
+		return Markdown, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (want %s)", s, strings.Join(Names, ", "))
+	}
+}
+
+// SchemaVersion is bumped whenever a field of Rant is renamed or removed.
+// Adding fields is not a breaking change.
+const SchemaVersion = 1
+
+// Rant is the stable JSON representation of domain.Rant. Every field is
+// always present; in_reply_to_id is null for top-level posts and media is an
+// empty array when there are no attachments.
+type Rant struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+	Account     Account   `json:"account"`
+	Content     string    `json:"content"`
+	InReplyToID *string   `json:"in_reply_to_id"`
+	Counts      Counts    `json:"counts"`
+	Liked       bool      `json:"liked"`
+	Own         bool      `json:"own"`
+	Media       []Media   `json:"media"`
+}
+
+// Account identifies the author of a rant.
+type Account struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+}
+
+// Counts holds engagement totals.
+type Counts struct {
+	Likes   int `json:"likes"`
+	Replies int `json:"replies"`
+}
+
+// Media is an attachment on a rant.
+type Media struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	URL         string `json:"url"`
+	PreviewURL  string `json:"preview_url"`
+	Description string `json:"description"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// Document is the --format json output: the schema version and every rant.
+type Document struct {
+	SchemaVersion int    `json:"schema_version"`
+	Rants         []Rant `json:"rants"`
+}
+
+// Line is one line of --format ndjson output: a rant with the schema
+// version alongside its fields, so each line stands on its own.
+type Line struct {
+	SchemaVersion int `json:"schema_version"`
+	Rant
+}
+
+// FromRant maps a domain rant to its JSON representation.
+func FromRant(r domain.Rant) Rant {
+	out := Rant{
+		ID:        r.ID,
+		URL:       r.URL,
+		CreatedAt: r.CreatedAt.UTC(),
+		Account: Account{
+			ID:          r.AccountID,
+			Username:    r.Username,
+			DisplayName: r.Author,
+		},
+		Content: strings.TrimSpace(r.Content),
+		Counts: Counts{
+			Likes:   r.LikesCount,
+			Replies: r.RepliesCount,
+		},
+		Liked: r.Liked,
+		Own:   r.IsOwn,
+		Media: make([]Media, 0, len(r.Media)),
+	}
+	if r.InReplyToID != "" {
+		id := r.InReplyToID
+		out.InReplyToID = &id
+	}
+	for _, m := range r.Media {
+		out.Media = append(out.Media, Media{
+			ID:          m.ID,
+			Type:        m.Type,
+			URL:         m.URL,
+			PreviewURL:  m.PreviewURL,
+			Description: m.Description,
+			Width:       m.Width,
+			Height:      m.Height,
+		})
+	}
+	return out
+}
+
+// WriteRants writes rants to w in the requested format.
+func WriteRants(w io.Writer, f Format, rants []domain.Rant) error {
+	switch f {
+	case JSON:
+		doc := Document{SchemaVersion: SchemaVersion, Rants: make([]Rant, 0, len(rants))}
+		for _, r := range rants {
+			doc.Rants = append(doc.Rants, FromRant(r))
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		for _, r := range rants {
+			if err := enc.Encode(Line{SchemaVersion: SchemaVersion, Rant: FromRant(r)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Markdown:
+		return writeMarkdown(w, rants)
+	default:
+		return writeText(w, rants)
+	}
+}
+
+func writeText(w io.Writer, rants []domain.Rant) error {
+	var b strings.Builder
+	for i, r := range rants {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s  @%s", r.ID, r.Username)
+		if !r.CreatedAt.IsZero() {
+			fmt.Fprintf(&b, "  %s", r.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		b.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(r.Content), "\n") {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdown(w io.Writer, rants []domain.Rant) error {
+	var b strings.Builder
+	for i, r := range rants {
+		if i > 0 {
+			b.WriteString("\n---\n\n")
+		}
+		name := r.Author
+		if name == "" {
+			name = r.Username
+		}
+		fmt.Fprintf(&b, "### %s (@%s)\n\n", name, r.Username)
+		for _, line := range strings.Split(strings.TrimSpace(r.Content), "\n") {
+			fmt.Fprintf(&b, "> %s\n", line)
+		}
+		b.WriteString("\n")
+		for _, m := range r.Media {
+			label := m.Description
+			if label == "" {
+				label = m.Type
+			}
+			if m.Type == "image" {
+				fmt.Fprintf(&b, "![%s](%s)\n", label, m.URL)
+			} else {
+				fmt.Fprintf(&b, "- [%s](%s)\n", label, m.URL)
+			}
+		}
+		if len(r.Media) > 0 {
+			b.WriteString("\n")
+		}
+		meta := []string{}
+		if !r.CreatedAt.IsZero() {
+			meta = append(meta, r.CreatedAt.UTC().Format("2006-01-02 15:04 UTC"))
+		}
+		meta = append(meta, fmt.Sprintf("%d likes", r.LikesCount), fmt.Sprintf("%d replies", r.RepliesCount))
+		if r.URL != "" {
+			meta = append(meta, fmt.Sprintf("[open](%s)", r.URL))
+		}
+		fmt.Fprintf(&b, "_%s_\n", strings.Join(meta, " · "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
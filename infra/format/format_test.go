@@ -0,0 +1,123 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+func sampleRants() []domain.Rant {
+	return []domain.Rant{
+		{
+			ID:           "2",
+			AccountID:    "a1",
+			Author:       "Alice",
+			Username:     "alice",
+			Content:      "works on my machine",
+			CreatedAt:    time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			URL:          "https://example/2",
+			LikesCount:   3,
+			RepliesCount: 1,
+			Media: []domain.MediaAttachment{
+				{ID: "m1", Type: "image", URL: "https://example/m1.png", Description: "stack trace"},
+			},
+		},
+		{
+			ID:          "3",
+			AccountID:   "b2",
+			Username:    "bob",
+			Content:     "same",
+			InReplyToID: "2",
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]Format{"": Text, "JSON": JSON, "ndjson": NDJSON, "md": Markdown, "markdown": Markdown} {
+		got, err := Parse(in)
+		if err != nil || got != want {
+			t.Fatalf("Parse(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := Parse("yaml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestWriteRants_JSONSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRants(&buf, JSON, sampleRants()); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	var doc struct {
+		SchemaVersion int              `json:"schema_version"`
+		Rants         []map[string]any `json:"rants"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc.SchemaVersion != SchemaVersion {
+		t.Fatalf("expected schema_version %d, got %d", SchemaVersion, doc.SchemaVersion)
+	}
+	got := doc.Rants
+	if len(got) != 2 {
+		t.Fatalf("expected 2 rants, got %d", len(got))
+	}
+	first := got[0]
+	for _, key := range []string{"id", "url", "created_at", "account", "content", "in_reply_to_id", "counts", "liked", "own", "media"} {
+		if _, ok := first[key]; !ok {
+			t.Fatalf("missing key %q in %v", key, first)
+		}
+	}
+	if first["in_reply_to_id"] != nil {
+		t.Fatalf("top-level post should have null in_reply_to_id")
+	}
+	if got[1]["in_reply_to_id"] != "2" {
+		t.Fatalf("reply link not preserved: %v", got[1]["in_reply_to_id"])
+	}
+	if media, ok := got[1]["media"].([]any); !ok || len(media) != 0 {
+		t.Fatalf("media should be an empty array, got %#v", got[1]["media"])
+	}
+	counts := first["counts"].(map[string]any)
+	if counts["likes"] != float64(3) || counts["replies"] != float64(1) {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+}
+
+func TestWriteRants_NDJSONOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRants(&buf, NDJSON, sampleRants()); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	var r Line
+	if err := json.Unmarshal([]byte(lines[0]), &r); err != nil {
+		t.Fatalf("line is not a rant object: %v", err)
+	}
+	if r.SchemaVersion != SchemaVersion {
+		t.Fatalf("expected schema_version on every line, got %d", r.SchemaVersion)
+	}
+	if r.Account.Username != "alice" || len(r.Media) != 1 || r.Media[0].Description != "stack trace" {
+		t.Fatalf("unexpected decoded rant: %#v", r)
+	}
+}
+
+func TestWriteRants_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRants(&buf, Markdown, sampleRants()); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"### Alice (@alice)", "> works on my machine", "![stack trace](https://example/m1.png)", "[open](https://example/2)", "---"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
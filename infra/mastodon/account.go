@@ -52,6 +52,10 @@ func (s *accountService) CurrentProfile(_ context.Context) (app.Profile, error)
 		StatusesCount  int    `json:"statuses_count"`
 		FollowersCount int    `json:"followers_count"`
 		FollowingCount int    `json:"following_count"`
+		Locked         bool   `json:"locked"`
+		Source         struct {
+			FollowRequestsCount int `json:"follow_requests_count"`
+		} `json:"source"`
 	}
 	if err := json.Unmarshal(data, &acct); err != nil {
 		return app.Profile{}, fmt.Errorf("parsing account: %w", err)
@@ -68,6 +72,9 @@ func (s *accountService) CurrentProfile(_ context.Context) (app.Profile, error)
 		PostsCount:  acct.StatusesCount,
 		Followers:   acct.FollowersCount,
 		Following:   acct.FollowingCount,
+		Locked:      acct.Locked,
+
+		FollowRequests: acct.Source.FollowRequestsCount,
 	}, nil
 }
 
@@ -83,32 +90,75 @@ func (s *accountService) UpdateProfile(_ context.Context, displayName, bio strin
 	return nil
 }
 
-func (s *accountService) FollowUser(_ context.Context, accountID string) error {
+// mastodonRelationship is the JSON shape of a Mastodon Relationship entity.
+type mastodonRelationship struct {
+	ID             string `json:"id"`
+	Following      bool   `json:"following"`
+	FollowedBy     bool   `json:"followed_by"`
+	Requested      bool   `json:"requested"`
+	Muting         bool   `json:"muting"`
+	Blocking       bool   `json:"blocking"`
+	DomainBlocking bool   `json:"domain_blocking"`
+	ShowingReblogs bool   `json:"showing_reblogs"`
+	Notifying      bool   `json:"notifying"`
+	Note           string `json:"note"`
+}
+
+func (r mastodonRelationship) toApp() app.Relationship {
+	return app.Relationship{
+		ID:             sanitizeForTerminal(r.ID),
+		Following:      r.Following,
+		FollowedBy:     r.FollowedBy,
+		Requested:      r.Requested,
+		Muting:         r.Muting,
+		Blocking:       r.Blocking,
+		DomainBlocking: r.DomainBlocking,
+		ShowingReblogs: r.ShowingReblogs,
+		Notifying:      r.Notifying,
+		Note:           sanitizeForTerminal(strings.TrimSpace(r.Note)),
+	}
+}
+
+// postRelationship posts to an account action endpoint that answers with the
+// updated relationship.
+func (s *accountService) postRelationship(accountID, action string) (app.Relationship, error) {
 	if strings.TrimSpace(accountID) == "" {
-		return fmt.Errorf("invalid account id")
+		return app.Relationship{}, fmt.Errorf("invalid account id")
 	}
-	path := fmt.Sprintf("/api/v1/accounts/%s/follow", accountID)
-	_, err := s.client.Post(path, nil)
+	path := fmt.Sprintf("/api/v1/accounts/%s/%s", accountID, action)
+	data, err := s.client.Post(path, nil)
 	if err != nil {
-		return fmt.Errorf("following user: %w", err)
+		return app.Relationship{}, err
 	}
-	return nil
+	var rel mastodonRelationship
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return app.Relationship{}, fmt.Errorf("parsing relationship: %w", err)
+	}
+	out := rel.toApp()
+	if out.ID == "" {
+		out.ID = accountID
+	}
+	return out, nil
 }
 
-func (s *accountService) UnfollowUser(_ context.Context, accountID string) error {
-	if strings.TrimSpace(accountID) == "" {
-		return fmt.Errorf("invalid account id")
+func (s *accountService) FollowUser(_ context.Context, accountID string) (app.Relationship, error) {
+	rel, err := s.postRelationship(accountID, "follow")
+	if err != nil {
+		return app.Relationship{}, fmt.Errorf("following user: %w", err)
 	}
-	path := fmt.Sprintf("/api/v1/accounts/%s/unfollow", accountID)
-	_, err := s.client.Post(path, nil)
+	return rel, nil
+}
+
+func (s *accountService) UnfollowUser(_ context.Context, accountID string) (app.Relationship, error) {
+	rel, err := s.postRelationship(accountID, "unfollow")
 	if err != nil {
-		return fmt.Errorf("unfollowing user: %w", err)
+		return app.Relationship{}, fmt.Errorf("unfollowing user: %w", err)
 	}
-	return nil
+	return rel, nil
 }
 
-func (s *accountService) LookupFollowing(_ context.Context, accountIDs []string) (map[string]bool, error) {
-	res := make(map[string]bool)
+func (s *accountService) LookupRelationships(_ context.Context, accountIDs []string) (map[string]app.Relationship, error) {
+	res := make(map[string]app.Relationship)
 	if len(accountIDs) == 0 {
 		return res, nil
 	}
@@ -132,19 +182,48 @@ func (s *accountService) LookupFollowing(_ context.Context, accountIDs []string)
 	if err != nil {
 		return nil, fmt.Errorf("fetching relationships: %w", err)
 	}
-	var rels []struct {
-		ID        string `json:"id"`
-		Following bool   `json:"following"`
-	}
+	var rels []mastodonRelationship
 	if err := json.Unmarshal(data, &rels); err != nil {
 		return nil, fmt.Errorf("parsing relationships: %w", err)
 	}
 	for _, r := range rels {
-		res[sanitizeForTerminal(r.ID)] = r.Following
+		rel := r.toApp()
+		res[rel.ID] = rel
 	}
 	return res, nil
 }
 
+func (s *accountService) ResolveAccount(_ context.Context, profileURL string) (app.AccountSummary, error) {
+	profileURL = strings.TrimSpace(profileURL)
+	if profileURL == "" {
+		return app.AccountSummary{}, fmt.Errorf("resolving account: missing URL")
+	}
+	params := url.Values{}
+	params.Set("q", profileURL)
+	params.Set("type", "accounts")
+	params.Set("resolve", "true")
+	params.Set("limit", "1")
+	data, err := s.client.Get("/api/v2/search?" + params.Encode())
+	if err != nil {
+		return app.AccountSummary{}, fmt.Errorf("resolving account: %w", err)
+	}
+	var result struct {
+		Accounts []mastodonAccount `json:"accounts"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return app.AccountSummary{}, fmt.Errorf("parsing resolved account: %w", err)
+	}
+	if len(result.Accounts) == 0 {
+		return app.AccountSummary{}, fmt.Errorf("resolving account: %w", app.ErrNotFound)
+	}
+	a := result.Accounts[0]
+	return app.AccountSummary{
+		ID:          sanitizeForTerminal(a.ID),
+		Username:    sanitizeForTerminal(a.Acct),
+		DisplayName: sanitizeForTerminal(a.DisplayName),
+	}, nil
+}
+
 func (s *accountService) ProfileByID(_ context.Context, accountID string) (app.Profile, error) {
 	accountID = strings.TrimSpace(accountID)
 	if accountID == "" {
@@ -232,12 +311,14 @@ func (s *accountService) PostsByAccount(_ context.Context, accountID string, lim
 			Username:     sanitizeForTerminal(st.Account.Acct),
 			Content:      stripHTML(st.Content),
 			CreatedAt:    createdAt,
+			EditedAt:     parseEditedAt(st.EditedAt),
 			URL:          sanitizeForTerminal(st.URL),
 			IsOwn:        s.cachedID != "" && st.Account.ID == s.cachedID,
 			Liked:        st.Favourited,
 			LikesCount:   st.FavouritesCount,
 			RepliesCount: st.RepliesCount,
 			InReplyToID:  inReplyToID,
+			Visibility:   st.Visibility,
 			Media:        mapMediaAttachments(st.MediaAttachments),
 		})
 	}
@@ -297,3 +378,119 @@ func (s *accountService) UnblockUser(_ context.Context, accountID string) error
 	}
 	return nil
 }
+
+func (s *accountService) ListDomainBlocks(_ context.Context, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	data, err := s.client.Get(fmt.Sprintf("/api/v1/domain_blocks?limit=%d", limit))
+	if err != nil {
+		return nil, fmt.Errorf("fetching blocked domains: %w", err)
+	}
+	var domains []string
+	if err := json.Unmarshal(data, &domains); err != nil {
+		return nil, fmt.Errorf("parsing blocked domains: %w", err)
+	}
+	out := make([]string, 0, len(domains))
+	for _, d := range domains {
+		if d = sanitizeForTerminal(strings.TrimSpace(d)); d != "" {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (s *accountService) BlockDomain(_ context.Context, domain string) error {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return fmt.Errorf("invalid domain")
+	}
+	form := url.Values{}
+	form.Set("domain", domain)
+	if _, err := s.client.Post("/api/v1/domain_blocks", strings.NewReader(form.Encode())); err != nil {
+		return fmt.Errorf("blocking domain: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) UnblockDomain(_ context.Context, domain string) error {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return fmt.Errorf("invalid domain")
+	}
+	if _, err := s.client.Delete("/api/v1/domain_blocks?domain=" + url.QueryEscape(domain)); err != nil {
+		return fmt.Errorf("unblocking domain: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) Followers(_ context.Context, accountID string, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, "", fmt.Errorf("invalid account id")
+	}
+	return fetchAccountPage(s.client, fmt.Sprintf("/api/v1/accounts/%s/followers", url.PathEscape(accountID)), limit, maxID)
+}
+
+func (s *accountService) Following(_ context.Context, accountID string, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, "", fmt.Errorf("invalid account id")
+	}
+	return fetchAccountPage(s.client, fmt.Sprintf("/api/v1/accounts/%s/following", url.PathEscape(accountID)), limit, maxID)
+}
+
+func (s *accountService) FollowRequests(_ context.Context, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	return fetchAccountPage(s.client, "/api/v1/follow_requests", limit, maxID)
+}
+
+func (s *accountService) AuthorizeFollowRequest(_ context.Context, accountID string) error {
+	if strings.TrimSpace(accountID) == "" {
+		return fmt.Errorf("invalid account id")
+	}
+	path := fmt.Sprintf("/api/v1/follow_requests/%s/authorize", url.PathEscape(accountID))
+	if _, err := s.client.Post(path, nil); err != nil {
+		return fmt.Errorf("authorizing follow request: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) RejectFollowRequest(_ context.Context, accountID string) error {
+	if strings.TrimSpace(accountID) == "" {
+		return fmt.Errorf("invalid account id")
+	}
+	path := fmt.Sprintf("/api/v1/follow_requests/%s/reject", url.PathEscape(accountID))
+	if _, err := s.client.Post(path, nil); err != nil {
+		return fmt.Errorf("rejecting follow request: %w", err)
+	}
+	return nil
+}
+
+// fetchAccountPage reads a list of accounts whose paging cursor is only
+// available via the Link header.
+func fetchAccountPage(client *Client, base string, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	if limit <= 0 {
+		limit = 40
+	}
+	path := fmt.Sprintf("%s?limit=%d", base, limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	data, next, err := client.GetPage(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %s: %w", base, err)
+	}
+
+	var accounts []mastodonAccount
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", base, err)
+	}
+
+	out := make([]app.AccountSummary, 0, len(accounts))
+	for _, a := range accounts {
+		out = append(out, app.AccountSummary{
+			ID:          sanitizeForTerminal(a.ID),
+			Username:    sanitizeForTerminal(a.Acct),
+			DisplayName: sanitizeForTerminal(a.DisplayName),
+		})
+	}
+	return out, next, nil
+}
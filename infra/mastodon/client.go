@@ -4,20 +4,25 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/CrestNiraj12/terminalrant/infra/auth"
 )
 
 // Client is a thin HTTP wrapper for the Mastodon API.
-// It handles base URL construction and bearer token injection.
+// It handles base URL construction and bearer token injection. A Client
+// without a TokenProvider makes anonymous requests, which is enough to read
+// the public timelines of most instances.
 type Client struct {
 	baseURL       string
 	tokenProvider auth.TokenProvider
 	http          *http.Client
 }
 
-// NewClient creates a Mastodon API client.
+// NewClient creates a Mastodon API client. tp may be nil for anonymous
+// access.
 func NewClient(baseURL string, tp auth.TokenProvider) *Client {
 	return &Client{
 		baseURL:       baseURL,
@@ -33,6 +38,18 @@ func (c *Client) Get(path string) ([]byte, error) {
 	return c.do(http.MethodGet, path, nil)
 }
 
+// GetPage performs an authenticated GET request and returns the max_id of the
+// next (older) page from the Link header, or "" when there are no more pages.
+// Endpoints such as bookmarks and favourites page by internal IDs that are
+// only exposed this way.
+func (c *Client) GetPage(path string) ([]byte, string, error) {
+	data, header, err := c.doWithHeader(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, nextMaxID(header.Get("Link")), nil
+}
+
 // Post performs an authenticated POST request.
 func (c *Client) Post(path string, body io.Reader) ([]byte, error) {
 	return c.do(http.MethodPost, path, body)
@@ -54,37 +71,67 @@ func (c *Client) Delete(path string) ([]byte, error) {
 }
 
 func (c *Client) do(method, path string, body io.Reader) ([]byte, error) {
-	token, err := c.tokenProvider.AccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("auth: %w", err)
-	}
-
-	url := c.baseURL + path
+	data, _, err := c.doWithHeader(method, path, body)
+	return data, err
+}
 
-	req, err := http.NewRequest(method, url, body)
+func (c *Client) doWithHeader(method, path string, body io.Reader) ([]byte, http.Header, error) {
+	req, err := http.NewRequest(method, c.baseURL+path, body)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
+	if c.tokenProvider != nil {
+		token, err := c.tokenProvider.AccessToken()
+		if err != nil {
+			return nil, nil, fmt.Errorf("auth: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request to %s: %w", path, err)
+		return nil, nil, fmt.Errorf("request to %s: %w", path, err)
 	}
 	defer resp.Body.Close()
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, nil, fmt.Errorf("reading response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API %s %s returned %d: %s", method, path, resp.StatusCode, string(data))
+		return nil, nil, fmt.Errorf("API %s %s returned %d: %s", method, path, resp.StatusCode, string(data))
 	}
 
-	return data, nil
+	return data, resp.Header, nil
+}
+
+// nextMaxID extracts max_id from the rel="next" entry of a Link header.
+func nextMaxID(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segs := strings.Split(part, ";")
+		if len(segs) < 2 {
+			continue
+		}
+		isNext := false
+		for _, p := range segs[1:] {
+			if strings.TrimSpace(p) == `rel="next"` {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+		raw := strings.Trim(strings.TrimSpace(segs[0]), "<>")
+		u, err := url.Parse(raw)
+		if err != nil {
+			return ""
+		}
+		return u.Query().Get("max_id")
+	}
+	return ""
 }
@@ -0,0 +1,180 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// instanceCacheTTL is how long fetched instance limits are trusted before
+// the instance is asked again.
+const instanceCacheTTL = 24 * time.Hour
+
+// instanceService implements app.InstanceService using the Mastodon API.
+// Limits are cached in memory and, when cachePath is set, on disk.
+type instanceService struct {
+	client    *Client
+	cachePath string
+
+	mu     sync.Mutex
+	cached *app.InstanceConfig
+}
+
+// NewInstanceService creates an InstanceService backed by Mastodon. An empty
+// cachePath disables the disk cache.
+func NewInstanceService(client *Client, cachePath string) *instanceService {
+	return &instanceService{client: client, cachePath: cachePath}
+}
+
+// mastodonInstanceConfiguration is the configuration block shared by the v1
+// and v2 instance entities.
+type mastodonInstanceConfiguration struct {
+	Statuses struct {
+		MaxCharacters            int `json:"max_characters"`
+		CharactersReservedPerURL int `json:"characters_reserved_per_url"`
+		MaxMediaAttachments      int `json:"max_media_attachments"`
+	} `json:"statuses"`
+	MediaAttachments struct {
+		ImageSizeLimit int64 `json:"image_size_limit"`
+		VideoSizeLimit int64 `json:"video_size_limit"`
+	} `json:"media_attachments"`
+	Polls struct {
+		MaxOptions             int `json:"max_options"`
+		MaxCharactersPerOption int `json:"max_characters_per_option"`
+		MinExpiration          int `json:"min_expiration"` // seconds
+		MaxExpiration          int `json:"max_expiration"` // seconds
+	} `json:"polls"`
+}
+
+type mastodonInstance struct {
+	Configuration mastodonInstanceConfiguration `json:"configuration"`
+}
+
+type instanceCacheFile struct {
+	Instance  string             `json:"instance"`
+	FetchedAt time.Time          `json:"fetched_at"`
+	Config    app.InstanceConfig `json:"config"`
+}
+
+func (s *instanceService) InstanceConfig(_ context.Context) (app.InstanceConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil {
+		return *s.cached, nil
+	}
+	cache, cacheErr := s.readCache()
+	if cacheErr == nil && time.Since(cache.FetchedAt) < instanceCacheTTL {
+		s.cached = &cache.Config
+		return cache.Config, nil
+	}
+
+	cfg, err := s.fetch()
+	if err != nil {
+		if cacheErr == nil {
+			// A stale answer from this instance beats the stock defaults.
+			return cache.Config, fmt.Errorf("fetching instance limits: %w", err)
+		}
+		return app.DefaultInstanceConfig(), fmt.Errorf("fetching instance limits: %w", err)
+	}
+	s.cached = &cfg
+	_ = s.writeCache(cfg)
+	return cfg, nil
+}
+
+func (s *instanceService) fetch() (app.InstanceConfig, error) {
+	data, err := s.client.Get("/api/v2/instance")
+	if err != nil {
+		// Servers older than Mastodon 4.0 only offer v1.
+		var v1Err error
+		data, v1Err = s.client.Get("/api/v1/instance")
+		if v1Err != nil {
+			return app.InstanceConfig{}, err
+		}
+	}
+	var raw mastodonInstance
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return app.InstanceConfig{}, fmt.Errorf("parsing instance: %w", err)
+	}
+	return mapInstanceConfiguration(raw.Configuration), nil
+}
+
+// mapInstanceConfiguration converts the API configuration, keeping the stock
+// default for any limit the instance does not report.
+func mapInstanceConfiguration(c mastodonInstanceConfiguration) app.InstanceConfig {
+	cfg := app.DefaultInstanceConfig()
+	if c.Statuses.MaxCharacters > 0 {
+		cfg.MaxCharacters = c.Statuses.MaxCharacters
+	}
+	if c.Statuses.CharactersReservedPerURL > 0 {
+		cfg.CharactersReservedPerURL = c.Statuses.CharactersReservedPerURL
+	}
+	if c.Statuses.MaxMediaAttachments > 0 {
+		cfg.MaxMediaAttachments = c.Statuses.MaxMediaAttachments
+	}
+	if c.MediaAttachments.ImageSizeLimit > 0 {
+		cfg.ImageSizeLimit = c.MediaAttachments.ImageSizeLimit
+	}
+	if c.MediaAttachments.VideoSizeLimit > 0 {
+		cfg.VideoSizeLimit = c.MediaAttachments.VideoSizeLimit
+	}
+	if c.Polls.MaxOptions > 0 {
+		cfg.MaxPollOptions = c.Polls.MaxOptions
+	}
+	if c.Polls.MaxCharactersPerOption > 0 {
+		cfg.MaxPollOptionCharacters = c.Polls.MaxCharactersPerOption
+	}
+	if c.Polls.MinExpiration > 0 {
+		cfg.MinPollExpiration = time.Duration(c.Polls.MinExpiration) * time.Second
+	}
+	if c.Polls.MaxExpiration > 0 {
+		cfg.MaxPollExpiration = time.Duration(c.Polls.MaxExpiration) * time.Second
+	}
+	return cfg
+}
+
+// readCache loads the disk cache if it belongs to this instance.
+func (s *instanceService) readCache() (instanceCacheFile, error) {
+	if s.cachePath == "" {
+		return instanceCacheFile{}, os.ErrNotExist
+	}
+	data, err := os.ReadFile(s.cachePath)
+	if err != nil {
+		return instanceCacheFile{}, err
+	}
+	var f instanceCacheFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return instanceCacheFile{}, fmt.Errorf("parsing instance cache: %w", err)
+	}
+	if f.Instance != s.client.baseURL || f.Config.MaxCharacters <= 0 {
+		return instanceCacheFile{}, os.ErrNotExist
+	}
+	return f, nil
+}
+
+func (s *instanceService) writeCache(cfg app.InstanceConfig) error {
+	if s.cachePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(instanceCacheFile{
+		Instance:  s.client.baseURL,
+		FetchedAt: time.Now().UTC(),
+		Config:    cfg,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding instance cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.cachePath), 0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	if err := os.WriteFile(s.cachePath, data, 0o600); err != nil {
+		return fmt.Errorf("writing instance cache: %w", err)
+	}
+	return nil
+}
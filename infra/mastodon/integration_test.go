@@ -3,11 +3,15 @@ package mastodon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 	"testing"
 	"time"
@@ -157,8 +161,8 @@ func TestPostService_Post_SendsForm(t *testing.T) {
 	})
 
 	client := newTestClient(h)
-	svc := NewPostService(client)
-	_, err := svc.Post(context.Background(), "hello", "terminalrant")
+	svc := NewPostService(client, "")
+	_, err := svc.Post(context.Background(), "hello", "terminalrant", app.PostOptions{})
 	if err != nil {
 		t.Fatalf("post failed: %v", err)
 	}
@@ -175,26 +179,68 @@ func TestPostService_Post_SendsForm(t *testing.T) {
 	if !strings.Contains(strings.ToLower(vals.Get("status")), domain.AppHashTag) {
 		t.Fatalf("expected required hashtag in status: %q", vals.Get("status"))
 	}
+
+	// The hashtag argument is the tag policy: none, or several tags.
+	if _, err := svc.Post(context.Background(), "work note", "", app.PostOptions{}); err != nil {
+		t.Fatalf("post without tags failed: %v", err)
+	}
+	vals, _ = url.ParseQuery(body)
+	if vals.Get("status") != "work note" {
+		t.Fatalf("expected no tags appended, got %q", vals.Get("status"))
+	}
+	if _, err := svc.Post(context.Background(), "about #Golang", "#golang ci", app.PostOptions{}); err != nil {
+		t.Fatalf("post with tags failed: %v", err)
+	}
+	vals, _ = url.ParseQuery(body)
+	if vals.Get("status") != "about #Golang\n\n#ci" {
+		t.Fatalf("expected only missing tags appended, got %q", vals.Get("status"))
+	}
+
+	// The configured default visibility applies to new posts.
+	if _, err := NewPostService(client, "unlisted").Post(context.Background(), "quiet", "", app.PostOptions{}); err != nil {
+		t.Fatalf("unlisted post failed: %v", err)
+	}
+	vals, _ = url.ParseQuery(body)
+	if vals.Get("visibility") != "unlisted" {
+		t.Fatalf("expected unlisted visibility, got %q", vals.Get("visibility"))
+	}
+
+	// A redraft keeps the visibility, content warning and media of the original.
+	opts := app.PostOptions{Visibility: "private", SpoilerText: "spoilers", MediaIDs: []string{"m1", "m2"}}
+	if _, err := svc.Post(context.Background(), "again", "", opts); err != nil {
+		t.Fatalf("redraft post failed: %v", err)
+	}
+	vals, _ = url.ParseQuery(body)
+	if vals.Get("visibility") != "private" || vals.Get("spoiler_text") != "spoilers" || !reflect.DeepEqual(vals["media_ids[]"], []string{"m1", "m2"}) {
+		t.Fatalf("expected redraft options in form, got %v", vals)
+	}
 }
 
-func TestAccountService_LookupFollowing_EncodesIDs(t *testing.T) {
+func TestAccountService_LookupRelationships_EncodesIDs(t *testing.T) {
 	var gotQuery url.Values
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		gotQuery = r.URL.Query()
-		_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "a", "following": true}, {"id": "b", "following": false}})
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"id": "a", "following": true, "followed_by": true, "showing_reblogs": true, "note": " met at a conf "},
+			{"id": "b", "following": false, "requested": true, "muting": true, "domain_blocking": true},
+		})
 	})
 
 	client := newTestClient(h)
 	svc := NewAccountService(client)
-	res, err := svc.LookupFollowing(context.Background(), []string{"a", "b", "a"})
+	res, err := svc.LookupRelationships(context.Background(), []string{"a", "b", "a"})
 	if err != nil {
 		t.Fatalf("lookup failed: %v", err)
 	}
 	if len(gotQuery["id[]"]) != 2 {
 		t.Fatalf("expected unique id[] entries, got: %v", gotQuery["id[]"])
 	}
-	if !res["a"] || res["b"] {
-		t.Fatalf("unexpected follow map: %#v", res)
+	a, b := res["a"], res["b"]
+	if !a.Following || !a.FollowedBy || !a.ShowingReblogs || a.Note != "met at a conf" {
+		t.Fatalf("unexpected relationship for a: %#v", a)
+	}
+	if b.Following || !b.Requested || !b.Muting || !b.DomainBlocking {
+		t.Fatalf("unexpected relationship for b: %#v", b)
 	}
 }
 
@@ -261,6 +307,8 @@ func TestTimelineService_FetchHomePage_AndFetchThread(t *testing.T) {
 				t.Fatalf("expected max_id in home query")
 			}
 			_ = json.NewEncoder(w).Encode([]map[string]any{statusJSON("10", "acct-1", "name", "user1", "home")})
+		case "/api/v1/statuses/10":
+			_ = json.NewEncoder(w).Encode(statusJSON("10", "acct-1", "name", "user1", "root"))
 		case "/api/v1/statuses/10/context":
 			_ = json.NewEncoder(w).Encode(map[string]any{
 				"ancestors":   []map[string]any{statusJSON("9", "acct-2", "name2", "u2", "ancestor")},
@@ -281,6 +329,14 @@ func TestTimelineService_FetchHomePage_AndFetchThread(t *testing.T) {
 		t.Fatalf("unexpected home mapping: %#v", home)
 	}
 
+	root, err := svc.FetchStatus(context.Background(), "10")
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if root.ID != "10" || !root.IsOwn || !strings.Contains(root.Content, "root") {
+		t.Fatalf("unexpected status mapping: %#v", root)
+	}
+
 	anc, desc, err := svc.FetchThread(context.Background(), "10")
 	if err != nil {
 		t.Fatalf("thread failed: %v", err)
@@ -290,6 +346,74 @@ func TestTimelineService_FetchHomePage_AndFetchThread(t *testing.T) {
 	}
 }
 
+func TestTimelineService_FetchHistory_AndEditedAt(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/statuses/10":
+			st := statusJSON("10", "acct-1", "name", "user1", "second")
+			st["edited_at"] = "2026-01-02T04:05:06Z"
+			_ = json.NewEncoder(w).Encode(st)
+		case "/api/v1/statuses/10/history":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"content": "<p>first</p>", "created_at": "2026-01-02T03:04:05Z"},
+				{"content": "<p>second</p>", "created_at": "2026-01-02T04:05:06Z"},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	svc := NewTimelineService(newTestClient(h), "acct-1")
+
+	st, err := svc.FetchStatus(context.Background(), "10")
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if !st.IsEdited() || st.EditedAt.Hour() != 4 {
+		t.Fatalf("expected edited_at to be mapped, got %v", st.EditedAt)
+	}
+
+	revs, err := svc.FetchHistory(context.Background(), "10")
+	if err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	if len(revs) != 2 || revs[0].Content != "first" || revs[1].Content != "second" || revs[0].CreatedAt.IsZero() {
+		t.Fatalf("unexpected history mapping: %#v", revs)
+	}
+}
+
+func TestPostService_Source_AndVisibility(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/statuses/12/source":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "12", "text": "raw #text", "spoiler_text": "cw"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/statuses/12":
+			st := statusJSON("12", "acct-1", "n", "u", "raw")
+			st["visibility"] = "unlisted"
+			st["media_attachments"] = []any{map[string]any{"id": "m1", "type": "image"}, map[string]any{"id": "m2", "type": "image"}}
+			_ = json.NewEncoder(w).Encode(st)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewPostService(newTestClient(h), "")
+
+	src, err := svc.Source(context.Background(), "12")
+	if err != nil {
+		t.Fatalf("source failed: %v", err)
+	}
+	if src.ID != "12" || src.Text != "raw #text" || src.SpoilerText != "cw" || src.Visibility != "unlisted" || !reflect.DeepEqual(src.MediaIDs, []string{"m1", "m2"}) {
+		t.Fatalf("unexpected source mapping: %#v", src)
+	}
+
+	st := statusJSON("13", "acct-1", "n", "u", "private")
+	st["visibility"] = "private"
+	data, _ := json.Marshal(st)
+	rant, err := svc.parseStatus(data)
+	if err != nil || rant.Visibility != "private" {
+		t.Fatalf("expected visibility to be mapped, got %q (%v)", rant.Visibility, err)
+	}
+}
+
 func TestPostService_EditDeleteLikeUnlikeReply_RequestShape(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
@@ -321,7 +445,7 @@ func TestPostService_EditDeleteLikeUnlikeReply_RequestShape(t *testing.T) {
 		}
 	})
 	client := newTestClient(h)
-	svc := NewPostService(client)
+	svc := NewPostService(client, "")
 
 	if _, err := svc.Edit(context.Background(), "12", "edited", "terminalrant"); err != nil {
 		t.Fatalf("edit failed: %v", err)
@@ -335,7 +459,7 @@ func TestPostService_EditDeleteLikeUnlikeReply_RequestShape(t *testing.T) {
 	if err := svc.Unlike(context.Background(), "12"); err != nil {
 		t.Fatalf("unlike failed: %v", err)
 	}
-	if _, err := svc.Reply(context.Background(), "12", "hello", "terminalrant"); err != nil {
+	if _, err := svc.Reply(context.Background(), "12", "hello", "terminalrant", app.PostOptions{}); err != nil {
 		t.Fatalf("reply failed: %v", err)
 	}
 }
@@ -362,7 +486,7 @@ func TestAccountService_Endpoints_RequestShapeAndMapping(t *testing.T) {
 			}
 			_, _ = w.Write([]byte(`{}`))
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/accounts/42/follow":
-			_, _ = w.Write([]byte(`{}`))
+			_, _ = w.Write([]byte(`{"id":"42","following":false,"requested":true}`))
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/accounts/42/unfollow":
 			_, _ = w.Write([]byte(`{}`))
 		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/accounts/42":
@@ -405,11 +529,15 @@ func TestAccountService_Endpoints_RequestShapeAndMapping(t *testing.T) {
 	if err := svc.UpdateProfile(context.Background(), "New Name", "New Bio"); err != nil {
 		t.Fatalf("update profile failed: %v", err)
 	}
-	if err := svc.FollowUser(context.Background(), "42"); err != nil {
+	rel, err := svc.FollowUser(context.Background(), "42")
+	if err != nil {
 		t.Fatalf("follow failed: %v", err)
 	}
-	if err := svc.UnfollowUser(context.Background(), "42"); err != nil {
-		t.Fatalf("unfollow failed: %v", err)
+	if !rel.Requested || rel.Following {
+		t.Fatalf("expected pending follow request, got %#v", rel)
+	}
+	if rel, err = svc.UnfollowUser(context.Background(), "42"); err != nil || rel.ID != "42" || rel.Requested {
+		t.Fatalf("unfollow failed: %#v %v", rel, err)
 	}
 	profile, err := svc.ProfileByID(context.Background(), "42")
 	if err != nil {
@@ -440,13 +568,489 @@ func TestAccountService_Endpoints_RequestShapeAndMapping(t *testing.T) {
 	}
 }
 
+func TestTimelineService_FetchBookmarksPage_FollowsLinkHeader(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/bookmarks" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("max_id") == "" {
+			w.Header().Set("Link", `<https://example/api/v1/bookmarks?limit=2&max_id=7001>; rel="next", <https://example/api/v1/bookmarks?limit=2&min_id=9000>; rel="prev"`)
+			_ = json.NewEncoder(w).Encode([]map[string]any{statusJSON("20", "a", "n", "u", "one"), statusJSON("19", "a", "n", "u", "two")})
+			return
+		}
+		if r.URL.Query().Get("max_id") != "7001" {
+			t.Fatalf("expected cursor from link header, got %q", r.URL.Query().Get("max_id"))
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]any{statusJSON("5", "a", "n", "u", "three")})
+	})
+	svc := NewTimelineService(newTestClient(h), "")
+
+	first, next, err := svc.FetchBookmarksPage(context.Background(), 2, "")
+	if err != nil {
+		t.Fatalf("first page failed: %v", err)
+	}
+	if len(first) != 2 || next != "7001" {
+		t.Fatalf("unexpected first page: len=%d next=%q", len(first), next)
+	}
+	second, next, err := svc.FetchBookmarksPage(context.Background(), 2, next)
+	if err != nil {
+		t.Fatalf("second page failed: %v", err)
+	}
+	if len(second) != 1 || next != "" {
+		t.Fatalf("unexpected second page: len=%d next=%q", len(second), next)
+	}
+}
+
+func TestTimelineService_FavouritedAndRebloggedBy_Paging(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := map[string]any{"id": "7", "acct": "alice@remote.example", "display_name": "Alice"}
+		switch r.URL.Path {
+		case "/api/v1/statuses/10/favourited_by":
+			if r.URL.Query().Get("max_id") == "" {
+				w.Header().Set("Link", `<https://example/api/v1/statuses/10/favourited_by?max_id=55>; rel="next"`)
+			} else if r.URL.Query().Get("max_id") != "55" {
+				t.Fatalf("expected cursor from link header, got %q", r.URL.Query().Get("max_id"))
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]any{account})
+		case "/api/v1/statuses/10/reblogged_by":
+			_ = json.NewEncoder(w).Encode([]map[string]any{account})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	svc := NewTimelineService(newTestClient(h), "")
+
+	liked, next, err := svc.FavouritedBy(context.Background(), "10", 20, "")
+	if err != nil {
+		t.Fatalf("favourited_by failed: %v", err)
+	}
+	if len(liked) != 1 || next != "55" || liked[0].ID != "7" || liked[0].Username != "alice@remote.example" || liked[0].DisplayName != "Alice" {
+		t.Fatalf("unexpected favourited_by page: %#v next=%q", liked, next)
+	}
+	if _, next, err = svc.FavouritedBy(context.Background(), "10", 20, next); err != nil || next != "" {
+		t.Fatalf("unexpected second page: next=%q err=%v", next, err)
+	}
+
+	boosted, next, err := svc.RebloggedBy(context.Background(), "10", 20, "")
+	if err != nil || len(boosted) != 1 || next != "" {
+		t.Fatalf("unexpected reblogged_by page: %#v next=%q err=%v", boosted, next, err)
+	}
+}
+
+func TestAccountService_FollowersAndFollowing_Paging(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/accounts/42/followers":
+			if r.URL.Query().Get("limit") != "40" {
+				t.Fatalf("expected limit=40, got %q", r.URL.Query().Get("limit"))
+			}
+			if r.URL.Query().Get("max_id") == "" {
+				w.Header().Set("Link", `<https://example/api/v1/accounts/42/followers?max_id=900>; rel="next"`)
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "7", "acct": "alice", "display_name": "Alice"}})
+		case "/api/v1/accounts/42/following":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "8", "acct": "bob@remote.example"}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	svc := NewAccountService(newTestClient(h))
+
+	followers, next, err := svc.Followers(context.Background(), "42", 40, "")
+	if err != nil || len(followers) != 1 || followers[0].Username != "alice" || next != "900" {
+		t.Fatalf("unexpected followers page: %#v next=%q err=%v", followers, next, err)
+	}
+	following, next, err := svc.Following(context.Background(), "42", 40, "")
+	if err != nil || len(following) != 1 || following[0].ID != "8" || next != "" {
+		t.Fatalf("unexpected following page: %#v next=%q err=%v", following, next, err)
+	}
+}
+
+func TestAccountService_FollowRequests(t *testing.T) {
+	var resolved []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/accounts/verify_credentials":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":     "1",
+				"acct":   "me",
+				"locked": true,
+				"source": map[string]any{"follow_requests_count": 2},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/follow_requests":
+			w.Header().Set("Link", `<https://example/api/v1/follow_requests?max_id=70>; rel="next"`)
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "7", "acct": "alice"}, {"id": "8", "acct": "bob"}})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/v1/follow_requests/"):
+			resolved = append(resolved, r.URL.Path)
+			_, _ = w.Write([]byte(`{"id":"7","followed_by":true}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewAccountService(newTestClient(h))
+
+	profile, err := svc.CurrentProfile(context.Background())
+	if err != nil || !profile.Locked || profile.FollowRequests != 2 {
+		t.Fatalf("unexpected own profile: %#v err=%v", profile, err)
+	}
+	requests, next, err := svc.FollowRequests(context.Background(), 40, "")
+	if err != nil || len(requests) != 2 || requests[1].Username != "bob" || next != "70" {
+		t.Fatalf("unexpected follow requests: %#v next=%q err=%v", requests, next, err)
+	}
+	if err := svc.AuthorizeFollowRequest(context.Background(), "7"); err != nil {
+		t.Fatalf("authorize failed: %v", err)
+	}
+	if err := svc.RejectFollowRequest(context.Background(), "8"); err != nil {
+		t.Fatalf("reject failed: %v", err)
+	}
+	want := []string{"/api/v1/follow_requests/7/authorize", "/api/v1/follow_requests/8/reject"}
+	if strings.Join(resolved, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected resolve calls: %v", resolved)
+	}
+}
+
+func TestAccountService_DomainBlocks(t *testing.T) {
+	var calls []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domain_blocks" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`["noise.example"," ","spam.example"]`))
+			return
+		case http.MethodPost:
+			raw, _ := io.ReadAll(r.Body)
+			vals, _ := url.ParseQuery(string(raw))
+			calls = append(calls, "block "+vals.Get("domain"))
+		case http.MethodDelete:
+			calls = append(calls, "unblock "+r.URL.Query().Get("domain"))
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	svc := NewAccountService(newTestClient(h))
+
+	domains, err := svc.ListDomainBlocks(context.Background(), 100)
+	if err != nil || strings.Join(domains, ",") != "noise.example,spam.example" {
+		t.Fatalf("unexpected domain blocks: %v err=%v", domains, err)
+	}
+	if err := svc.BlockDomain(context.Background(), " noise.example "); err != nil {
+		t.Fatalf("block domain failed: %v", err)
+	}
+	if err := svc.UnblockDomain(context.Background(), "noise.example"); err != nil {
+		t.Fatalf("unblock domain failed: %v", err)
+	}
+	if err := svc.BlockDomain(context.Background(), " "); err == nil {
+		t.Fatalf("expected empty domain to be rejected")
+	}
+	if strings.Join(calls, ",") != "block noise.example,unblock noise.example" {
+		t.Fatalf("unexpected domain block calls: %v", calls)
+	}
+}
+
+func TestAccountService_FollowedTags(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/followed_tags":
+			if r.URL.Query().Get("max_id") == "" {
+				w.Header().Set("Link", `<https://example/api/v1/followed_tags?max_id=12>; rel="next"`)
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"name": "golang", "following": true}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tags/tui":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "tui", "following": false})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tags/tui/follow":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "tui", "following": true})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tags/golang/unfollow":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "golang", "following": false})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewAccountService(newTestClient(h))
+
+	tags, next, err := svc.FollowedTags(context.Background(), 40, "")
+	if err != nil || len(tags) != 1 || tags[0].Name != "golang" || !tags[0].Following || next != "12" {
+		t.Fatalf("unexpected followed tags: %#v next=%q err=%v", tags, next, err)
+	}
+	if tag, err := svc.LookupTag(context.Background(), "#tui"); err != nil || tag.Following {
+		t.Fatalf("unexpected tag lookup: %#v err=%v", tag, err)
+	}
+	if tag, err := svc.FollowTag(context.Background(), "tui"); err != nil || !tag.Following {
+		t.Fatalf("unexpected follow result: %#v err=%v", tag, err)
+	}
+	if tag, err := svc.UnfollowTag(context.Background(), "golang"); err != nil || tag.Following {
+		t.Fatalf("unexpected unfollow result: %#v err=%v", tag, err)
+	}
+	if _, err := svc.FollowTag(context.Background(), " # "); err == nil {
+		t.Fatalf("expected error for empty tag")
+	}
+}
+
+func TestTimelineService_TrendingTagsAndLinks(t *testing.T) {
+	history := []map[string]any{
+		{"day": "1700172800", "uses": "5", "accounts": "4"},
+		{"day": "1700086400", "uses": "2", "accounts": "1"},
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/trends/tags":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"name": "golang", "history": history}})
+		case "/api/v1/trends/links":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{
+				"url":           "https://example.com/a",
+				"title":         " A story ",
+				"description":   "About things",
+				"provider_name": "Example",
+				"history":       history,
+			}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	svc := NewTimelineService(newTestClient(h), "")
+
+	tags, err := svc.TrendingTags(context.Background(), 20)
+	if err != nil || len(tags) != 1 || tags[0].Name != "golang" || tags[0].Accounts != 5 {
+		t.Fatalf("unexpected trending tags: %#v err=%v", tags, err)
+	}
+	if len(tags[0].Uses) != 2 || tags[0].Uses[0] != 2 || tags[0].Uses[1] != 5 {
+		t.Fatalf("expected uses oldest first, got %v", tags[0].Uses)
+	}
+	links, err := svc.TrendingLinks(context.Background(), 20)
+	if err != nil || len(links) != 1 || links[0].Title != "A story" || links[0].Provider != "Example" || links[0].URL != "https://example.com/a" {
+		t.Fatalf("unexpected trending links: %#v err=%v", links, err)
+	}
+}
+
+func TestReportService_RulesAndReport(t *testing.T) {
+	var form url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/instance/rules":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "3", "text": "Be kind", "hint": "No harassment"}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/reports":
+			raw, _ := io.ReadAll(r.Body)
+			form, _ = url.ParseQuery(string(raw))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "1"})
+		default:
+			t.Fatalf("unexpected req: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewReportService(newTestClient(h))
+
+	rules, err := svc.Rules(context.Background())
+	if err != nil || len(rules) != 1 || rules[0].ID != "3" || rules[0].Text != "Be kind" || rules[0].Hint != "No harassment" {
+		t.Fatalf("unexpected rules: %#v err=%v", rules, err)
+	}
+
+	err = svc.Report(context.Background(), app.Report{
+		AccountID: "a1",
+		StatusIDs: []string{"s1", "s2"},
+		Category:  app.ReportViolation,
+		RuleIDs:   []string{"3"},
+		Comment:   " spam bot ",
+		Forward:   true,
+	})
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	if form.Get("account_id") != "a1" || form.Get("category") != "violation" || form.Get("comment") != "spam bot" || form.Get("forward") != "true" {
+		t.Fatalf("unexpected report form: %v", form)
+	}
+	if got := form["status_ids[]"]; len(got) != 2 || got[0] != "s1" || got[1] != "s2" {
+		t.Fatalf("unexpected status ids: %v", got)
+	}
+	if got := form["rule_ids[]"]; len(got) != 1 || got[0] != "3" {
+		t.Fatalf("unexpected rule ids: %v", got)
+	}
+
+	if err := svc.Report(context.Background(), app.Report{AccountID: "a1", Category: app.ReportSpam, RuleIDs: []string{"3"}}); err != nil {
+		t.Fatalf("spam report failed: %v", err)
+	}
+	if _, ok := form["rule_ids[]"]; ok || form.Get("forward") != "false" {
+		t.Fatalf("expected rules only on violation reports, got %v", form)
+	}
+}
+
+func TestScheduleService_RequestShapeAndMapping(t *testing.T) {
+	at := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	scheduled := func(id, when, text string, replyTo any) map[string]any {
+		return map[string]any{
+			"id":           id,
+			"scheduled_at": when,
+			"params":       map[string]any{"text": text, "in_reply_to_id": replyTo, "media_ids": []string{}},
+		}
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/statuses":
+			body, _ := io.ReadAll(r.Body)
+			vals, _ := url.ParseQuery(string(body))
+			if vals.Get("scheduled_at") != "2030-01-02T09:00:00Z" || vals.Get("in_reply_to_id") != "77" {
+				t.Fatalf("unexpected schedule form: %v", vals)
+			}
+			_ = json.NewEncoder(w).Encode(scheduled("s1", "2030-01-02T09:00:00.000Z", vals.Get("status"), 77))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/scheduled_statuses":
+			if r.URL.Query().Get("max_id") == "s1" {
+				_ = json.NewEncoder(w).Encode([]map[string]any{scheduled("s0", "2030-01-01T09:00:00.000Z", "older", nil)})
+				return
+			}
+			w.Header().Set("Link", `<https://example/api/v1/scheduled_statuses?limit=20&max_id=s1>; rel="next"`)
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				scheduled("s2", "2030-01-03T09:00:00.000Z", "later", nil),
+				scheduled("s1", "2030-01-02T09:00:00.000Z", "sooner", "77"),
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/scheduled_statuses/s1":
+			body, _ := io.ReadAll(r.Body)
+			vals, _ := url.ParseQuery(string(body))
+			_ = json.NewEncoder(w).Encode(scheduled("s1", vals.Get("scheduled_at"), "sooner", nil))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/scheduled_statuses/s1":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewScheduleService(newTestClient(h), "")
+
+	post, err := svc.Schedule(context.Background(), "later rant", "terminalrant", "77", at, app.PostOptions{})
+	if err != nil {
+		t.Fatalf("schedule failed: %v", err)
+	}
+	if post.ID != "s1" || !post.ScheduledAt.Equal(at) || post.InReplyToID != "77" {
+		t.Fatalf("unexpected scheduled mapping: %#v", post)
+	}
+	if !strings.Contains(post.Content, domain.AppHashTag) {
+		t.Fatalf("scheduled post should carry the required hashtag: %q", post.Content)
+	}
+
+	list, next, err := svc.ListScheduled(context.Background(), 20, "")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "s1" || list[1].InReplyToID != "" {
+		t.Fatalf("expected soonest first with null reply ids mapped empty: %#v", list)
+	}
+	if next != "s1" {
+		t.Fatalf("expected next page cursor from the Link header, got %q", next)
+	}
+	list, next, err = svc.ListScheduled(context.Background(), 20, next)
+	if err != nil || len(list) != 1 || list[0].ID != "s0" || next != "" {
+		t.Fatalf("expected last page, got %#v next=%q err=%v", list, next, err)
+	}
+
+	moved, err := svc.Reschedule(context.Background(), "s1", at.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("reschedule failed: %v", err)
+	}
+	if !moved.ScheduledAt.Equal(at.Add(time.Hour)) {
+		t.Fatalf("unexpected rescheduled time: %v", moved.ScheduledAt)
+	}
+	if err := svc.CancelScheduled(context.Background(), "s1"); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+}
+
+func TestInstanceService_FetchesV2AndCaches(t *testing.T) {
+	calls := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v2/instance" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"configuration": map[string]any{
+				"statuses": map[string]any{"max_characters": 5000, "characters_reserved_per_url": 23},
+				"polls":    map[string]any{"max_options": 8, "max_expiration": 86400},
+			},
+		})
+	})
+	cachePath := filepath.Join(t.TempDir(), "instance.json")
+	svc := NewInstanceService(newTestClient(h), cachePath)
+
+	cfg, err := svc.InstanceConfig(context.Background())
+	if err != nil {
+		t.Fatalf("instance config failed: %v", err)
+	}
+	if cfg.MaxCharacters != 5000 || cfg.MaxPollOptions != 8 || cfg.MaxPollExpiration != 24*time.Hour {
+		t.Fatalf("unexpected instance mapping: %#v", cfg)
+	}
+	if cfg.MaxMediaAttachments != 4 {
+		t.Fatalf("missing limits should keep defaults: %#v", cfg)
+	}
+
+	// A new service for the same instance reads the disk cache.
+	again, err := NewInstanceService(newTestClient(h), cachePath).InstanceConfig(context.Background())
+	if err != nil || again.MaxCharacters != 5000 {
+		t.Fatalf("expected cached config, got %#v, %v", again, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one fetch, got %d", calls)
+	}
+}
+
+func TestInstanceService_FallsBackToV1ThenDefaults(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/instance":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v1/instance":
+			_, _ = w.Write([]byte(`{"configuration":{"statuses":{"max_characters":1000}}}`))
+		}
+	})
+	cfg, err := NewInstanceService(newTestClient(h), "").InstanceConfig(context.Background())
+	if err != nil || cfg.MaxCharacters != 1000 {
+		t.Fatalf("expected v1 fallback, got %#v, %v", cfg, err)
+	}
+
+	down := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	cfg, err = NewInstanceService(newTestClient(down), "").InstanceConfig(context.Background())
+	if err == nil {
+		t.Fatal("expected an error when the instance is unreachable")
+	}
+	if cfg != app.DefaultInstanceConfig() {
+		t.Fatalf("expected default limits on failure, got %#v", cfg)
+	}
+}
+
+func TestSearchService_AccountsAndHashtags(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/accounts/search":
+			if q.Get("q") != "ali" || q.Get("limit") != "5" || q.Get("resolve") != "false" {
+				t.Fatalf("unexpected account search query: %v", q)
+			}
+			_, _ = w.Write([]byte(`[{"acct":"alice@example.social","display_name":"Alice"}]`))
+		case "/api/v2/search":
+			if q.Get("q") != "go" || q.Get("type") != "hashtags" {
+				t.Fatalf("unexpected hashtag search query: %v", q)
+			}
+			_, _ = w.Write([]byte(`{"hashtags":[{"name":"golang","history":[{"uses":"12"}]},{"name":"gotchas","history":[]}]}`))
+		default:
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+	})
+	svc := NewSearchService(newTestClient(h))
+
+	accounts, err := svc.SearchAccounts(context.Background(), "@ali", 5)
+	if err != nil || len(accounts) != 1 || accounts[0].Value != "@alice@example.social" || accounts[0].Label != "Alice" {
+		t.Fatalf("unexpected accounts: %#v, %v", accounts, err)
+	}
+	tags, err := svc.SearchHashtags(context.Background(), "#go", 5)
+	if err != nil || len(tags) != 2 || tags[0].Value != "#golang" || tags[0].Label != "12 posts today" || tags[1].Label != "" {
+		t.Fatalf("unexpected hashtags: %#v, %v", tags, err)
+	}
+}
+
 func TestAPIErrorPropagation_ContainsPathAndStatus(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		_, _ = w.Write([]byte(`{"error":"bad"}`))
 	})
 	client := newTestClient(h)
-	postSvc := NewPostService(client)
+	postSvc := NewPostService(client, "")
 	_, err := postSvc.Edit(context.Background(), "12", "x", "terminalrant")
 	if err == nil {
 		t.Fatalf("expected error")
@@ -474,3 +1078,178 @@ func statusJSON(id, accountID, display, acct, content string) map[string]any {
 		},
 	}
 }
+
+func TestRemoteTimelineService_AnonymousLocalTimeline(t *testing.T) {
+	var gotAuth, gotQuery string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/timelines/public" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotQuery = r.URL.RawQuery
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"id": "1", "content": "<p>hi</p>", "created_at": "2026-01-01T00:00:00Z", "account": map[string]any{"id": "a1", "acct": "alice"}},
+			{"id": "2", "content": "<p>yo</p>", "created_at": "2026-01-01T00:00:00Z", "account": map[string]any{"id": "a2", "acct": "bob@elsewhere.example"}},
+		})
+	})
+	svc := NewRemoteTimelineService("https://remote.example/")
+	svc.client.http = &http.Client{Transport: handlerRoundTripper{h: h}}
+
+	rants, err := svc.FetchLocalPage(context.Background(), 20, "9")
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+	if gotAuth != "" {
+		t.Fatalf("expected no Authorization header, got %q", gotAuth)
+	}
+	q, _ := url.ParseQuery(gotQuery)
+	if q.Get("local") != "true" || q.Get("limit") != "20" || q.Get("max_id") != "9" {
+		t.Fatalf("unexpected query: %s", gotQuery)
+	}
+	if len(rants) != 2 || rants[0].Username != "alice@remote.example" || rants[1].Username != "bob@elsewhere.example" {
+		t.Fatalf("expected usernames qualified with the remote domain, got %#v", rants)
+	}
+}
+
+func TestTimelineService_ResolveStatus(t *testing.T) {
+	var gotQuery url.Values
+	found := true
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/search" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		gotQuery = r.URL.Query()
+		statuses := []map[string]any{}
+		if found {
+			statuses = append(statuses, map[string]any{"id": "local-7", "content": "<p>hi</p>", "created_at": "2026-01-01T00:00:00Z", "account": map[string]any{"id": "a9", "acct": "alice@remote.example"}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"statuses": statuses})
+	})
+	svc := NewTimelineService(newTestClient(h), "")
+
+	r, err := svc.ResolveStatus(context.Background(), "https://remote.example/@alice/1")
+	if err != nil || r.ID != "local-7" || r.AccountID != "a9" {
+		t.Fatalf("unexpected resolved status: %#v err=%v", r, err)
+	}
+	if gotQuery.Get("q") != "https://remote.example/@alice/1" || gotQuery.Get("resolve") != "true" || gotQuery.Get("type") != "statuses" {
+		t.Fatalf("unexpected query: %v", gotQuery)
+	}
+
+	found = false
+	if _, err := svc.ResolveStatus(context.Background(), "https://remote.example/@alice/2"); !errors.Is(err, app.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAccountService_ResolveAccount(t *testing.T) {
+	var gotQuery url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/search" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		gotQuery = r.URL.Query()
+		accounts := []map[string]any{}
+		if gotQuery.Get("q") == "https://remote.example/@alice" {
+			accounts = append(accounts, map[string]any{"id": "a1", "acct": "alice@remote.example", "display_name": "Alice"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"accounts": accounts})
+	})
+	svc := NewAccountService(newTestClient(h))
+
+	acct, err := svc.ResolveAccount(context.Background(), " https://remote.example/@alice ")
+	if err != nil || acct.ID != "a1" || acct.Username != "alice@remote.example" || acct.DisplayName != "Alice" {
+		t.Fatalf("unexpected resolved account: %#v err=%v", acct, err)
+	}
+	if gotQuery.Get("type") != "accounts" || gotQuery.Get("resolve") != "true" {
+		t.Fatalf("unexpected query: %v", gotQuery)
+	}
+	if _, err := svc.ResolveAccount(context.Background(), "https://remote.example/about"); !errors.Is(err, app.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMarkerService_MarkersAndSave(t *testing.T) {
+	var gotTimelines []string
+	var form url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/markers":
+			gotTimelines = r.URL.Query()["timeline[]"]
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"home":          map[string]any{"last_read_id": "105", "version": 3, "updated_at": "2026-01-02T03:04:05Z"},
+				"notifications": map[string]any{"last_read_id": "", "version": 0},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/markers":
+			raw, _ := io.ReadAll(r.Body)
+			form, _ = url.ParseQuery(string(raw))
+			_ = json.NewEncoder(w).Encode(map[string]any{"home": map[string]any{"last_read_id": "110", "version": 4}})
+		default:
+			t.Fatalf("unexpected req: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	svc := NewMarkerService(newTestClient(h))
+
+	markers, err := svc.Markers(context.Background(), app.MarkerHome, app.MarkerNotifications)
+	if err != nil {
+		t.Fatalf("markers failed: %v", err)
+	}
+	if len(gotTimelines) != 2 || gotTimelines[0] != "home" || gotTimelines[1] != "notifications" {
+		t.Fatalf("unexpected timelines requested: %v", gotTimelines)
+	}
+	home, ok := markers[app.MarkerHome]
+	if !ok || home.LastReadID != "105" || home.Version != 3 || home.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected home marker: %#v", home)
+	}
+	if _, ok := markers[app.MarkerNotifications]; ok {
+		t.Fatalf("expected an unset marker to be left out")
+	}
+
+	saved, err := svc.SaveMarker(context.Background(), app.MarkerHome, "110")
+	if err != nil || saved.LastReadID != "110" || saved.Version != 4 {
+		t.Fatalf("unexpected saved marker: %#v err=%v", saved, err)
+	}
+	if form.Get("home[last_read_id]") != "110" {
+		t.Fatalf("unexpected marker form: %v", form)
+	}
+}
+
+func TestNotificationService_PagesNotifications(t *testing.T) {
+	var gotQuery url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/notifications" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		gotQuery = r.URL.Query()
+		w.Header().Set("Link", `<https://example/api/v1/notifications?max_id=30>; rel="next"`)
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{
+				"id": "32", "type": "mention", "created_at": "2026-01-02T03:04:05Z",
+				"account": map[string]any{"id": "7", "acct": "alice", "display_name": "Alice"},
+				"status":  map[string]any{"id": "900", "content": "<p>@me hi</p>", "account": map[string]any{"id": "7", "acct": "alice"}},
+			},
+			{
+				"id": "31", "type": "follow",
+				"account": map[string]any{"id": "8", "acct": "bob"},
+			},
+		})
+	})
+	svc := NewNotificationService(newTestClient(h), "me-id")
+
+	got, next, err := svc.Notifications(context.Background(), 20, "40")
+	if err != nil || len(got) != 2 || next != "30" {
+		t.Fatalf("unexpected notifications: %#v next=%q err=%v", got, next, err)
+	}
+	if gotQuery.Get("limit") != "20" || gotQuery.Get("max_id") != "40" {
+		t.Fatalf("unexpected query: %v", gotQuery)
+	}
+	mention := got[0]
+	if mention.Type != "mention" || mention.Account.Username != "alice" || mention.CreatedAt.IsZero() {
+		t.Fatalf("unexpected mention: %#v", mention)
+	}
+	if mention.Rant == nil || mention.Rant.ID != "900" || strings.TrimSpace(mention.Rant.Content) != "@me hi" {
+		t.Fatalf("expected the mentioning post, got %#v", mention.Rant)
+	}
+	if got[1].Type != "follow" || got[1].Rant != nil || got[1].Account.ID != "8" {
+		t.Fatalf("unexpected follow: %#v", got[1])
+	}
+}
@@ -0,0 +1,78 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// markerService implements app.MarkerService using the Mastodon API.
+type markerService struct {
+	client *Client
+}
+
+// NewMarkerService creates a MarkerService backed by Mastodon.
+func NewMarkerService(client *Client) *markerService {
+	return &markerService{client: client}
+}
+
+// mastodonMarker is the JSON shape of a Mastodon Marker entity.
+type mastodonMarker struct {
+	LastReadID string `json:"last_read_id"`
+	Version    int    `json:"version"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func (m mastodonMarker) toApp() app.Marker {
+	updated, _ := time.Parse(time.RFC3339, m.UpdatedAt)
+	return app.Marker{
+		LastReadID: sanitizeForTerminal(m.LastReadID),
+		Version:    m.Version,
+		UpdatedAt:  updated,
+	}
+}
+
+func (s *markerService) Markers(_ context.Context, timelines ...app.MarkerTimeline) (map[app.MarkerTimeline]app.Marker, error) {
+	params := url.Values{}
+	for _, t := range timelines {
+		params.Add("timeline[]", string(t))
+	}
+	data, err := s.client.Get("/api/v1/markers?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("fetching markers: %w", err)
+	}
+	var raw map[string]mastodonMarker
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing markers: %w", err)
+	}
+	out := make(map[app.MarkerTimeline]app.Marker, len(raw))
+	for name, m := range raw {
+		if m.LastReadID != "" {
+			out[app.MarkerTimeline(name)] = m.toApp()
+		}
+	}
+	return out, nil
+}
+
+func (s *markerService) SaveMarker(_ context.Context, timeline app.MarkerTimeline, lastReadID string) (app.Marker, error) {
+	lastReadID = strings.TrimSpace(lastReadID)
+	if timeline == "" || lastReadID == "" {
+		return app.Marker{}, fmt.Errorf("saving marker: missing timeline or id")
+	}
+	form := url.Values{}
+	form.Set(string(timeline)+"[last_read_id]", lastReadID)
+	data, err := s.client.Post("/api/v1/markers", strings.NewReader(form.Encode()))
+	if err != nil {
+		return app.Marker{}, fmt.Errorf("saving marker: %w", err)
+	}
+	var raw map[string]mastodonMarker
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return app.Marker{}, fmt.Errorf("parsing saved marker: %w", err)
+	}
+	return raw[string(timeline)].toApp(), nil
+}
@@ -0,0 +1,78 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// notificationService implements app.NotificationService using the
+// Mastodon API.
+type notificationService struct {
+	client *Client
+	// statuses maps the posts notifications refer to, marking the user's own.
+	statuses *timelineService
+}
+
+// NewNotificationService creates a NotificationService backed by Mastodon.
+// Pass currentAccountID to mark the user's own posts.
+func NewNotificationService(client *Client, currentAccountID string) *notificationService {
+	return &notificationService{
+		client:   client,
+		statuses: NewTimelineService(client, currentAccountID),
+	}
+}
+
+// mastodonNotification is the subset of Mastodon's Notification entity we
+// care about.
+type mastodonNotification struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at"`
+	Account   mastodonAccount `json:"account"`
+	Status    *mastodonStatus `json:"status"`
+}
+
+func (s *notificationService) Notifications(_ context.Context, limit int, maxID string) ([]app.Notification, string, error) {
+	if limit <= 0 {
+		limit = 40
+	}
+	path := fmt.Sprintf("/api/v1/notifications?limit=%d", limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	data, next, err := s.client.GetPage(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching notifications: %w", err)
+	}
+
+	var raw []mastodonNotification
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, "", fmt.Errorf("parsing notifications: %w", err)
+	}
+
+	out := make([]app.Notification, 0, len(raw))
+	for _, n := range raw {
+		createdAt, _ := time.Parse(time.RFC3339, n.CreatedAt)
+		item := app.Notification{
+			ID:   sanitizeForTerminal(n.ID),
+			Type: sanitizeForTerminal(n.Type),
+			Account: app.AccountSummary{
+				ID:          sanitizeForTerminal(n.Account.ID),
+				Username:    sanitizeForTerminal(n.Account.Acct),
+				DisplayName: sanitizeForTerminal(n.Account.DisplayName),
+			},
+			CreatedAt: createdAt,
+		}
+		if n.Status != nil {
+			rant := s.statuses.mapStatuses([]mastodonStatus{*n.Status})[0]
+			item.Rant = &rant
+		}
+		out = append(out, item)
+	}
+	return out, next, nil
+}
@@ -8,32 +8,36 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
 
 // postService implements app.PostService using the Mastodon API.
 type postService struct {
-	client *Client
+	client     *Client
+	visibility string
 }
 
-const requiredHashtag = domain.AppHashTag
-
-// NewPostService creates a PostService backed by Mastodon.
-func NewPostService(client *Client) *postService {
-	return &postService{client: client}
+// NewPostService creates a PostService backed by Mastodon. New posts get
+// visibility: public, unlisted, private or direct; empty means public.
+func NewPostService(client *Client, visibility string) *postService {
+	if visibility == "" {
+		visibility = "public"
+	}
+	return &postService{client: client, visibility: visibility}
 }
 
-func (s *postService) Post(_ context.Context, content string, _ string) (domain.Rant, error) {
+func (s *postService) Post(_ context.Context, content string, hashtag string, opts app.PostOptions) (domain.Rant, error) {
 	content = strings.TrimSpace(content)
 	if content == "" {
 		return domain.Rant{}, domain.ErrEmptyRant
 	}
 
-	content = ensureRequiredHashtag(content)
+	content = ensureHashtags(content, hashtag)
 
 	form := url.Values{}
 	form.Set("status", content)
-	form.Set("visibility", "public")
+	setPostOptions(form, s.visibility, opts)
 
 	data, err := s.client.Post("/api/v1/statuses", strings.NewReader(form.Encode()))
 	if err != nil {
@@ -43,13 +47,13 @@ func (s *postService) Post(_ context.Context, content string, _ string) (domain.
 	return s.parseStatus(data)
 }
 
-func (s *postService) Edit(_ context.Context, id string, content string, _ string) (domain.Rant, error) {
+func (s *postService) Edit(_ context.Context, id string, content string, hashtag string) (domain.Rant, error) {
 	content = strings.TrimSpace(content)
 	if content == "" {
 		return domain.Rant{}, domain.ErrEmptyRant
 	}
 
-	content = ensureRequiredHashtag(content)
+	content = ensureHashtags(content, hashtag)
 
 	form := url.Values{}
 	form.Set("status", content)
@@ -72,6 +76,42 @@ func (s *postService) Delete(_ context.Context, id string) error {
 	return nil
 }
 
+// mastodonStatusSource is Mastodon's StatusSource entity.
+type mastodonStatusSource struct {
+	ID          string `json:"id"`
+	Text        string `json:"text"`
+	SpoilerText string `json:"spoiler_text"`
+}
+
+func (s *postService) Source(_ context.Context, id string) (app.StatusSource, error) {
+	path := fmt.Sprintf("/api/v1/statuses/%s/source", url.PathEscape(id))
+	data, err := s.client.Get(path)
+	if err != nil {
+		return app.StatusSource{}, fmt.Errorf("fetching rant source: %w", err)
+	}
+
+	var src mastodonStatusSource
+	if err := json.Unmarshal(data, &src); err != nil {
+		return app.StatusSource{}, fmt.Errorf("parsing rant source: %w", err)
+	}
+
+	// The source entity has no visibility or media; they come from the
+	// status itself.
+	data, err = s.client.Get(fmt.Sprintf("/api/v1/statuses/%s", url.PathEscape(id)))
+	if err != nil {
+		return app.StatusSource{}, fmt.Errorf("fetching rant: %w", err)
+	}
+	var st mastodonStatus
+	if err := json.Unmarshal(data, &st); err != nil {
+		return app.StatusSource{}, fmt.Errorf("parsing rant: %w", err)
+	}
+	out := app.StatusSource{ID: src.ID, Text: src.Text, SpoilerText: src.SpoilerText, Visibility: st.Visibility}
+	for _, m := range st.MediaAttachments {
+		out.MediaIDs = append(out.MediaIDs, m.ID)
+	}
+	return out, nil
+}
+
 func (s *postService) Like(_ context.Context, id string) error {
 	path := fmt.Sprintf("/api/v1/statuses/%s/favourite", id)
 	_, err := s.client.Post(path, nil)
@@ -90,18 +130,18 @@ func (s *postService) Unlike(_ context.Context, id string) error {
 	return nil
 }
 
-func (s *postService) Reply(_ context.Context, parentID string, content string, _ string) (domain.Rant, error) {
+func (s *postService) Reply(_ context.Context, parentID string, content string, hashtag string, opts app.PostOptions) (domain.Rant, error) {
 	content = strings.TrimSpace(content)
 	if content == "" {
 		return domain.Rant{}, domain.ErrEmptyRant
 	}
 
-	content = ensureRequiredHashtag(content)
+	content = ensureHashtags(content, hashtag)
 
 	form := url.Values{}
 	form.Set("status", content)
 	form.Set("in_reply_to_id", parentID)
-	form.Set("visibility", "public")
+	setPostOptions(form, s.visibility, opts)
 
 	data, err := s.client.Post("/api/v1/statuses", strings.NewReader(form.Encode()))
 	if err != nil {
@@ -111,11 +151,25 @@ func (s *postService) Reply(_ context.Context, parentID string, content string,
 	return s.parseStatus(data)
 }
 
-func ensureRequiredHashtag(content string) string {
-	if strings.Contains(strings.ToLower(content), requiredHashtag) {
-		return content
+// setPostOptions adds the visibility, content warning and media of opts to a
+// new status form, falling back to visibility.
+func setPostOptions(form url.Values, visibility string, opts app.PostOptions) {
+	if opts.Visibility != "" {
+		visibility = opts.Visibility
+	}
+	form.Set("visibility", visibility)
+	if opts.SpoilerText != "" {
+		form.Set("spoiler_text", opts.SpoilerText)
+	}
+	for _, id := range opts.MediaIDs {
+		form.Add("media_ids[]", id)
 	}
-	return content + "\n\n" + requiredHashtag
+}
+
+// ensureHashtags appends the tags listed in hashtag (comma or space
+// separated, '#' optional) that content does not already carry.
+func ensureHashtags(content, hashtag string) string {
+	return domain.AppendHashtags(content, domain.ParseHashtags(hashtag))
 }
 
 func (s *postService) parseStatus(data []byte) (domain.Rant, error) {
@@ -138,11 +192,13 @@ func (s *postService) parseStatus(data []byte) (domain.Rant, error) {
 		Username:     sanitizeForTerminal(st.Account.Acct),
 		Content:      stripHTML(st.Content),
 		CreatedAt:    createdAt,
+		EditedAt:     parseEditedAt(st.EditedAt),
 		URL:          sanitizeForTerminal(st.URL),
 		Liked:        st.Favourited,
 		LikesCount:   st.FavouritesCount,
 		RepliesCount: st.RepliesCount,
 		InReplyToID:  fmt.Sprintf("%v", st.InReplyToID),
+		Visibility:   st.Visibility,
 		Media:        mapMediaAttachments(st.MediaAttachments),
 	}, nil
 }
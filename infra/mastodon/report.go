@@ -0,0 +1,77 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// reportService implements app.ReportService using the Mastodon API.
+type reportService struct {
+	client *Client
+}
+
+// NewReportService creates a ReportService backed by Mastodon.
+func NewReportService(client *Client) *reportService {
+	return &reportService{client: client}
+}
+
+// mastodonRule is the JSON shape of a Mastodon Rule entity.
+type mastodonRule struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Hint string `json:"hint"`
+}
+
+func (s *reportService) Rules(_ context.Context) ([]app.Rule, error) {
+	data, err := s.client.Get("/api/v1/instance/rules")
+	if err != nil {
+		return nil, fmt.Errorf("fetching instance rules: %w", err)
+	}
+	var raw []mastodonRule
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing instance rules: %w", err)
+	}
+	out := make([]app.Rule, 0, len(raw))
+	for _, r := range raw {
+		out = append(out, app.Rule{
+			ID:   r.ID,
+			Text: sanitizeForTerminal(r.Text),
+			Hint: sanitizeForTerminal(r.Hint),
+		})
+	}
+	return out, nil
+}
+
+func (s *reportService) Report(_ context.Context, r app.Report) error {
+	if strings.TrimSpace(r.AccountID) == "" {
+		return fmt.Errorf("reporting: missing account")
+	}
+	form := url.Values{}
+	form.Set("account_id", r.AccountID)
+	for _, id := range r.StatusIDs {
+		form.Add("status_ids[]", id)
+	}
+	if r.Category != "" {
+		form.Set("category", string(r.Category))
+	}
+	if r.Category == app.ReportViolation {
+		for _, id := range r.RuleIDs {
+			form.Add("rule_ids[]", id)
+		}
+	}
+	if comment := strings.TrimSpace(r.Comment); comment != "" {
+		form.Set("comment", comment)
+	}
+	form.Set("forward", strconv.FormatBool(r.Forward))
+
+	if _, err := s.client.Post("/api/v1/reports", strings.NewReader(form.Encode())); err != nil {
+		return fmt.Errorf("reporting account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// scheduleService implements app.ScheduleService using the Mastodon API.
+type scheduleService struct {
+	client     *Client
+	visibility string
+}
+
+// NewScheduleService creates a ScheduleService backed by Mastodon. New posts get
+// visibility: public, unlisted, private or direct; empty means public.
+func NewScheduleService(client *Client, visibility string) *scheduleService {
+	if visibility == "" {
+		visibility = "public"
+	}
+	return &scheduleService{client: client, visibility: visibility}
+}
+
+// mastodonScheduledStatus is the subset of Mastodon's ScheduledStatus entity we use.
+type mastodonScheduledStatus struct {
+	ID          string `json:"id"`
+	ScheduledAt string `json:"scheduled_at"`
+	Params      struct {
+		Text        string          `json:"text"`
+		InReplyToID json.RawMessage `json:"in_reply_to_id"` // string, number or null
+		MediaIDs    []string        `json:"media_ids"`
+	} `json:"params"`
+	MediaAttachments []mastodonMediaAttachment `json:"media_attachments"`
+}
+
+func (s *scheduleService) Schedule(_ context.Context, content, hashtag string, inReplyToID string, at time.Time, opts app.PostOptions) (app.ScheduledPost, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return app.ScheduledPost{}, domain.ErrEmptyRant
+	}
+
+	content = ensureHashtags(content, hashtag)
+
+	form := url.Values{}
+	form.Set("status", content)
+	setPostOptions(form, s.visibility, opts)
+	form.Set("scheduled_at", at.UTC().Format(time.RFC3339))
+	if inReplyToID != "" {
+		form.Set("in_reply_to_id", inReplyToID)
+	}
+
+	data, err := s.client.Post("/api/v1/statuses", strings.NewReader(form.Encode()))
+	if err != nil {
+		return app.ScheduledPost{}, fmt.Errorf("scheduling rant: %w", err)
+	}
+	return parseScheduledStatus(data)
+}
+
+func (s *scheduleService) ListScheduled(_ context.Context, limit int, maxID string) ([]app.ScheduledPost, string, error) {
+	if limit <= 0 {
+		limit = 40
+	}
+	path := fmt.Sprintf("/api/v1/scheduled_statuses?limit=%d", limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	data, next, err := s.client.GetPage(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching scheduled rants: %w", err)
+	}
+
+	var raw []mastodonScheduledStatus
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, "", fmt.Errorf("parsing scheduled rants: %w", err)
+	}
+	out := make([]app.ScheduledPost, 0, len(raw))
+	for _, st := range raw {
+		out = append(out, mapScheduledStatus(st))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ScheduledAt.Before(out[j].ScheduledAt) })
+	return out, next, nil
+}
+
+func (s *scheduleService) Reschedule(_ context.Context, id string, at time.Time) (app.ScheduledPost, error) {
+	form := url.Values{}
+	form.Set("scheduled_at", at.UTC().Format(time.RFC3339))
+
+	path := fmt.Sprintf("/api/v1/scheduled_statuses/%s", url.PathEscape(id))
+	data, err := s.client.Put(path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return app.ScheduledPost{}, fmt.Errorf("rescheduling rant: %w", err)
+	}
+	return parseScheduledStatus(data)
+}
+
+func (s *scheduleService) CancelScheduled(_ context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/scheduled_statuses/%s", url.PathEscape(id))
+	if _, err := s.client.Delete(path); err != nil {
+		return fmt.Errorf("cancelling scheduled rant: %w", err)
+	}
+	return nil
+}
+
+func parseScheduledStatus(data []byte) (app.ScheduledPost, error) {
+	var st mastodonScheduledStatus
+	if err := json.Unmarshal(data, &st); err != nil {
+		return app.ScheduledPost{}, fmt.Errorf("parsing scheduled status: %w", err)
+	}
+	return mapScheduledStatus(st), nil
+}
+
+func mapScheduledStatus(st mastodonScheduledStatus) app.ScheduledPost {
+	at, _ := time.Parse(time.RFC3339, st.ScheduledAt)
+	inReplyToID := strings.Trim(string(st.Params.InReplyToID), `"`)
+	if inReplyToID == "null" {
+		inReplyToID = ""
+	}
+	mediaCount := len(st.MediaAttachments)
+	if mediaCount == 0 {
+		mediaCount = len(st.Params.MediaIDs)
+	}
+	return app.ScheduledPost{
+		ID:          st.ID,
+		ScheduledAt: at,
+		Content:     sanitizeForTerminal(st.Params.Text),
+		InReplyToID: inReplyToID,
+		MediaCount:  mediaCount,
+	}
+}
@@ -0,0 +1,94 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// searchService implements app.SearchService using the Mastodon API.
+type searchService struct {
+	client *Client
+}
+
+// NewSearchService creates a SearchService backed by Mastodon.
+func NewSearchService(client *Client) *searchService {
+	return &searchService{client: client}
+}
+
+func (s *searchService) SearchAccounts(_ context.Context, query string, limit int) ([]app.Suggestion, error) {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 8
+	}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", fmt.Sprint(limit))
+	params.Set("resolve", "false")
+	data, err := s.client.Get("/api/v1/accounts/search?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("searching accounts: %w", err)
+	}
+
+	var accounts []struct {
+		Acct        string `json:"acct"`
+		DisplayName string `json:"display_name"`
+	}
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("parsing accounts: %w", err)
+	}
+	out := make([]app.Suggestion, 0, len(accounts))
+	for _, a := range accounts {
+		out = append(out, app.Suggestion{
+			Value: "@" + sanitizeForTerminal(a.Acct),
+			Label: sanitizeForTerminal(a.DisplayName),
+		})
+	}
+	return out, nil
+}
+
+func (s *searchService) SearchHashtags(_ context.Context, query string, limit int) ([]app.Suggestion, error) {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "#")
+	if query == "" {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 8
+	}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("type", "hashtags")
+	params.Set("limit", fmt.Sprint(limit))
+	data, err := s.client.Get("/api/v2/search?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("searching hashtags: %w", err)
+	}
+
+	var result struct {
+		Hashtags []struct {
+			Name    string `json:"name"`
+			History []struct {
+				Uses string `json:"uses"` // Mastodon sends counts as strings
+			} `json:"history"`
+		} `json:"hashtags"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parsing hashtags: %w", err)
+	}
+	out := make([]app.Suggestion, 0, len(result.Hashtags))
+	for _, h := range result.Hashtags {
+		sg := app.Suggestion{Value: "#" + sanitizeForTerminal(h.Name)}
+		if len(h.History) > 0 && h.History[0].Uses != "" && h.History[0].Uses != "0" {
+			sg.Label = sanitizeForTerminal(h.History[0].Uses) + " posts today"
+		}
+		out = append(out, sg)
+	}
+	return out, nil
+}
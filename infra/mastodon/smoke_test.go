@@ -55,7 +55,7 @@ func TestSmoke_MutationRoundtrip_OptIn(t *testing.T) {
 		t.Skip("SMOKE_ALLOW_MUTATION=true required")
 	}
 	client := smokeClient(t)
-	post := NewPostService(client)
+	post := NewPostService(client, "")
 
 	marker := fmt.Sprintf("smoke-%d", time.Now().Unix())
 	r, err := post.Post(context.Background(), "smoke post "+marker, "terminalrant")
@@ -0,0 +1,97 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// mastodonTag is the JSON shape of a Mastodon Tag entity.
+type mastodonTag struct {
+	Name      string `json:"name"`
+	Following bool   `json:"following"`
+}
+
+func (t mastodonTag) toApp() app.Tag {
+	return app.Tag{Name: sanitizeForTerminal(t.Name), Following: t.Following}
+}
+
+// tagPath returns the API path of a hashtag, or an error for an empty name.
+func tagPath(name string) (string, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	if name == "" {
+		return "", fmt.Errorf("invalid hashtag")
+	}
+	return "/api/v1/tags/" + url.PathEscape(name), nil
+}
+
+func (s *accountService) FollowedTags(_ context.Context, limit int, maxID string) ([]app.Tag, string, error) {
+	if limit <= 0 {
+		limit = 40
+	}
+	path := fmt.Sprintf("/api/v1/followed_tags?limit=%d", limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	data, next, err := s.client.GetPage(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching followed tags: %w", err)
+	}
+	var tags []mastodonTag
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, "", fmt.Errorf("parsing followed tags: %w", err)
+	}
+	out := make([]app.Tag, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.toApp())
+	}
+	return out, next, nil
+}
+
+func (s *accountService) LookupTag(_ context.Context, name string) (app.Tag, error) {
+	path, err := tagPath(name)
+	if err != nil {
+		return app.Tag{}, err
+	}
+	data, err := s.client.Get(path)
+	if err != nil {
+		return app.Tag{}, fmt.Errorf("fetching tag: %w", err)
+	}
+	return parseTag(data)
+}
+
+func (s *accountService) FollowTag(_ context.Context, name string) (app.Tag, error) {
+	path, err := tagPath(name)
+	if err != nil {
+		return app.Tag{}, err
+	}
+	data, err := s.client.Post(path+"/follow", nil)
+	if err != nil {
+		return app.Tag{}, fmt.Errorf("following tag: %w", err)
+	}
+	return parseTag(data)
+}
+
+func (s *accountService) UnfollowTag(_ context.Context, name string) (app.Tag, error) {
+	path, err := tagPath(name)
+	if err != nil {
+		return app.Tag{}, err
+	}
+	data, err := s.client.Post(path+"/unfollow", nil)
+	if err != nil {
+		return app.Tag{}, fmt.Errorf("unfollowing tag: %w", err)
+	}
+	return parseTag(data)
+}
+
+func parseTag(data []byte) (app.Tag, error) {
+	var t mastodonTag
+	if err := json.Unmarshal(data, &t); err != nil {
+		return app.Tag{}, fmt.Errorf("parsing tag: %w", err)
+	}
+	return t.toApp(), nil
+}
@@ -7,9 +7,11 @@ import (
 	"html"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
 
@@ -17,6 +19,9 @@ import (
 type timelineService struct {
 	client           *Client
 	currentAccountID string // Set after init to mark own posts.
+	// acctDomain qualifies local usernames of a remote instance, so that
+	// "alice" there reads as "alice@domain" here.
+	acctDomain string
 }
 
 // NewTimelineService creates a TimelineService backed by Mastodon.
@@ -28,17 +33,34 @@ func NewTimelineService(client *Client, currentAccountID string) *timelineServic
 	}
 }
 
+// NewRemoteTimelineService creates a read-only TimelineService for browsing
+// another instance anonymously. Usernames local to that instance are
+// qualified with its domain.
+func NewRemoteTimelineService(instanceURL string) *timelineService {
+	instanceURL = strings.TrimRight(strings.TrimSpace(instanceURL), "/")
+	acctDomain := instanceURL
+	if u, err := url.Parse(instanceURL); err == nil && u.Host != "" {
+		acctDomain = u.Host
+	}
+	return &timelineService{
+		client:     NewClient(instanceURL, nil),
+		acctDomain: acctDomain,
+	}
+}
+
 // mastodonStatus is the subset of Mastodon's Status entity we care about.
 type mastodonStatus struct {
 	ID               string                    `json:"id"`
 	Content          string                    `json:"content"` // HTML
 	CreatedAt        string                    `json:"created_at"`
+	EditedAt         string                    `json:"edited_at"` // null unless edited
 	URL              string                    `json:"url"`
 	Account          mastodonAccount           `json:"account"`
 	Favourited       bool                      `json:"favourited"`
 	FavouritesCount  int                       `json:"favourites_count"`
 	RepliesCount     int                       `json:"replies_count"`
 	InReplyToID      interface{}               `json:"in_reply_to_id"` // Can be string or null
+	Visibility       string                    `json:"visibility"`
 	MediaAttachments []mastodonMediaAttachment `json:"media_attachments"`
 }
 
@@ -62,6 +84,15 @@ type mastodonMediaAttachment struct {
 	} `json:"meta"`
 }
 
+// username returns the account's handle as seen from the user's instance.
+func (s *timelineService) username(a mastodonAccount) string {
+	acct := sanitizeForTerminal(a.Acct)
+	if s.acctDomain != "" && acct != "" && !strings.Contains(acct, "@") {
+		acct += "@" + s.acctDomain
+	}
+	return acct
+}
+
 func (s *timelineService) FetchByHashtag(_ context.Context, hashtag string, limit int) ([]domain.Rant, error) {
 	return s.FetchByHashtagPage(context.Background(), hashtag, limit, "")
 }
@@ -90,6 +121,14 @@ func (s *timelineService) FetchPublicPage(_ context.Context, limit int, maxID st
 	return s.fetchTimelinePath(path)
 }
 
+func (s *timelineService) FetchLocalPage(_ context.Context, limit int, maxID string) ([]domain.Rant, error) {
+	path := fmt.Sprintf("/api/v1/timelines/public?local=true&limit=%d", limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	return s.fetchTimelinePath(path)
+}
+
 func (s *timelineService) FetchTrendingPage(_ context.Context, limit int, maxID string) ([]domain.Rant, error) {
 	if limit <= 0 {
 		limit = 20
@@ -117,6 +156,36 @@ func (s *timelineService) FetchTrendingPage(_ context.Context, limit int, maxID
 	return rants, nil
 }
 
+func (s *timelineService) FetchBookmarksPage(_ context.Context, limit int, maxID string) ([]domain.Rant, string, error) {
+	return s.fetchLinkedPage("/api/v1/bookmarks", limit, maxID)
+}
+
+func (s *timelineService) FetchFavouritesPage(_ context.Context, limit int, maxID string) ([]domain.Rant, string, error) {
+	return s.fetchLinkedPage("/api/v1/favourites", limit, maxID)
+}
+
+// fetchLinkedPage reads a timeline whose paging cursor is only available via
+// the Link header (the max_id is not a status ID).
+func (s *timelineService) fetchLinkedPage(base string, limit int, maxID string) ([]domain.Rant, string, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	path := fmt.Sprintf("%s?limit=%d", base, limit)
+	if maxID != "" {
+		path += "&max_id=" + url.QueryEscape(maxID)
+	}
+	data, next, err := s.client.GetPage(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %s: %w", base, err)
+	}
+
+	var statuses []mastodonStatus
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", base, err)
+	}
+	return s.mapStatuses(statuses), next, nil
+}
+
 func (s *timelineService) fetchTimelinePath(path string) ([]domain.Rant, error) {
 	data, err := s.client.Get(path)
 	if err != nil {
@@ -146,15 +215,17 @@ func (s *timelineService) fetchTimelinePath(path string) ([]domain.Rant, error)
 			ID:           st.ID,
 			AccountID:    st.Account.ID,
 			Author:       author,
-			Username:     sanitizeForTerminal(st.Account.Acct),
+			Username:     s.username(st.Account),
 			Content:      stripHTML(st.Content),
 			CreatedAt:    createdAt,
+			EditedAt:     parseEditedAt(st.EditedAt),
 			URL:          sanitizeForTerminal(st.URL),
 			IsOwn:        s.currentAccountID != "" && st.Account.ID == s.currentAccountID,
 			Liked:        st.Favourited,
 			LikesCount:   st.FavouritesCount,
 			RepliesCount: st.RepliesCount,
 			InReplyToID:  inReplyToID,
+			Visibility:   st.Visibility,
 			Media:        mapMediaAttachments(st.MediaAttachments),
 		})
 	}
@@ -162,6 +233,48 @@ func (s *timelineService) fetchTimelinePath(path string) ([]domain.Rant, error)
 	return rants, nil
 }
 
+func (s *timelineService) FetchStatus(_ context.Context, id string) (domain.Rant, error) {
+	path := fmt.Sprintf("/api/v1/statuses/%s", url.PathEscape(id))
+
+	data, err := s.client.Get(path)
+	if err != nil {
+		return domain.Rant{}, fmt.Errorf("fetching status: %w", err)
+	}
+
+	var st mastodonStatus
+	if err := json.Unmarshal(data, &st); err != nil {
+		return domain.Rant{}, fmt.Errorf("parsing status: %w", err)
+	}
+
+	return s.mapStatuses([]mastodonStatus{st})[0], nil
+}
+
+func (s *timelineService) ResolveStatus(_ context.Context, statusURL string) (domain.Rant, error) {
+	statusURL = strings.TrimSpace(statusURL)
+	if statusURL == "" {
+		return domain.Rant{}, fmt.Errorf("resolving status: missing URL")
+	}
+	params := url.Values{}
+	params.Set("q", statusURL)
+	params.Set("type", "statuses")
+	params.Set("resolve", "true")
+	params.Set("limit", "1")
+	data, err := s.client.Get("/api/v2/search?" + params.Encode())
+	if err != nil {
+		return domain.Rant{}, fmt.Errorf("resolving status: %w", err)
+	}
+	var result struct {
+		Statuses []mastodonStatus `json:"statuses"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return domain.Rant{}, fmt.Errorf("parsing resolved status: %w", err)
+	}
+	if len(result.Statuses) == 0 {
+		return domain.Rant{}, fmt.Errorf("resolving status: %w", app.ErrNotFound)
+	}
+	return s.mapStatuses(result.Statuses[:1])[0], nil
+}
+
 type mastodonContext struct {
 	Ancestors   []mastodonStatus `json:"ancestors"`
 	Descendants []mastodonStatus `json:"descendants"`
@@ -186,6 +299,44 @@ func (s *timelineService) FetchThread(_ context.Context, id string) (ancestors,
 	return ancestors, descendants, nil
 }
 
+// mastodonStatusEdit is the subset of Mastodon's StatusEdit entity we use.
+type mastodonStatusEdit struct {
+	Content   string `json:"content"` // HTML
+	CreatedAt string `json:"created_at"`
+}
+
+func (s *timelineService) FetchHistory(_ context.Context, id string) ([]app.Revision, error) {
+	path := fmt.Sprintf("/api/v1/statuses/%s/history", url.PathEscape(id))
+
+	data, err := s.client.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("fetching edit history: %w", err)
+	}
+
+	var edits []mastodonStatusEdit
+	if err := json.Unmarshal(data, &edits); err != nil {
+		return nil, fmt.Errorf("parsing edit history: %w", err)
+	}
+
+	revisions := make([]app.Revision, 0, len(edits))
+	for _, e := range edits {
+		createdAt, _ := time.Parse(time.RFC3339, e.CreatedAt)
+		revisions = append(revisions, app.Revision{
+			Content:   strings.TrimSpace(stripHTML(e.Content)),
+			CreatedAt: createdAt,
+		})
+	}
+	return revisions, nil
+}
+
+func (s *timelineService) FavouritedBy(_ context.Context, id string, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	return fetchAccountPage(s.client, fmt.Sprintf("/api/v1/statuses/%s/favourited_by", url.PathEscape(id)), limit, maxID)
+}
+
+func (s *timelineService) RebloggedBy(_ context.Context, id string, limit int, maxID string) ([]app.AccountSummary, string, error) {
+	return fetchAccountPage(s.client, fmt.Sprintf("/api/v1/statuses/%s/reblogged_by", url.PathEscape(id)), limit, maxID)
+}
+
 func (s *timelineService) mapStatuses(statuses []mastodonStatus) []domain.Rant {
 	rants := make([]domain.Rant, 0, len(statuses))
 	for _, st := range statuses {
@@ -205,21 +356,33 @@ func (s *timelineService) mapStatuses(statuses []mastodonStatus) []domain.Rant {
 			ID:           st.ID,
 			AccountID:    st.Account.ID,
 			Author:       author,
-			Username:     sanitizeForTerminal(st.Account.Acct),
+			Username:     s.username(st.Account),
 			Content:      stripHTML(st.Content),
 			CreatedAt:    createdAt,
+			EditedAt:     parseEditedAt(st.EditedAt),
 			URL:          sanitizeForTerminal(st.URL),
 			IsOwn:        s.currentAccountID != "" && st.Account.ID == s.currentAccountID,
 			Liked:        st.Favourited,
 			LikesCount:   st.FavouritesCount,
 			RepliesCount: st.RepliesCount,
 			InReplyToID:  inReplyToID,
+			Visibility:   st.Visibility,
 			Media:        mapMediaAttachments(st.MediaAttachments),
 		})
 	}
 	return rants
 }
 
+// parseEditedAt parses a status's edited_at, which is null for unedited
+// statuses.
+func parseEditedAt(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
 func mapMediaAttachments(in []mastodonMediaAttachment) []domain.MediaAttachment {
 	if len(in) == 0 {
 		return nil
@@ -278,3 +441,86 @@ func sanitizeForTerminal(s string) string {
 	}
 	return b.String()
 }
+
+// mastodonTrendHistory is the daily usage attached to trending tags and
+// links, newest day first. Mastodon sends the counts as strings.
+type mastodonTrendHistory []struct {
+	Uses     string `json:"uses"`
+	Accounts string `json:"accounts"`
+}
+
+// uses returns posts per day, oldest first.
+func (h mastodonTrendHistory) uses() []int {
+	out := make([]int, len(h))
+	for i, day := range h {
+		n, _ := strconv.Atoi(day.Uses)
+		out[len(h)-1-i] = n
+	}
+	return out
+}
+
+func (h mastodonTrendHistory) accounts() int {
+	total := 0
+	for _, day := range h {
+		n, _ := strconv.Atoi(day.Accounts)
+		total += n
+	}
+	return total
+}
+
+func (s *timelineService) TrendingTags(_ context.Context, limit int) ([]app.TrendingTag, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	data, err := s.client.Get(fmt.Sprintf("/api/v1/trends/tags?limit=%d", limit))
+	if err != nil {
+		return nil, fmt.Errorf("fetching trending tags: %w", err)
+	}
+	var tags []struct {
+		Name    string               `json:"name"`
+		History mastodonTrendHistory `json:"history"`
+	}
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("parsing trending tags: %w", err)
+	}
+	out := make([]app.TrendingTag, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, app.TrendingTag{
+			Name:     sanitizeForTerminal(t.Name),
+			Uses:     t.History.uses(),
+			Accounts: t.History.accounts(),
+		})
+	}
+	return out, nil
+}
+
+func (s *timelineService) TrendingLinks(_ context.Context, limit int) ([]app.TrendingLink, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	data, err := s.client.Get(fmt.Sprintf("/api/v1/trends/links?limit=%d", limit))
+	if err != nil {
+		return nil, fmt.Errorf("fetching trending links: %w", err)
+	}
+	var links []struct {
+		URL          string               `json:"url"`
+		Title        string               `json:"title"`
+		Description  string               `json:"description"`
+		ProviderName string               `json:"provider_name"`
+		History      mastodonTrendHistory `json:"history"`
+	}
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, fmt.Errorf("parsing trending links: %w", err)
+	}
+	out := make([]app.TrendingLink, 0, len(links))
+	for _, l := range links {
+		out = append(out, app.TrendingLink{
+			URL:         sanitizeForTerminal(l.URL),
+			Title:       sanitizeForTerminal(strings.TrimSpace(l.Title)),
+			Description: sanitizeForTerminal(strings.TrimSpace(l.Description)),
+			Provider:    sanitizeForTerminal(strings.TrimSpace(l.ProviderName)),
+			Uses:        l.History.uses(),
+		})
+	}
+	return out, nil
+}
@@ -2,18 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"runtime/debug"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/infra/auth"
 	"github.com/CrestNiraj12/terminalrant/infra/config"
+	"github.com/CrestNiraj12/terminalrant/infra/drafts"
 	"github.com/CrestNiraj12/terminalrant/infra/editor"
 	"github.com/CrestNiraj12/terminalrant/infra/mastodon"
 	"github.com/CrestNiraj12/terminalrant/tui"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
 )
 
 var (
@@ -28,9 +36,15 @@ const (
 	cliRun cliMode = iota
 	cliVersion
 	cliHelp
+	cliLogout
+	cliOpen
+	cliConfigCheck
+	cliCommand
 	cliInvalid
 )
 
+// parseCLIArgs picks the mode to run in. The string is the link to open for
+// cliOpen and the error message for cliInvalid.
 func parseCLIArgs(args []string) (cliMode, string) {
 	if len(args) == 0 {
 		return cliRun, ""
@@ -41,13 +55,69 @@ func parseCLIArgs(args []string) (cliMode, string) {
 		return cliVersion, ""
 	case "--help", "-h", "help":
 		return cliHelp, ""
-	default:
-		return cliInvalid, fmt.Sprintf("unexpected argument: %s", strings.Join(args, " "))
+	case "logout":
+		return cliLogout, ""
+	case "open":
+		if len(args) != 2 || strings.TrimSpace(args[1]) == "" {
+			return cliInvalid, "open needs exactly one post or profile URL"
+		}
+		return cliOpen, strings.TrimSpace(args[1])
+	case "config":
+		if len(args) != 2 || args[1] != "check" {
+			return cliInvalid, "config needs a command: config check"
+		}
+		return cliConfigCheck, ""
+	}
+	if _, ok := findSubcommand(args[0]); ok {
+		return cliCommand, ""
 	}
+	return cliInvalid, fmt.Sprintf("unexpected argument: %s", strings.Join(args, " "))
+}
+
+// globalFlags are the flags that may precede any command. Each takes a
+// value, given as the next argument or after '='.
+var globalFlags = []string{"--config", "--profile", "--instance", "--hashtag"}
+
+// splitGlobalFlags takes the leading global flags off args. They override
+// the config file and the environment.
+func splitGlobalFlags(args []string) (config.Overrides, []string, error) {
+	var o config.Overrides
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		if !slices.Contains(globalFlags, name) {
+			break
+		}
+		args = args[1:]
+		if !hasValue {
+			if len(args) == 0 {
+				return o, nil, fmt.Errorf("%s needs a value", name)
+			}
+			value, args = args[0], args[1:]
+		}
+		if strings.TrimSpace(value) == "" {
+			return o, nil, fmt.Errorf("%s needs a value", name)
+		}
+		switch name {
+		case "--config":
+			o.ConfigPath = value
+		case "--profile":
+			o.Profile = value
+		case "--instance":
+			o.Instance = value
+		case "--hashtag":
+			o.Hashtag = value
+		}
+	}
+	return o, args, nil
 }
 
 func usage() string {
-	return "Usage: terminalrant [--version|-version|-v] [--help|-h]"
+	return "Usage: terminalrant [--config PATH] [--profile NAME] [--instance URL] [--hashtag TAG] [command]\n" +
+		"       terminalrant [--version|-version|-v] [--help|-h]" +
+		usageLine("logout", "revoke the token and delete local credentials") +
+		usageLine("open <url>", "start on the post or profile at url") +
+		usageLine("config check", "validate the config file and print the effective settings") +
+		subcommandUsage()
 }
 
 func hasCommitInfo(c string) bool {
@@ -113,8 +183,134 @@ func resolvedRuntimeVersionInfo(v, c, d string) (string, string, string) {
 	return resolveVersionInfo(v, c, d, info.Main.Version, buildSettingsMap(info.Settings))
 }
 
+// openTokenStore resolves the configured token backend and moves a legacy
+// plaintext token into it when one is found.
+func openTokenStore(cfg config.Config) (auth.TokenStore, error) {
+	store, err := auth.OpenTokenStore(auth.StoreOptions{
+		Backend:       cfg.TokenStore,
+		InstanceURL:   cfg.InstanceURL,
+		Account:       cfg.KeyringAccount,
+		PlaintextPath: cfg.OAuthTokenPath,
+		EncryptedPath: cfg.EncryptedTokenPath,
+		Passphrase:    auth.EnvPassphrase("TERMINALRANT_TOKEN_PASSPHRASE"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := auth.MigratePlaintextToken(cfg.OAuthTokenPath, store)
+	if err != nil {
+		return nil, err
+	}
+	if migrated {
+		fmt.Fprintf(os.Stderr, "Moved plaintext token into %s.\n", store.Describe())
+	}
+	return store, nil
+}
+
+// loadKeyMap applies the [keys] table of the config file to the configured
+// preset. Keys bound to two actions of the same view are an error.
+func loadKeyMap(cfg config.Config) (common.KeyMap, error) {
+	preset, err := common.PresetKeyMap(cfg.KeyPreset)
+	if err != nil {
+		return common.KeyMap{}, err
+	}
+	keys, err := preset.Remap(cfg.Keys)
+	if err != nil {
+		return common.KeyMap{}, fmt.Errorf("%s: [keys]: %w", cfg.ConfigPath, err)
+	}
+	if conflicts := keys.Conflicts(); len(conflicts) > 0 {
+		return common.KeyMap{}, fmt.Errorf("%s: [keys]: conflicting keys:\n  %s", cfg.ConfigPath, strings.Join(conflicts, "\n  "))
+	}
+	return keys, nil
+}
+
+// runUsage prints msg and the usage text, returning the exit code for a
+// malformed command line.
+func runUsage(msg string, errOut io.Writer) int {
+	fmt.Fprintf(errOut, "%s\n%s\n", msg, usage())
+	return exitUsage
+}
+
+// runConfigCheck validates the configuration and prints the merged result,
+// returning the process exit code.
+func runConfigCheck(o config.Overrides, out, errOut io.Writer) int {
+	cfg, err := config.LoadWith(o)
+	if err == nil {
+		_, err = loadKeyMap(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(errOut, "config: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintln(out, cfg.Effective())
+	return exitOK
+}
+
+// runCLICommand runs a scripting subcommand without starting the TUI and
+// returns the process exit code. It never opens a browser to log in.
+func runCLICommand(o config.Overrides, args []string) int {
+	cfg, err := config.LoadWith(o)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return exitError
+	}
+
+	tagPolicy, err := config.LoadTagPolicy(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return exitError
+	}
+
+	action, err := parseSubcommand(args, cliInput{
+		Stdin:       os.Stdin,
+		StdinIsTerm: term.IsTerminal(os.Stdin.Fd()),
+		DefaultTag:  cfg.Hashtag,
+	}, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "terminalrant %s: %v\n", args[0], err)
+		return exitUsage
+	}
+
+	tokenStore, err := openTokenStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "token store: %v\n", err)
+		return exitError
+	}
+	if _, err := tokenStore.AccessToken(); err != nil {
+		fmt.Fprintf(os.Stderr, "not logged in (%v); run terminalrant once to sign in\n", err)
+		return exitError
+	}
+
+	httpClient := mastodon.NewClient(cfg.InstanceURL, tokenStore)
+	accountSvc := mastodon.NewAccountService(httpClient)
+	// The timeline marks the user's own rants, which --format json reports.
+	accountID, err := accountSvc.CurrentAccountID(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "terminalrant %s: resolving current account: %v\n", args[0], err)
+		return exitError
+	}
+	svc := cliServices{
+		Timeline: mastodon.NewTimelineService(httpClient, accountID),
+		Post:     mastodon.NewPostService(httpClient, cfg.Visibility),
+		Account:  accountSvc,
+		Instance: mastodon.NewInstanceService(httpClient, cfg.InstanceCachePath),
+		Search:   mastodon.NewSearchService(httpClient),
+		Tags:     tagPolicy,
+		Hashtag:  cfg.Hashtag,
+	}
+	return runSubcommand(context.Background(), args[0], action, svc, os.Stdout, os.Stderr)
+}
+
 func main() {
-	mode, msg := parseCLIArgs(os.Args[1:])
+	overrides, args, err := splitGlobalFlags(os.Args[1:])
+	if err != nil {
+		os.Exit(runUsage(err.Error(), os.Stderr))
+	}
+	mode, msg := parseCLIArgs(args)
+	openLink := ""
 	switch mode {
 	case cliVersion:
 		v, c, d := resolvedRuntimeVersionInfo(version, commit, date)
@@ -123,25 +319,51 @@ func main() {
 	case cliHelp:
 		fmt.Println(usage())
 		return
+	case cliOpen:
+		openLink = msg
+	case cliConfigCheck:
+		os.Exit(runConfigCheck(overrides, os.Stdout, os.Stderr))
+	case cliCommand:
+		os.Exit(runCLICommand(overrides, args))
 	case cliInvalid:
-		fmt.Fprintf(os.Stderr, "%s\n%s\n", msg, usage())
+		os.Exit(runUsage(msg, os.Stderr))
 	}
 
-	// 1. Load config from environment.
-	cfg, err := config.Load()
+	// 1. Load config from the config file, environment and flags.
+	cfg, err := config.LoadWith(overrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		os.Exit(1)
+	}
+	keys, err := loadKeyMap(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "config: %v\n", err)
 		os.Exit(1)
 	}
+	common.ApplyTheme(cfg.Theme)
+
+	tokenStore, err := openTokenStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "token store: %v\n", err)
+		os.Exit(1)
+	}
+
+	if mode == cliLogout {
+		if err := auth.Logout(context.Background(), cfg.InstanceURL, tokenStore, cfg.OAuthClientPath); err != nil {
+			fmt.Fprintf(os.Stderr, "logout: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Logged out. Local credentials removed.")
+		return
+	}
 
 	// 2. Build infrastructure.
-	if err := auth.EnsureOAuthLogin(context.Background(), cfg.InstanceURL, cfg.OAuthTokenPath, cfg.OAuthClientPath, cfg.OAuthCallbackPort); err != nil {
+	if err := auth.EnsureOAuthLogin(context.Background(), cfg.InstanceURL, tokenStore, cfg.OAuthClientPath, cfg.OAuthCallbackPort); err != nil {
 		fmt.Fprintf(os.Stderr, "oauth login: %v\n", err)
 		os.Exit(1)
 	}
 
-	tokenProvider := auth.NewFileTokenProvider(cfg.OAuthTokenPath)
-	httpClient := mastodon.NewClient(cfg.InstanceURL, tokenProvider)
+	httpClient := mastodon.NewClient(cfg.InstanceURL, tokenStore)
 
 	// 3. Build services (concrete types satisfy app.* interfaces).
 	accountSvc := mastodon.NewAccountService(httpClient)
@@ -149,8 +371,14 @@ func main() {
 	accountID, _ := accountSvc.CurrentAccountID(context.Background())
 
 	timelineSvc := mastodon.NewTimelineService(httpClient, accountID)
-	postSvc := mastodon.NewPostService(httpClient)
-	editorSvc := editor.NewEnvEditor()
+	postSvc := mastodon.NewPostService(httpClient, cfg.Visibility)
+	editorSvc := editor.NewEnvEditor(cfg.Editor)
+
+	tagPolicy, err := config.LoadTagPolicy(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		os.Exit(1)
+	}
 
 	uiState, _ := config.LoadUIState(cfg.UIStatePath)
 	initialHashtag := cfg.Hashtag
@@ -163,14 +391,31 @@ func main() {
 	}
 
 	// 4. Wire root TUI model.
+	remoteTimeline := func(instanceURL string) app.TimelineService {
+		return mastodon.NewRemoteTimelineService(instanceURL)
+	}
 	rootModel := tui.NewApp(tui.Deps{
-		Timeline:  timelineSvc,
-		Post:      postSvc,
-		Account:   accountSvc,
-		Editor:    editorSvc,
-		Hashtag:   initialHashtag,
-		FeedView:  initialFeedSource,
-		StatePath: cfg.UIStatePath,
+		Timeline:      timelineSvc,
+		Post:          postSvc,
+		Account:       accountSvc,
+		Schedule:      mastodon.NewScheduleService(httpClient, cfg.Visibility),
+		Drafts:        drafts.NewStore(cfg.DraftsDir),
+		Instance:      mastodon.NewInstanceService(httpClient, cfg.InstanceCachePath),
+		Reports:       mastodon.NewReportService(httpClient),
+		Search:        mastodon.NewSearchService(httpClient),
+		Remote:        remoteTimeline,
+		Markers:       mastodon.NewMarkerService(httpClient),
+		Notifications: mastodon.NewNotificationService(httpClient, accountID),
+		Tags:          tagPolicy,
+		Editor:        editorSvc,
+		Hashtag:       initialHashtag,
+		FeedView:      initialFeedSource,
+		StatePath:     cfg.UIStatePath,
+		OpenLink:      openLink,
+		Keys:          keys,
+		Tabs:          cfg.Tabs,
+		Previews:      cfg.MediaPreviews,
+		Animate:       cfg.MediaAnimate,
 	})
 
 	// 5. Run.
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/CrestNiraj12/terminalrant/infra/config"
 )
 
 func TestParseCLIArgs(t *testing.T) {
@@ -19,6 +23,14 @@ func TestParseCLIArgs(t *testing.T) {
 		{name: "help long", args: []string{"--help"}, mode: cliHelp},
 		{name: "help short", args: []string{"-h"}, mode: cliHelp},
 		{name: "help word", args: []string{"help"}, mode: cliHelp},
+		{name: "logout", args: []string{"logout"}, mode: cliLogout},
+		{name: "open link", args: []string{"open", "https://mastodon.social/@alice/1"}, mode: cliOpen, msg: "https://mastodon.social/@alice/1"},
+		{name: "open without link", args: []string{"open"}, mode: cliInvalid, msg: "open needs exactly one post or profile URL"},
+		{name: "config check", args: []string{"config", "check"}, mode: cliConfigCheck},
+		{name: "config without command", args: []string{"config"}, mode: cliInvalid, msg: "config needs a command: config check"},
+		{name: "post subcommand", args: []string{"post", "-m", "hi"}, mode: cliCommand},
+		{name: "timeline subcommand", args: []string{"timeline", "--limit", "5"}, mode: cliCommand},
+		{name: "whoami subcommand", args: []string{"whoami"}, mode: cliCommand},
 		{name: "invalid flag", args: []string{"--bogus"}, mode: cliInvalid, msg: "unexpected argument: --bogus"},
 		{name: "invalid flags", args: []string{"--bogus", "--pogus"}, mode: cliInvalid, msg: "unexpected argument: --bogus --pogus"},
 		{name: "valid with invalid after", args: []string{"--version", "extra"}, mode: cliVersion},
@@ -37,6 +49,79 @@ func TestParseCLIArgs(t *testing.T) {
 	}
 }
 
+func TestSplitGlobalFlags(t *testing.T) {
+	o, rest, err := splitGlobalFlags([]string{"--profile", "work", "--instance=https://a.example", "--hashtag", "go", "timeline", "--limit", "5"})
+	if err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+	if o.Profile != "work" || o.Instance != "https://a.example" || o.Hashtag != "go" || o.ConfigPath != "" {
+		t.Fatalf("unexpected overrides: %#v", o)
+	}
+	if strings.Join(rest, " ") != "timeline --limit 5" {
+		t.Fatalf("expected the command to be left, got %v", rest)
+	}
+	if _, _, err := splitGlobalFlags([]string{"--config"}); err == nil {
+		t.Fatalf("expected a flag without value to be refused")
+	}
+	if _, rest, _ := splitGlobalFlags([]string{"--version"}); len(rest) != 1 {
+		t.Fatalf("expected other flags to be left alone, got %v", rest)
+	}
+}
+
+func TestInvalidArgs_ExitWithUsageCode(t *testing.T) {
+	for _, args := range [][]string{
+		{"tiemline"},
+		{"open"},
+		{"open", "a", "b"},
+		{"config"},
+		{"config", "edit"},
+	} {
+		mode, msg := parseCLIArgs(args)
+		if mode != cliInvalid {
+			t.Fatalf("%q: expected invalid arguments, got mode %v", args, mode)
+		}
+		var errOut strings.Builder
+		if code := runUsage(msg, &errOut); code != 2 {
+			t.Fatalf("%q: expected exit code 2, got %d", args, code)
+		}
+		if !strings.Contains(errOut.String(), msg) || !strings.Contains(errOut.String(), usage()) {
+			t.Fatalf("%q: expected the error and usage, got:\n%s", args, errOut.String())
+		}
+	}
+}
+
+func TestRunConfigCheck(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TERMINALRANT_AUTH_DIR", dir)
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("visibility = \"unlisted\"\n[keys]\nlike = \"+\"\n"), 0o600); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	var out, errOut strings.Builder
+	if code := runConfigCheck(config.Overrides{}, &out, &errOut); code != exitOK {
+		t.Fatalf("expected check to pass, got %d: %s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), `visibility = "unlisted"`) || !strings.Contains(out.String(), `like = ["+"]`) {
+		t.Fatalf("expected the effective config, got:\n%s", out.String())
+	}
+
+	if err := os.WriteFile(path, []byte("[keys]\njump = \"J\"\n"), 0o600); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	errOut.Reset()
+	if code := runConfigCheck(config.Overrides{}, &out, &errOut); code != exitError || !strings.Contains(errOut.String(), `unknown key action "jump"`) {
+		t.Fatalf("expected unknown key action to fail the check, got %d: %s", code, errOut.String())
+	}
+
+	if err := os.WriteFile(path, []byte("keymap = \"vim\"\n[keys]\nlike = \"L\"\n"), 0o600); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	errOut.Reset()
+	if code := runConfigCheck(config.Overrides{}, &out, &errOut); code != exitError || !strings.Contains(errOut.String(), `"L" is bound to both like and interactions (feed)`) {
+		t.Fatalf("expected conflicting keys to fail the check, got %d: %s", code, errOut.String())
+	}
+}
+
 func TestFormatVersionOutput_HidesUnknownFields(t *testing.T) {
 	out := formatVersionOutput("v0.4.1", "none", "unknown")
 	if !strings.Contains(out, "TerminalRant v0.4.1") {
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -21,13 +22,27 @@ import (
 
 // Deps holds all dependencies the TUI needs. Plain struct, not a DI container.
 type Deps struct {
-	Timeline  app.TimelineService
-	Post      app.PostService
-	Account   app.AccountService
-	Editor    *editor.EnvEditor
-	Hashtag   string
-	FeedView  string
-	StatePath string
+	Timeline      app.TimelineService
+	Post          app.PostService
+	Account       app.AccountService
+	Schedule      app.ScheduleService
+	Drafts        app.DraftStore
+	Instance      app.InstanceService
+	Reports       app.ReportService
+	Search        app.SearchService
+	Remote        func(instanceURL string) app.TimelineService // Opens another instance read-only
+	Tags          app.TagPolicy                                // Hashtags appended to posts
+	Editor        *editor.EnvEditor
+	Hashtag       string
+	FeedView      string
+	StatePath     string
+	Markers       app.MarkerService
+	Notifications app.NotificationService
+	OpenLink      string        // Post or profile URL to open on start
+	Keys          common.KeyMap // Key bindings; zero value means the defaults
+	Tabs          []string      // Feed tabs in order; empty shows every tab
+	Previews      bool          // Show media previews on start
+	Animate       bool          // Play animated media previews
 }
 
 type activeView int
@@ -43,6 +58,7 @@ type App struct {
 	active      activeView
 	feed        feed.Model
 	compose     compose.Model
+	limits      app.InstanceConfig // Limits of the connected instance
 	keys        common.KeyMap
 	status      string // Transient status message (e.g. "Rant posted!")
 	confirmQuit bool
@@ -53,22 +69,47 @@ type App struct {
 
 // NewApp creates the root model with all dependencies wired.
 func NewApp(deps Deps) App {
+	if deps.Tags.Mode == "" {
+		deps.Tags = app.DefaultTagPolicy()
+	}
+	if len(deps.Keys.ForceQuit.Keys()) == 0 {
+		deps.Keys = common.DefaultKeyMap()
+	}
 	return App{
 		deps:   deps,
 		active: feedView,
-		feed:   feed.New(deps.Timeline, deps.Account, deps.Hashtag, deps.FeedView),
-		keys:   common.DefaultKeyMap(),
+		feed: feed.New(deps.Timeline, deps.Account, deps.Hashtag, deps.FeedView).
+			WithRemoteTimelines(deps.Remote).
+			WithMarkers(deps.Markers).
+			WithNotifications(deps.Notifications).
+			WithKeys(deps.Keys).
+			WithTabs(deps.Tabs).
+			WithMedia(deps.Previews, deps.Animate),
+		keys:   deps.Keys,
+		limits: app.DefaultInstanceConfig(),
 	}
 }
 
-// Init delegates to the active sub-model and fetches the current account ID.
+// Init delegates to the active sub-model and fetches the current account ID
+// and the instance limits.
 func (a App) Init() tea.Cmd {
 	return tea.Batch(
 		a.feed.Init(),
 		a.initAccount(),
+		a.initInstance(),
+		a.initLink(),
 	)
 }
 
+// initLink opens the link given on the command line, if any.
+func (a App) initLink() tea.Cmd {
+	if a.deps.OpenLink == "" {
+		return nil
+	}
+	link := a.deps.OpenLink
+	return func() tea.Msg { return feed.OpenLinkMsg{URL: link} }
+}
+
 func (a App) initAccount() tea.Cmd {
 	return func() tea.Msg {
 		id, _ := a.deps.Account.CurrentAccountID(context.Background())
@@ -80,6 +121,21 @@ type accountIDMsg struct {
 	ID string
 }
 
+func (a App) initInstance() tea.Cmd {
+	if a.deps.Instance == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		// On failure the service still returns usable (cached or default) limits.
+		cfg, _ := a.deps.Instance.InstanceConfig(context.Background())
+		return instanceConfigMsg{Config: cfg}
+	}
+}
+
+type instanceConfigMsg struct {
+	Config app.InstanceConfig
+}
+
 type profileLoadedMsg struct {
 	Profile   app.Profile
 	UseInline bool
@@ -95,6 +151,12 @@ type profileSaveResultMsg struct {
 	Err error
 }
 
+type scheduleResultMsg struct {
+	Post    app.ScheduledPost
+	DraftID string
+	Err     error
+}
+
 // Update handles messages and routes to the active sub-model.
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -105,10 +167,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if a.confirmQuit {
-			switch msg.String() {
-			case "y", "Y":
+			switch {
+			case key.Matches(msg, a.keys.Yes):
 				return a, tea.Quit
-			case "n", "N", "esc", "q":
+			case key.Matches(msg, a.keys.No, a.keys.Back, a.keys.Quit):
 				a.confirmQuit = false
 				return a, nil
 			default:
@@ -127,21 +189,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if key.Matches(msg, a.keys.EditProfile) {
 				return a, a.loadProfileForEdit(false)
 			}
-			if msg.String() == "V" {
+			if key.Matches(msg, a.keys.EditProfileInline) {
 				return a, a.loadProfileForEdit(true)
 			}
 
 			if key.Matches(msg, a.keys.NewEditor) {
 				a.active = composeView
 				a.status = ""
-				a.compose = compose.NewEditor(a.deps.Post, a.deps.Editor, a.deps.Hashtag)
+				a.compose = a.rantComposer(compose.NewEditor(a.deps.Post, a.deps.Editor, a.postTags("")))
 				return a, a.compose.Init()
 			}
 
 			if key.Matches(msg, a.keys.NewInline) {
 				a.active = composeView
 				a.status = ""
-				a.compose = compose.NewInline(a.deps.Post, a.deps.Hashtag)
+				a.compose = a.rantComposer(compose.NewInline(a.deps.Post, a.postTags("")))
 				return a, a.compose.Init()
 			}
 		}
@@ -154,33 +216,43 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Let's assume we can set it on the feed.
 		return a, nil
 
+	case instanceConfigMsg:
+		a.limits = msg.Config
+		if a.active == composeView && !a.profileEditInline {
+			// A composer opened before the instance answered.
+			a.compose = a.compose.WithLimits(a.limits)
+		}
+		return a, nil
+
 	case feed.EditRantMsg:
 		a.active = composeView
 		a.status = ""
-		content := common.StripHashtag(msg.Rant.Content, a.deps.Hashtag)
+		content := common.StripHashtags(msg.Rant.Content, domain.ParseHashtags(a.postTags("")))
 		if msg.UseInline {
-			a.compose = compose.NewInlineWithContent(a.deps.Post, a.deps.Hashtag, msg.Rant.ID, content, true, false, "", "")
+			a.compose = a.rantComposer(compose.NewInlineWithContent(a.deps.Post, a.postTags(""), msg.Rant.ID, content, true, false, "", ""))
 		} else {
-			a.compose = compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, a.deps.Hashtag, msg.Rant.ID, content, true, false, "", "")
+			a.compose = a.rantComposer(compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, a.postTags(""), msg.Rant.ID, content, true, false, "", ""))
 		}
 		return a, a.compose.Init()
 
+	case feed.ResumeDraftMsg:
+		a.active = composeView
+		a.status = ""
+		a.compose = a.rantComposer(a.composeForDraft(msg.Draft, msg.UseInline)).WithDraft(msg.Draft)
+		return a, a.compose.Init()
+
 	case feed.EditProfileMsg:
 		return a, a.loadProfileForEdit(msg.UseInline)
 
 	case feed.ReplyRantMsg:
 		a.active = composeView
 		a.status = ""
-		parentSummary := msg.Rant.Content
-		if len(parentSummary) > 50 {
-			parentSummary = parentSummary[:47] + "..."
-		}
-		parentSummary = fmt.Sprintf("@%s: %s", msg.Rant.Username, parentSummary)
+		parentSummary := replySummary(msg.Rant)
 
 		if msg.UseInline {
-			a.compose = compose.NewInlineWithContent(a.deps.Post, a.deps.Hashtag, msg.Rant.ID, "", false, true, msg.Rant.Username, parentSummary)
+			a.compose = a.rantComposer(compose.NewInlineWithContent(a.deps.Post, a.postTags(msg.Rant.Content), msg.Rant.ID, "", false, true, msg.Rant.Username, parentSummary))
 		} else {
-			a.compose = compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, a.deps.Hashtag, msg.Rant.ID, "", false, true, msg.Rant.Username, parentSummary)
+			a.compose = a.rantComposer(compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, a.postTags(msg.Rant.Content), msg.Rant.ID, "", false, true, msg.Rant.Username, parentSummary))
 		}
 		return a, a.compose.Init()
 
@@ -227,17 +299,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.status = verb + " @" + msg.Username + "..."
 		return a, func() tea.Msg {
-			var err error
+			var (
+				rel app.Relationship
+				err error
+			)
 			if msg.Follow {
-				err = a.deps.Account.FollowUser(context.Background(), msg.AccountID)
+				rel, err = a.deps.Account.FollowUser(context.Background(), msg.AccountID)
 			} else {
-				err = a.deps.Account.UnfollowUser(context.Background(), msg.AccountID)
+				rel, err = a.deps.Account.UnfollowUser(context.Background(), msg.AccountID)
 			}
 			return feed.FollowToggleResultMsg{
-				AccountID: msg.AccountID,
-				Username:  msg.Username,
-				Follow:    msg.Follow,
-				Err:       err,
+				AccountID:    msg.AccountID,
+				Username:     msg.Username,
+				Follow:       msg.Follow,
+				Relationship: rel,
+				Err:          err,
 			}
 		}
 
@@ -246,6 +322,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.feed, cmd = a.feed.Update(msg)
 		if msg.Err != nil {
 			a.status = "Follow update failed: " + msg.Err.Error()
+		} else if msg.Relationship.Requested {
+			a.status = "Follow request sent to @" + msg.Username
 		} else if msg.Follow {
 			a.status = "Following @" + msg.Username
 		} else {
@@ -256,9 +334,39 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case feed.RequestBlockedUsersMsg:
 		return a, func() tea.Msg {
 			users, err := a.deps.Account.ListBlockedUsers(context.Background(), 80)
-			return feed.BlockedUsersLoadedMsg{Users: users, Err: err}
+			if err != nil {
+				return feed.BlockedUsersLoadedMsg{Err: err}
+			}
+			domains, err := a.deps.Account.ListDomainBlocks(context.Background(), 100)
+			return feed.BlockedUsersLoadedMsg{Users: users, Domains: domains, Err: err}
+		}
+
+	case feed.BlockDomainMsg:
+		a.status = "Blocking " + msg.Domain + "..."
+		return a, func() tea.Msg {
+			err := a.deps.Account.BlockDomain(context.Background(), msg.Domain)
+			return feed.DomainBlockResultMsg{Domain: msg.Domain, Block: true, Err: err}
+		}
+
+	case feed.UnblockDomainMsg:
+		return a, func() tea.Msg {
+			err := a.deps.Account.UnblockDomain(context.Background(), msg.Domain)
+			return feed.DomainBlockResultMsg{Domain: msg.Domain, Err: err}
 		}
 
+	case feed.DomainBlockResultMsg:
+		var cmd tea.Cmd
+		a.feed, cmd = a.feed.Update(msg)
+		if !msg.Block {
+			return a, cmd
+		}
+		if msg.Err != nil {
+			a.status = "Error blocking " + msg.Domain + ": " + msg.Err.Error()
+		} else {
+			a.status = "Blocked " + msg.Domain + ". Posts from it are hidden."
+		}
+		return a, cmd
+
 	case feed.UnblockUserMsg:
 		return a, func() tea.Msg {
 			err := a.deps.Account.UnblockUser(context.Background(), msg.AccountID)
@@ -269,6 +377,123 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case feed.ResolveFollowRequestMsg:
+		return a, func() tea.Msg {
+			var err error
+			if msg.Authorize {
+				err = a.deps.Account.AuthorizeFollowRequest(context.Background(), msg.AccountID)
+			} else {
+				err = a.deps.Account.RejectFollowRequest(context.Background(), msg.AccountID)
+			}
+			return feed.FollowRequestResolvedMsg{
+				AccountID: msg.AccountID,
+				Username:  msg.Username,
+				Authorize: msg.Authorize,
+				Err:       err,
+			}
+		}
+
+	case feed.ToggleTagFollowMsg:
+		return a, func() tea.Msg {
+			var (
+				tag app.Tag
+				err error
+			)
+			if msg.Follow {
+				tag, err = a.deps.Account.FollowTag(context.Background(), msg.Name)
+			} else {
+				tag, err = a.deps.Account.UnfollowTag(context.Background(), msg.Name)
+			}
+			return feed.TagFollowResultMsg{Name: msg.Name, Follow: msg.Follow, Tag: tag, Err: err}
+		}
+
+	case feed.RequestReportRulesMsg:
+		return a, func() tea.Msg {
+			if a.deps.Reports == nil {
+				return feed.ReportRulesLoadedMsg{Seq: msg.Seq}
+			}
+			rules, err := a.deps.Reports.Rules(context.Background())
+			return feed.ReportRulesLoadedMsg{Seq: msg.Seq, Rules: rules, Err: err}
+		}
+
+	case feed.SubmitReportMsg:
+		a.status = "Reporting @" + msg.Username + "..."
+		return a, func() tea.Msg {
+			err := errors.New("reporting is not available")
+			if a.deps.Reports != nil {
+				err = a.deps.Reports.Report(context.Background(), msg.Report)
+			}
+			return feed.ReportResultMsg{AccountID: msg.Report.AccountID, Username: msg.Username, Err: err}
+		}
+
+	case feed.ReportResultMsg:
+		a.feed, _ = a.feed.Update(msg)
+		if msg.Err != nil {
+			a.status = "Error reporting @" + msg.Username + ": " + msg.Err.Error()
+		} else {
+			a.status = "Reported @" + msg.Username + " to the moderators."
+		}
+		return a, nil
+
+	case feed.RequestScheduledPostsMsg:
+		return a, func() tea.Msg {
+			posts, next, err := a.deps.Schedule.ListScheduled(context.Background(), 40, msg.MaxID)
+			return feed.ScheduledPostsLoadedMsg{Seq: msg.Seq, Posts: posts, Next: next, Err: err}
+		}
+
+	case feed.RescheduleScheduledMsg:
+		return a, func() tea.Msg {
+			post, err := a.deps.Schedule.Reschedule(context.Background(), msg.ID, msg.At)
+			return feed.RescheduleResultMsg{Post: post, Err: err}
+		}
+
+	case feed.CancelScheduledMsg:
+		return a, func() tea.Msg {
+			err := a.deps.Schedule.CancelScheduled(context.Background(), msg.ID)
+			return feed.CancelScheduledResultMsg{ID: msg.ID, Err: err}
+		}
+
+	case scheduleResultMsg:
+		if msg.Err != nil {
+			a.status = "Schedule failed: " + msg.Err.Error() + draftKeptNote(msg.DraftID)
+			return a, nil
+		}
+		a.status = "⏰ Scheduled for " + common.FormatScheduleTime(msg.Post.ScheduledAt) + " (S to manage)"
+		return a, a.discardDraft(msg.DraftID)
+
+	case threadPartMsg:
+		return a.handleThreadPart(msg)
+
+	case threadDraftSavedMsg:
+		if msg.err != nil {
+			a.status = "Could not save unsent thread posts: " + msg.err.Error()
+		}
+		return a, nil
+
+	case feed.RequestDraftsMsg:
+		return a, func() tea.Msg {
+			if a.deps.Drafts == nil {
+				return feed.DraftsLoadedMsg{}
+			}
+			list, err := a.deps.Drafts.ListDrafts()
+			return feed.DraftsLoadedMsg{Drafts: list, Err: err}
+		}
+
+	case feed.DuplicateDraftMsg:
+		return a, func() tea.Msg {
+			d := msg.Draft
+			d.ID = ""
+			d.CreatedAt = time.Time{}
+			copied, err := a.deps.Drafts.SaveDraft(d)
+			return feed.DuplicateDraftResultMsg{Draft: copied, Err: err}
+		}
+
+	case feed.DiscardDraftMsg:
+		return a, func() tea.Msg {
+			err := a.deps.Drafts.DeleteDraft(msg.ID)
+			return feed.DiscardDraftResultMsg{ID: msg.ID, Err: err}
+		}
+
 	case feed.FeedPrefsChangedMsg:
 		if strings.TrimSpace(a.deps.StatePath) == "" {
 			return a, nil
@@ -303,14 +528,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.profileEditInline = true
 			a.compose = compose.NewInlineWithContent(
 				a.deps.Post,
-				a.deps.Hashtag,
+				"",
 				"profile-edit",
 				formatProfileDraft(msg.Profile),
 				true,
 				false,
 				"",
 				"",
-			)
+			).WithoutLimit().WithKeys(a.keys)
 			return a, a.compose.Init()
 		}
 		cmd, tmpPath, err := a.deps.Editor.Cmd(formatProfileDraft(msg.Profile), "")
@@ -363,6 +588,13 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return feed.DeleteResultMsg{ID: msg.ID, Err: err}
 		}
 
+	case feed.RedraftRantMsg:
+		a.status = "Preparing redraft..."
+		return a, a.redraft(msg)
+
+	case redraftReadyMsg:
+		return a.handleRedraftReady(msg)
+
 	case feed.DeleteResultMsg:
 		a.feed, _ = a.feed.Update(msg)
 		if msg.Err != nil {
@@ -407,14 +639,38 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.active = feedView
 		a.feed, _ = a.feed.Update(feed.ResetFeedStateMsg{ForceReset: false})
 		if msg.Err != nil {
-			a.status = "Error: " + msg.Err.Error()
+			a.status = "Error: " + msg.Err.Error() + draftKeptNote(msg.DraftID)
 			return a, nil
 		}
 
 		if msg.Content == "" {
-			a.status = "Cancelled."
+			a.status = "Cancelled." + draftKeptNote(msg.DraftID)
 			return a, nil
 		}
+		if len(msg.Thread) <= 1 {
+			if err := a.limits.CheckLength(msg.Content, domain.ParseHashtags(msg.Hashtag)); err != nil {
+				a.status = "Error: " + err.Error() + draftKeptNote(msg.DraftID)
+				return a, nil
+			}
+		}
+
+		// Scheduled rants are not in any timeline yet, so skip the optimistic
+		// items; they show up in the scheduled posts dialog instead.
+		if !msg.ScheduledAt.IsZero() {
+			parentID := ""
+			if msg.IsReply {
+				parentID = msg.ParentID
+			}
+			a.status = "Scheduling..."
+			return a, func() tea.Msg {
+				post, err := a.deps.Schedule.Schedule(context.Background(), msg.Content, msg.Hashtag, parentID, msg.ScheduledAt, msg.Options)
+				return scheduleResultMsg{Post: post, DraftID: msg.DraftID, Err: err}
+			}
+		}
+
+		if len(msg.Thread) > 1 {
+			return a.startThread(msg)
+		}
 
 		localReplyID := ""
 		var preCmd tea.Cmd
@@ -448,11 +704,11 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var rant domain.Rant
 			var err error
 			if msg.IsEdit {
-				rant, err = a.deps.Post.Edit(context.Background(), msg.RantID, msg.Content, a.deps.Hashtag)
+				rant, err = a.deps.Post.Edit(context.Background(), msg.RantID, msg.Content, msg.Hashtag)
 			} else if msg.IsReply {
-				rant, err = a.deps.Post.Reply(context.Background(), msg.ParentID, msg.Content, a.deps.Hashtag)
+				rant, err = a.deps.Post.Reply(context.Background(), msg.ParentID, msg.Content, msg.Hashtag, msg.Options)
 			} else {
-				rant, err = a.deps.Post.Post(context.Background(), msg.Content, a.deps.Hashtag)
+				rant, err = a.deps.Post.Post(context.Background(), msg.Content, msg.Hashtag, msg.Options)
 			}
 			// Mark as own since we just performed the action
 			rant.IsOwn = true
@@ -461,10 +717,11 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				resultID = localReplyID
 			}
 			return feed.ResultMsg{
-				ID:     resultID,
-				Rant:   rant,
-				IsEdit: msg.IsEdit,
-				Err:    err,
+				ID:      resultID,
+				Rant:    rant,
+				IsEdit:  msg.IsEdit,
+				Err:     err,
+				DraftID: msg.DraftID,
 			}
 		}
 		return a, tea.Batch(preCmd, postCmd)
@@ -473,19 +730,19 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.feed, _ = a.feed.Update(msg)
 		a.feed, _ = a.feed.Update(feed.ResetFeedStateMsg{ForceReset: false})
 		if msg.Err != nil {
-			a.status = "Error: " + msg.Err.Error()
+			a.status = "Error: " + msg.Err.Error() + draftKeptNote(msg.DraftID)
+			return a, nil
+		}
+		if msg.IsEdit {
+			a.status = "🔥 Rant updated!"
 		} else {
-			if msg.IsEdit {
-				a.status = "🔥 Rant updated!"
-			} else {
-				a.status = "🔥 Rant posted!"
-				// Only auto-open detail for new top-level posts.
-				if msg.Rant.InReplyToID == "" || msg.Rant.InReplyToID == "<nil>" || msg.Rant.InReplyToID == "0" {
-					a.feed, _ = a.feed.Update(feed.OpenDetailWithoutRepliesMsg{ID: msg.Rant.ID})
-				}
+			a.status = "🔥 Rant posted!"
+			// Only auto-open detail for new top-level posts.
+			if !isReplyTo(msg.Rant.InReplyToID) {
+				a.feed, _ = a.feed.Update(feed.OpenDetailWithoutRepliesMsg{ID: msg.Rant.ID})
 			}
 		}
-		return a, nil
+		return a, a.discardDraft(msg.DraftID)
 	}
 
 	// Delegate to the active sub-model.
@@ -503,6 +760,70 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// postTags lists the hashtags the tag policy adds to a post, in the form
+// app.PostService takes. parentContent is the text of the rant being
+// replied to, if any.
+func (a App) postTags(parentContent string) string {
+	return strings.Join(a.deps.Tags.TagsFor(a.feed.ActiveHashtag(), parentContent), " ")
+}
+
+// rantComposer applies the instance limits to a rant composer and turns on
+// autocomplete and draft autosave.
+func (a App) rantComposer(m compose.Model) compose.Model {
+	m = m.WithLimits(a.limits).WithCompletion(a.deps.Search, a.feed.Rants()).WithKeys(a.keys)
+	if a.deps.Drafts == nil {
+		return m
+	}
+	return m.WithDrafts(a.deps.Drafts)
+}
+
+// composeForDraft builds a composer with the reply or edit context of d.
+func (a App) composeForDraft(d app.Draft, useInline bool) compose.Model {
+	tags := a.draftTags(d)
+	switch {
+	case d.IsEdit() && useInline:
+		return compose.NewInlineWithContent(a.deps.Post, tags, d.RantID, "", true, false, "", "")
+	case d.IsEdit():
+		return compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, tags, d.RantID, "", true, false, "", "")
+	case d.IsReply() && useInline:
+		return compose.NewInlineWithContent(a.deps.Post, tags, d.ParentID, "", false, true, d.ParentAuthor, d.ParentSummary)
+	case d.IsReply():
+		return compose.NewEditorWithContent(a.deps.Post, a.deps.Editor, tags, d.ParentID, "", false, true, d.ParentAuthor, d.ParentSummary)
+	case useInline:
+		return compose.NewInline(a.deps.Post, tags)
+	default:
+		return compose.NewEditor(a.deps.Post, a.deps.Editor, tags)
+	}
+}
+
+// draftTags returns the tags d was composed with. Drafts saved before tags
+// were stored fall back to the current policy without the parent's tags,
+// since the stored summary may have cut them off.
+func (a App) draftTags(d app.Draft) string {
+	if d.Hashtags != "" {
+		return d.Hashtags
+	}
+	return a.postTags("")
+}
+
+// discardDraft removes a draft once its content has been published.
+func (a App) discardDraft(id string) tea.Cmd {
+	if id == "" || a.deps.Drafts == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = a.deps.Drafts.DeleteDraft(id)
+		return nil
+	}
+}
+
+func draftKeptNote(draftID string) string {
+	if draftID == "" {
+		return ""
+	}
+	return " • draft saved (D to open drafts)"
+}
+
 func (a App) loadProfileForEdit(useInline bool) tea.Cmd {
 	return func() tea.Msg {
 		profile, err := a.deps.Account.CurrentProfile(context.Background())
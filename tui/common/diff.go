@@ -0,0 +1,119 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DiffKind says whether a diff piece is unchanged, added, or removed.
+type DiffKind int
+
+const (
+	DiffEqual DiffKind = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffOp is one piece of a diff between two texts.
+type DiffOp struct {
+	Kind DiffKind
+	Text string
+}
+
+// WordDiff compares two texts word by word. Whitespace is kept with the word
+// it follows, so joining the Text of the equal and deleted pieces gives back
+// before, and the equal and inserted pieces give back after.
+func WordDiff(before, after string) []DiffOp {
+	return diffTokens(splitWords(before), splitWords(after))
+}
+
+// LineDiff compares two texts line by line. A missing newline at the end of
+// either text is ignored, so every line in the pieces ends in a newline.
+func LineDiff(before, after string) []DiffOp {
+	return diffTokens(splitLines(before), splitLines(after))
+}
+
+// diffTokens computes a shortest edit script from the longest common
+// subsequence of a and b, merging neighbouring pieces of the same kind.
+// Deletions come before insertions where both apply.
+func diffTokens(a, b []string) []DiffOp {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []DiffOp
+	add := func(kind DiffKind, text string) {
+		if n := len(ops); n > 0 && ops[n-1].Kind == kind {
+			ops[n-1].Text += text
+			return
+		}
+		ops = append(ops, DiffOp{Kind: kind, Text: text})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(DiffInsert, b[j])
+	}
+	return ops
+}
+
+// splitWords splits s into words, each carrying the whitespace after it.
+// Leading whitespace becomes a token of its own.
+func splitWords(s string) []string {
+	var (
+		out   []string
+		start int
+		inWS  = true
+	)
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if !space && inWS && i > start {
+			out = append(out, s[start:i])
+			start = i
+		}
+		inWS = space
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] += "\n"
+	}
+	return lines
+}
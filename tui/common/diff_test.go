@@ -0,0 +1,54 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	got := WordDiff("the quick fox jumps", "the slow fox jumps high")
+	want := []DiffOp{
+		{DiffEqual, "the "},
+		{DiffDelete, "quick "},
+		{DiffInsert, "slow "},
+		{DiffEqual, "fox "},
+		{DiffDelete, "jumps"},
+		{DiffInsert, "jumps high"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected word diff:\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestWordDiff_RebuildsBothSides(t *testing.T) {
+	before, after := "  keep this\nline, drop that", "keep this\nline and add more  "
+	var gotBefore, gotAfter string
+	for _, op := range WordDiff(before, after) {
+		if op.Kind != DiffInsert {
+			gotBefore += op.Text
+		}
+		if op.Kind != DiffDelete {
+			gotAfter += op.Text
+		}
+	}
+	if gotBefore != before || gotAfter != after {
+		t.Fatalf("diff does not rebuild inputs: %q / %q", gotBefore, gotAfter)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := LineDiff("a\nb\nc", "a\nB\nc\nd\n")
+	want := []DiffOp{
+		{DiffEqual, "a\n"},
+		{DiffDelete, "b\n"},
+		{DiffInsert, "B\n"},
+		{DiffEqual, "c\n"},
+		{DiffInsert, "d\n"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected line diff:\n got %#v\nwant %#v", got, want)
+	}
+	if ops := LineDiff("", ""); len(ops) != 0 {
+		t.Fatalf("expected no ops for empty texts, got %#v", ops)
+	}
+}
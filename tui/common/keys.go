@@ -1,37 +1,90 @@
 package common
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines shared key bindings across all views.
 type KeyMap struct {
-	Quit           key.Binding
-	ForceQuit      key.Binding // ctrl+c — force quit from any view
-	ToggleHints    key.Binding // ? — toggle hidden key hints
-	Refresh        key.Binding
-	LoadMore       key.Binding // disabled (legacy key)
-	BlockUser      key.Binding // b — block selected user
-	FollowUser     key.Binding // f — follow/unfollow selected user
-	ManageBlocks   key.Binding // B — manage blocked users
-	HidePost       key.Binding // x — hide selected post locally
-	ShowHidden     key.Binding // X — toggle hidden posts visibility
-	EditProfile    key.Binding // v — edit current profile
-	OpenProfile    key.Binding // z — open selected user profile
-	OpenOwnProfile key.Binding // Z — open current user's profile
-	SwitchFeed     key.Binding // t — switch feed source
-	SetHashtag     key.Binding // H — change hashtag
-	NewEditor      key.Binding // p — compose via $EDITOR
-	NewInline      key.Binding // P — compose via inline textarea
-	Edit           key.Binding // e — fast edit own post (buffer)
-	EditInline     key.Binding // E — fast edit own post (inline)
-	Delete         key.Binding // d — fast delete own post
-	Like           key.Binding // l — like/favorite
-	Reply          key.Binding // r — reply via $EDITOR
-	ReplyInline    key.Binding // ctrl+r — reply inline
-	Up             key.Binding
-	Down           key.Binding
-	Open           key.Binding // o — open in browser
-	GitHub         key.Binding // g — open creator GitHub profile
-	Home           key.Binding // h — back to top of home feed
+	Quit            key.Binding
+	ForceQuit       key.Binding // ctrl+c — force quit from any view
+	ToggleHints     key.Binding // ? — toggle hidden key hints
+	Refresh         key.Binding
+	LoadMore        key.Binding // disabled (legacy key)
+	BlockUser       key.Binding // b — block selected user
+	BlockDomain     key.Binding // K — block selected user's domain
+	FollowUser      key.Binding // f — follow/unfollow selected user
+	ManageBlocks    key.Binding // B — manage blocked users
+	ManageScheduled key.Binding // S — manage scheduled posts
+	ManageDrafts    key.Binding // D — manage saved drafts
+	HidePost        key.Binding // x — hide selected post locally
+	ShowHidden      key.Binding // X — toggle hidden posts visibility
+	EditProfile     key.Binding // v — edit current profile
+	OpenProfile     key.Binding // z — open selected user profile
+	OpenOwnProfile  key.Binding // Z — open current user's profile
+	SwitchFeed      key.Binding // t — switch feed source
+	SetHashtag      key.Binding // H — change hashtag
+	BrowseInstance  key.Binding // O — browse another instance read-only
+	OpenLink        key.Binding // U — open a post or profile by URL
+	NewEditor       key.Binding // p — compose via $EDITOR
+	NewInline       key.Binding // P — compose via inline textarea
+	Edit            key.Binding // e — fast edit own post (buffer)
+	EditInline      key.Binding // E — fast edit own post (inline)
+	Delete          key.Binding // d — fast delete own post
+	Redraft         key.Binding // w — delete own post and redraft it via $EDITOR
+	RedraftInline   key.Binding // W — delete own post and redraft it inline
+	Like            key.Binding // l — like/favorite
+	Reply           key.Binding // r — reply via $EDITOR
+	ReplyInline     key.Binding // ctrl+r — reply inline
+	Up              key.Binding
+	Down            key.Binding
+	Open            key.Binding // o — open in browser
+	History         key.Binding // R — edit history of selected post
+	Interactions    key.Binding // L — who liked/boosted selected post
+	FollowRequests  key.Binding // F — pending follow requests (own profile)
+	FollowedTags    key.Binding // # — followed hashtags; in detail, pick a post hashtag
+	Trends          key.Binding // N — trending hashtags and links
+	Notifications   key.Binding // A — notifications
+	Report          key.Binding // ! — report selected post's author
+	GitHub          key.Binding // g — open creator GitHub profile
+	Home            key.Binding // h — back to top of home feed
+
+	// Keys of views and dialogs.
+	Back              key.Binding // esc — close a view or dialog (quit also backs out)
+	Select            key.Binding // enter — open or pick the selected item
+	Yes               key.Binding // y — confirm
+	No                key.Binding // n — decline
+	Left              key.Binding // left — pan left
+	Right             key.Binding // right — pan right
+	NextTab           key.Binding // tab — next list of a profile or dialog
+	PrevTab           key.Binding // shift+tab — previous list
+	PickPrev          key.Binding // left/h — previous choice in a picker
+	PickNext          key.Binding // right/l — next choice in a picker
+	PrevFeed          key.Binding // T — switch feed source backwards
+	EditProfileInline key.Binding // V — edit current profile inline
+	ToggleMedia       key.Binding // i — toggle media previews
+	OpenMedia         key.Binding // I — open media in browser
+	ParentPost        key.Binding // u — open parent post in detail
+	Unblock           key.Binding // u — unblock in blocked users
+	FollowTag         key.Binding // f — follow/unfollow a hashtag
+	Accept            key.Binding // a — accept follow request
+	Reject            key.Binding // r — reject follow request
+	Reschedule        key.Binding // r — reschedule a scheduled post
+	Duplicate         key.Binding // c — duplicate a draft
+	DiffMode          key.Binding // w — word or line diff in edit history
+	Check             key.Binding // space/x — tick a report row
+	Comment           key.Binding // c — write a report comment
+	Forward           key.Binding // f — forward a report to the remote server
+	Submit            key.Binding // enter — apply a text prompt
+	Cancel            key.Binding // esc — leave a text prompt
+
+	// Keys of the inline composer.
+	Send     key.Binding // ctrl+d — post, or schedule when a time is set
+	Schedule key.Binding // ctrl+t — open the schedule field
+	Thread   key.Binding // ctrl+s — toggle thread mode
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -60,6 +113,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("b"),
 			key.WithHelp("b", "block user"),
 		),
+		BlockDomain: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "block domain"),
+		),
 		FollowUser: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "follow/unfollow"),
@@ -68,6 +125,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("B"),
 			key.WithHelp("B", "blocked users"),
 		),
+		ManageScheduled: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "scheduled posts"),
+		),
+		ManageDrafts: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "drafts"),
+		),
 		HidePost: key.NewBinding(
 			key.WithKeys("x"),
 			key.WithHelp("x", "hide post"),
@@ -96,6 +161,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("H"),
 			key.WithHelp("H", "set hashtag"),
 		),
+		BrowseInstance: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "browse instance"),
+		),
+		OpenLink: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "open URL"),
+		),
 		NewEditor: key.NewBinding(
 			key.WithKeys("p"),
 			key.WithHelp("p", "rant ($EDITOR)"),
@@ -128,6 +201,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete"),
 		),
+		Redraft: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "delete & redraft ($EDITOR)"),
+		),
+		RedraftInline: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "delete & redraft (inline)"),
+		),
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
 			key.WithHelp("↑/k", "up"),
@@ -140,6 +221,34 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "open"),
 		),
+		History: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "edit history"),
+		),
+		Interactions: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "liked/boosted by"),
+		),
+		FollowRequests: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "follow requests"),
+		),
+		FollowedTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "followed tags"),
+		),
+		Trends: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "trends"),
+		),
+		Notifications: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "notifications"),
+		),
+		Report: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "report"),
+		),
 		GitHub: key.NewBinding(
 			key.WithKeys("g"),
 			key.WithHelp("g", "creator github"),
@@ -148,5 +257,258 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("h"),
 			key.WithHelp("h", "home"),
 		),
+		Back:              binding("back", "esc"),
+		Select:            binding("open", "enter"),
+		Yes:               binding("yes", "y", "Y"),
+		No:                binding("no", "n"),
+		Left:              binding("pan left", "left"),
+		Right:             binding("pan right", "right"),
+		NextTab:           binding("next tab", "tab"),
+		PrevTab:           binding("previous tab", "shift+tab"),
+		PickPrev:          binding("previous choice", "left", "h", "shift+tab"),
+		PickNext:          binding("next choice", "right", "l", "tab"),
+		PrevFeed:          binding("previous feed", "T"),
+		EditProfileInline: binding("edit profile (inline)", "V"),
+		ToggleMedia:       binding("toggle media previews", "i"),
+		OpenMedia:         binding("open media", "I"),
+		ParentPost:        binding("open parent post", "u"),
+		Unblock:           binding("unblock", "u"),
+		FollowTag:         binding("follow/unfollow tag", "f", "u"),
+		Accept:            binding("accept", "a"),
+		Reject:            binding("reject", "r"),
+		Reschedule:        binding("reschedule", "r"),
+		Duplicate:         binding("duplicate", "c"),
+		DiffMode:          binding("word/line diff", "w"),
+		Check:             binding("tick", " ", "x"),
+		Comment:           binding("comment", "c"),
+		Forward:           binding("forward", "f"),
+		Submit:            binding("apply", "enter"),
+		Cancel:            binding("cancel", "esc"),
+		Send:              binding("post", "ctrl+d"),
+		Schedule:          binding("schedule", "ctrl+t"),
+		Thread:            binding("thread", "ctrl+s"),
+	}
+}
+
+// binding makes a binding whose help lists its keys.
+func binding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), desc))
+}
+
+// helpKeys joins keys for help text, spelling out the space bar.
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+	return strings.Join(names, "/")
+}
+
+// keyActions names the remappable bindings as they are written in the
+// [keys] table of the config file.
+var keyActions = []struct {
+	name    string
+	binding func(*KeyMap) *key.Binding
+}{
+	{"quit", func(k *KeyMap) *key.Binding { return &k.Quit }},
+	{"force_quit", func(k *KeyMap) *key.Binding { return &k.ForceQuit }},
+	{"toggle_hints", func(k *KeyMap) *key.Binding { return &k.ToggleHints }},
+	{"refresh", func(k *KeyMap) *key.Binding { return &k.Refresh }},
+	{"block_user", func(k *KeyMap) *key.Binding { return &k.BlockUser }},
+	{"block_domain", func(k *KeyMap) *key.Binding { return &k.BlockDomain }},
+	{"follow_user", func(k *KeyMap) *key.Binding { return &k.FollowUser }},
+	{"manage_blocks", func(k *KeyMap) *key.Binding { return &k.ManageBlocks }},
+	{"manage_scheduled", func(k *KeyMap) *key.Binding { return &k.ManageScheduled }},
+	{"manage_drafts", func(k *KeyMap) *key.Binding { return &k.ManageDrafts }},
+	{"hide_post", func(k *KeyMap) *key.Binding { return &k.HidePost }},
+	{"show_hidden", func(k *KeyMap) *key.Binding { return &k.ShowHidden }},
+	{"edit_profile", func(k *KeyMap) *key.Binding { return &k.EditProfile }},
+	{"open_profile", func(k *KeyMap) *key.Binding { return &k.OpenProfile }},
+	{"open_own_profile", func(k *KeyMap) *key.Binding { return &k.OpenOwnProfile }},
+	{"switch_feed", func(k *KeyMap) *key.Binding { return &k.SwitchFeed }},
+	{"set_hashtag", func(k *KeyMap) *key.Binding { return &k.SetHashtag }},
+	{"browse_instance", func(k *KeyMap) *key.Binding { return &k.BrowseInstance }},
+	{"open_link", func(k *KeyMap) *key.Binding { return &k.OpenLink }},
+	{"new_editor", func(k *KeyMap) *key.Binding { return &k.NewEditor }},
+	{"new_inline", func(k *KeyMap) *key.Binding { return &k.NewInline }},
+	{"edit", func(k *KeyMap) *key.Binding { return &k.Edit }},
+	{"edit_inline", func(k *KeyMap) *key.Binding { return &k.EditInline }},
+	{"delete", func(k *KeyMap) *key.Binding { return &k.Delete }},
+	{"redraft", func(k *KeyMap) *key.Binding { return &k.Redraft }},
+	{"redraft_inline", func(k *KeyMap) *key.Binding { return &k.RedraftInline }},
+	{"like", func(k *KeyMap) *key.Binding { return &k.Like }},
+	{"reply", func(k *KeyMap) *key.Binding { return &k.Reply }},
+	{"reply_inline", func(k *KeyMap) *key.Binding { return &k.ReplyInline }},
+	{"up", func(k *KeyMap) *key.Binding { return &k.Up }},
+	{"down", func(k *KeyMap) *key.Binding { return &k.Down }},
+	{"open", func(k *KeyMap) *key.Binding { return &k.Open }},
+	{"history", func(k *KeyMap) *key.Binding { return &k.History }},
+	{"interactions", func(k *KeyMap) *key.Binding { return &k.Interactions }},
+	{"follow_requests", func(k *KeyMap) *key.Binding { return &k.FollowRequests }},
+	{"followed_tags", func(k *KeyMap) *key.Binding { return &k.FollowedTags }},
+	{"trends", func(k *KeyMap) *key.Binding { return &k.Trends }},
+	{"notifications", func(k *KeyMap) *key.Binding { return &k.Notifications }},
+	{"report", func(k *KeyMap) *key.Binding { return &k.Report }},
+	{"github", func(k *KeyMap) *key.Binding { return &k.GitHub }},
+	{"home", func(k *KeyMap) *key.Binding { return &k.Home }},
+	{"back", func(k *KeyMap) *key.Binding { return &k.Back }},
+	{"select", func(k *KeyMap) *key.Binding { return &k.Select }},
+	{"yes", func(k *KeyMap) *key.Binding { return &k.Yes }},
+	{"no", func(k *KeyMap) *key.Binding { return &k.No }},
+	{"left", func(k *KeyMap) *key.Binding { return &k.Left }},
+	{"right", func(k *KeyMap) *key.Binding { return &k.Right }},
+	{"next_tab", func(k *KeyMap) *key.Binding { return &k.NextTab }},
+	{"prev_tab", func(k *KeyMap) *key.Binding { return &k.PrevTab }},
+	{"pick_prev", func(k *KeyMap) *key.Binding { return &k.PickPrev }},
+	{"pick_next", func(k *KeyMap) *key.Binding { return &k.PickNext }},
+	{"prev_feed", func(k *KeyMap) *key.Binding { return &k.PrevFeed }},
+	{"edit_profile_inline", func(k *KeyMap) *key.Binding { return &k.EditProfileInline }},
+	{"toggle_media", func(k *KeyMap) *key.Binding { return &k.ToggleMedia }},
+	{"open_media", func(k *KeyMap) *key.Binding { return &k.OpenMedia }},
+	{"parent_post", func(k *KeyMap) *key.Binding { return &k.ParentPost }},
+	{"unblock", func(k *KeyMap) *key.Binding { return &k.Unblock }},
+	{"follow_tag", func(k *KeyMap) *key.Binding { return &k.FollowTag }},
+	{"accept", func(k *KeyMap) *key.Binding { return &k.Accept }},
+	{"reject", func(k *KeyMap) *key.Binding { return &k.Reject }},
+	{"reschedule", func(k *KeyMap) *key.Binding { return &k.Reschedule }},
+	{"duplicate", func(k *KeyMap) *key.Binding { return &k.Duplicate }},
+	{"diff_mode", func(k *KeyMap) *key.Binding { return &k.DiffMode }},
+	{"check", func(k *KeyMap) *key.Binding { return &k.Check }},
+	{"comment", func(k *KeyMap) *key.Binding { return &k.Comment }},
+	{"forward", func(k *KeyMap) *key.Binding { return &k.Forward }},
+	{"submit", func(k *KeyMap) *key.Binding { return &k.Submit }},
+	{"cancel", func(k *KeyMap) *key.Binding { return &k.Cancel }},
+	{"send", func(k *KeyMap) *key.Binding { return &k.Send }},
+	{"schedule", func(k *KeyMap) *key.Binding { return &k.Schedule }},
+	{"thread", func(k *KeyMap) *key.Binding { return &k.Thread }},
+}
+
+// Remap returns k with the keys of the named actions replaced, keeping their
+// help descriptions. Unknown action names are an error.
+func (k KeyMap) Remap(overrides map[string][]string) (KeyMap, error) {
+	for name, keys := range overrides {
+		b := k.action(name)
+		if b == nil {
+			return KeyMap{}, fmt.Errorf("unknown key action %q", name)
+		}
+		*b = binding(b.Help().Desc, keys...)
+	}
+	return k, nil
+}
+
+// action returns the binding named in the config file, or nil.
+func (k *KeyMap) action(name string) *key.Binding {
+	for _, a := range keyActions {
+		if a.name == name {
+			return a.binding(k)
+		}
+	}
+	return nil
+}
+
+// keyScopes groups the actions that are active in the same view or dialog;
+// a key may only be bound to one of them. Force quit works everywhere.
+var keyScopes = []struct {
+	name    string
+	actions []string
+}{
+	{"feed", []string{
+		"quit", "toggle_hints", "refresh", "block_user", "block_domain", "follow_user",
+		"manage_blocks", "manage_scheduled", "manage_drafts", "hide_post", "show_hidden",
+		"edit_profile", "edit_profile_inline", "open_profile", "open_own_profile",
+		"switch_feed", "prev_feed", "set_hashtag", "browse_instance", "open_link",
+		"new_editor", "new_inline", "edit", "edit_inline", "delete", "redraft",
+		"redraft_inline", "like", "reply", "reply_inline", "up", "down", "left", "right",
+		"open", "history", "interactions", "follow_requests", "followed_tags", "trends",
+		"notifications", "report", "github", "home", "toggle_media", "open_media", "parent_post", "back",
+		"select", "yes", "no",
+	}},
+	{"profile", []string{
+		"quit", "toggle_hints", "back", "left", "right", "home", "edit_profile",
+		"edit_profile_inline", "set_hashtag", "up", "down", "like", "follow_user",
+		"open_profile", "manage_blocks", "follow_requests", "manage_scheduled",
+		"manage_drafts", "toggle_media", "open_media", "open", "select", "yes", "no",
+		"next_tab", "prev_tab",
+	}},
+	{"blocked users", []string{"quit", "back", "up", "down", "unblock", "yes", "no"}},
+	{"drafts", []string{"quit", "back", "up", "down", "select", "new_editor", "new_inline", "duplicate", "delete", "yes", "no"}},
+	{"scheduled posts", []string{"quit", "back", "up", "down", "reschedule", "delete", "yes", "no"}},
+	{"edit history", []string{"quit", "back", "history", "up", "down", "diff_mode"}},
+	{"interactions", []string{"quit", "back", "up", "down", "next_tab", "select", "open_profile", "follow_user", "yes"}},
+	{"follow requests", []string{"quit", "back", "follow_requests", "up", "down", "accept", "reject", "select", "open_profile", "follow_user", "yes"}},
+	{"followed hashtags", []string{"quit", "back", "followed_tags", "up", "down", "follow_tag", "select"}},
+	{"hashtag picker", []string{"quit", "back", "followed_tags", "pick_prev", "pick_next", "follow_tag", "select"}},
+	{"notifications", []string{"quit", "back", "notifications", "up", "down", "select", "open_profile"}},
+	{"trends", []string{"quit", "back", "trends", "up", "down", "next_tab", "prev_tab", "select", "open", "refresh"}},
+	{"report", []string{"quit", "back", "up", "down", "pick_prev", "pick_next", "check", "comment", "forward", "select"}},
+	{"text prompts", []string{"submit", "cancel"}},
+	{"inline composer", []string{"send", "schedule", "thread", "cancel", "submit", "next_tab"}},
+	{"suggestion popup", []string{"send", "up", "down", "select", "next_tab", "cancel"}},
+}
+
+// Conflicts lists keys bound to more than one action in the same view, e.g.
+// `"L" is bound to both like and interactions (feed, profile)`.
+func (k KeyMap) Conflicts() []string {
+	var order []string
+	scopes := map[string][]string{}
+	for _, scope := range keyScopes {
+		owner := map[string]string{}
+		for _, name := range append([]string{"force_quit"}, scope.actions...) {
+			for _, kk := range k.action(name).Keys() {
+				prev, taken := owner[kk]
+				if !taken || prev == name {
+					owner[kk] = name
+					continue
+				}
+				clash := fmt.Sprintf("%q is bound to both %s and %s", kk, prev, name)
+				if _, seen := scopes[clash]; !seen {
+					order = append(order, clash)
+				}
+				scopes[clash] = append(scopes[clash], scope.name)
+			}
+		}
+	}
+	out := make([]string, len(order))
+	for i, clash := range order {
+		out[i] = clash + " (" + strings.Join(scopes[clash], ", ") + ")"
+	}
+	return out
+}
+
+// keyPresets are alternative layouts, applied before the user's own keys.
+var keyPresets = map[string]map[string][]string{
+	// vim: g goes to the top, which frees h to pan left.
+	"vim": {
+		"home":   {"g", "home"},
+		"github": {"ctrl+g"},
+		"left":   {"left", "h"},
+	},
+	// emacs: ctrl+p/n/b/f move and ctrl+g backs out.
+	"emacs": {
+		"up":        {"up", "k", "ctrl+p"},
+		"down":      {"down", "j", "ctrl+n"},
+		"left":      {"left", "ctrl+b"},
+		"right":     {"right", "ctrl+f"},
+		"pick_prev": {"left", "h", "shift+tab", "ctrl+b"},
+		"pick_next": {"right", "l", "tab", "ctrl+f"},
+		"back":      {"esc", "ctrl+g"},
+		"cancel":    {"esc", "ctrl+g"},
+		"home":      {"h", "home", "alt+<"},
+	},
+}
+
+// PresetKeyMap returns the bindings of a preset: "default" (or empty),
+// "vim" or "emacs".
+func PresetKeyMap(name string) (KeyMap, error) {
+	if name == "" || name == "default" {
+		return DefaultKeyMap(), nil
+	}
+	preset, ok := keyPresets[name]
+	if !ok {
+		return KeyMap{}, fmt.Errorf("unknown key preset %q (want default, vim or emacs)", name)
 	}
+	return DefaultKeyMap().Remap(preset)
 }
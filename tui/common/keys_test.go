@@ -14,3 +14,63 @@ func TestDefaultKeyMap_HasCriticalBindings(t *testing.T) {
 		t.Fatalf("legacy load more should be hidden/disabled")
 	}
 }
+
+func TestKeyMap_Remap(t *testing.T) {
+	km, err := DefaultKeyMap().Remap(map[string][]string{"like": {"L", "ctrl+l"}})
+	if err != nil {
+		t.Fatalf("remap failed: %v", err)
+	}
+	if keys := km.Like.Keys(); len(keys) != 2 || keys[0] != "L" || km.Like.Help().Key != "L/ctrl+l" || km.Like.Help().Desc != "like" {
+		t.Fatalf("unexpected like binding: %v %#v", keys, km.Like.Help())
+	}
+	if km.Quit.Keys()[0] != "q" {
+		t.Fatalf("expected other bindings to keep their keys")
+	}
+	if _, err := DefaultKeyMap().Remap(map[string][]string{"jump": {"J"}}); err == nil {
+		t.Fatalf("expected unknown action to be refused")
+	}
+}
+
+func TestKeyMap_PresetsHaveNoConflicts(t *testing.T) {
+	for _, name := range []string{"default", "vim", "emacs"} {
+		km, err := PresetKeyMap(name)
+		if err != nil {
+			t.Fatalf("preset %s: %v", name, err)
+		}
+		if c := km.Conflicts(); len(c) != 0 {
+			t.Fatalf("preset %s has conflicts: %v", name, c)
+		}
+	}
+	vim, _ := PresetKeyMap("vim")
+	if vim.Home.Keys()[0] != "g" || vim.Left.Help().Key != "left/h" {
+		t.Fatalf("unexpected vim bindings: %v %v", vim.Home.Keys(), vim.Left.Help())
+	}
+	if _, err := PresetKeyMap("nano"); err == nil {
+		t.Fatalf("expected unknown preset to be refused")
+	}
+}
+
+func TestKeyMap_ConflictsAreScopedToViews(t *testing.T) {
+	// u opens the parent post in the feed and unblocks in the blocked list;
+	// that is not a conflict.
+	km, _ := DefaultKeyMap().Remap(map[string][]string{"like": {"L"}})
+	c := km.Conflicts()
+	if len(c) != 1 || c[0] != `"L" is bound to both like and interactions (feed)` {
+		t.Fatalf("unexpected conflicts: %v", c)
+	}
+}
+
+func TestKeyMap_EveryActionHasAScope(t *testing.T) {
+	// Force quit works everywhere and is checked against every scope.
+	scoped := map[string]bool{"force_quit": true}
+	for _, scope := range keyScopes {
+		for _, name := range scope.actions {
+			scoped[name] = true
+		}
+	}
+	for _, a := range keyActions {
+		if !scoped[a.name] {
+			t.Errorf("action %s belongs to no key scope, so its conflicts go unchecked", a.name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinScheduleLead is how far ahead Mastodon requires scheduled posts to be.
+const MinScheduleLead = 5 * time.Minute
+
+var (
+	clockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	weekdayByName  = map[string]time.Weekday{}
+	absoluteLayout = []string{
+		time.RFC3339,
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+)
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		weekdayByName[name] = d
+		weekdayByName[name[:3]] = d
+	}
+}
+
+// ParseScheduleTime understands the times accepted by the composer:
+//
+//	RFC3339 / "2006-01-02 15:04"    absolute (local time unless an offset is given)
+//	"in 2h", "in 1h30m", "+45m"     relative to now
+//	"9am", "21:30", "noon"          next occurrence of that clock time
+//	"tomorrow 9am", "friday 18:00"  day word plus optional clock time
+//
+// The result must be at least MinScheduleLead after now.
+func ParseScheduleTime(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return time.Time{}, errors.New("empty schedule time")
+	}
+	t, err := parseScheduleExpr(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Before(now.Add(MinScheduleLead)) {
+		return time.Time{}, fmt.Errorf("scheduled time must be at least %d minutes from now", int(MinScheduleLead.Minutes()))
+	}
+	return t, nil
+}
+
+func parseScheduleExpr(s string, now time.Time) (time.Time, error) {
+	for _, layout := range absoluteLayout {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), now.Location()); err == nil {
+			if layout == "2006-01-02" {
+				t = t.Add(9 * time.Hour)
+			}
+			return t, nil
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(s, "in "); ok {
+		return parseRelative(rest, now)
+	}
+	if rest, ok := strings.CutPrefix(s, "+"); ok {
+		return parseRelative(rest, now)
+	}
+
+	day, clock, _ := strings.Cut(s, " ")
+	clock = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(clock), "at "))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch day {
+	case "today":
+		return atClock(today, clock, "")
+	case "tonight":
+		return atClock(today, clock, "8pm")
+	case "tomorrow":
+		return atClock(today.AddDate(0, 0, 1), clock, "9am")
+	}
+	if wd, ok := weekdayByName[day]; ok {
+		delta := (int(wd) - int(now.Weekday()) + 7) % 7
+		t, err := atClock(today.AddDate(0, 0, delta), clock, "9am")
+		if err == nil && !t.After(now) {
+			t = t.AddDate(0, 0, 7)
+		}
+		return t, err
+	}
+
+	// A bare clock time means its next occurrence.
+	t, err := atClock(today, s, "")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognised time %q (try \"tomorrow 9am\", \"in 2h\" or RFC3339)", s)
+	}
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func parseRelative(s string, now time.Time) (time.Time, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	s = strings.NewReplacer("days", "d", "day", "d", "hours", "h", "hour", "h", "mins", "m", "min", "m").Replace(s)
+	days := 0
+	if before, after, ok := strings.Cut(s, "d"); ok {
+		n, err := strconv.Atoi(before)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q", s)
+		}
+		days, s = n, after
+	}
+	var d time.Duration
+	if s != "" {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+	return now.AddDate(0, 0, days).Add(d), nil
+}
+
+// atClock sets a clock time on day. An empty clock uses fallback; when both
+// are empty the clock is required.
+func atClock(day time.Time, clock, fallback string) (time.Time, error) {
+	if clock == "" {
+		clock = fallback
+	}
+	switch clock {
+	case "":
+		return time.Time{}, errors.New("missing time of day")
+	case "noon":
+		clock = "12pm"
+	case "midnight":
+		clock = "12am"
+	}
+	m := clockPattern.FindStringSubmatch(clock)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+		}
+		hour %= 12
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+		}
+		hour = hour%12 + 12
+	}
+	if hour > 23 || minute > 59 {
+		return time.Time{}, fmt.Errorf("invalid time of day %q", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), nil
+}
+
+// FormatScheduleTime renders a scheduled time for status lines and dialogs.
+func FormatScheduleTime(t time.Time) string {
+	return t.Local().Format("Mon Jan 02 15:04")
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	loc := time.FixedZone("test", 2*3600)
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, loc) // Wednesday
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{in: "tomorrow 9am", want: time.Date(2026, 3, 5, 9, 0, 0, 0, loc)},
+		{in: "Tomorrow at 18:30", want: time.Date(2026, 3, 5, 18, 30, 0, 0, loc)},
+		{in: "tomorrow", want: time.Date(2026, 3, 5, 9, 0, 0, 0, loc)},
+		{in: "today 5pm", want: time.Date(2026, 3, 4, 17, 0, 0, 0, loc)},
+		{in: "9am", want: time.Date(2026, 3, 5, 9, 0, 0, 0, loc)},
+		{in: "noon", want: time.Date(2026, 3, 4, 12, 0, 0, 0, loc)},
+		{in: "friday 8pm", want: time.Date(2026, 3, 6, 20, 0, 0, 0, loc)},
+		{in: "wed 9am", want: time.Date(2026, 3, 11, 9, 0, 0, 0, loc)},
+		{in: "in 2h", want: now.Add(2 * time.Hour)},
+		{in: "in 1 day", want: now.AddDate(0, 0, 1)},
+		{in: "+1h30m", want: now.Add(90 * time.Minute)},
+		{in: "2026-03-05T08:00:00Z", want: time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)},
+		{in: "2026-03-05 08:00", want: time.Date(2026, 3, 5, 8, 0, 0, 0, loc)},
+	}
+	for _, tc := range tests {
+		got, err := ParseScheduleTime(tc.in, now)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.in, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("%q: got %v want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseScheduleTime_Rejects(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	for _, in := range []string{"", "whenever", "in 2m", "today 9am", "13pm", "2020-01-01T00:00:00Z", "tomorrow 25:00"} {
+		if _, err := ParseScheduleTime(in, now); err == nil {
+			t.Fatalf("%q: expected error", in)
+		}
+	}
+}
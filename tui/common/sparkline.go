@@ -0,0 +1,23 @@
+package common
+
+import "strings"
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a row of bars scaled to the largest value.
+// All-zero input draws the lowest bar for each value.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 && v > 0 {
+			i = v * (len(sparkBars) - 1) / peak
+		}
+		b.WriteRune(sparkBars[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package common
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"all zero", []int{0, 0, 0}, "▁▁▁"},
+		{"scaled to peak", []int{0, 7, 14, 3}, "▁▄█▂"},
+		{"negative clamps", []int{-2, 4}, "▁█"},
+	}
+	for _, tc := range tests {
+		if got := Sparkline(tc.values); got != tc.want {
+			t.Fatalf("%s: Sparkline(%v) = %q, want %q", tc.name, tc.values, got, tc.want)
+		}
+	}
+}
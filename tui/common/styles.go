@@ -91,4 +91,14 @@ var (
 	// MetadataStyle styles secondary info like counts.
 	MetadataStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#555555"))
+
+	// DiffInsertStyle marks text added by an edit.
+	DiffInsertStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A6DA95")).
+			Underline(true)
+
+	// DiffDeleteStyle marks text removed by an edit.
+	DiffDeleteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ED8796")).
+			Strikethrough(true)
 )
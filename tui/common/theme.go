@@ -0,0 +1,15 @@
+package common
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ApplyTheme switches the color theme before the UI starts: "mono" drops
+// all colors and keeps bold, italic and borders; anything else keeps the
+// default palette.
+func ApplyTheme(name string) {
+	if name == "mono" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
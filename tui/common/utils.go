@@ -15,3 +15,18 @@ func StripHashtag(content, hashtag string) string {
 	}
 	return content
 }
+
+// StripHashtags removes any of tags (each with its '#') from the end of the
+// text, in whatever order they were appended.
+func StripHashtags(content string, tags []string) string {
+	for {
+		stripped := content
+		for _, t := range tags {
+			stripped = StripHashtag(stripped, strings.TrimPrefix(t, "#"))
+		}
+		if stripped == content {
+			return content
+		}
+		content = stripped
+	}
+}
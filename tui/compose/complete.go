@@ -0,0 +1,252 @@
+package compose
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// completeDelay is how long typing must pause before the server is asked
+// for suggestions.
+const completeDelay = 300 * time.Millisecond
+
+// maxSuggestions caps the autocomplete popup.
+const maxSuggestions = 6
+
+var seenTagRe = regexp.MustCompile(`(?:^|\s)#(\w+)`)
+
+// completion is the inline mention/hashtag autocomplete state.
+type completion struct {
+	search    app.SearchService
+	accounts  []app.Suggestion // Seen in the feed
+	tags      []app.Suggestion // Seen in the feed
+	token     string           // "@al" or "#go" under the cursor; empty when closed
+	items     []app.Suggestion
+	cursor    int
+	seq       int
+	dismissed string // Token the popup was closed on with esc
+}
+
+type completeTickMsg struct {
+	seq int
+}
+
+type suggestionsMsg struct {
+	seq   int
+	token string
+	items []app.Suggestion
+	err   error
+}
+
+// WithCompletion enables @mention and #hashtag autocomplete in the inline
+// composer. Accounts and tags from seen rants are suggested straight away;
+// search, when set, adds matches from the server after a short pause.
+func (m Model) WithCompletion(search app.SearchService, seen []domain.Rant) Model {
+	m.complete.search = search
+	accounts := map[string]bool{}
+	tags := map[string]bool{}
+	addTag := func(name string) {
+		key := strings.ToLower(name)
+		if name == "" || tags[key] {
+			return
+		}
+		tags[key] = true
+		m.complete.tags = append(m.complete.tags, app.Suggestion{Value: "#" + name})
+	}
+	addTag(strings.TrimPrefix(domain.AppHashTag, "#"))
+	for _, r := range seen {
+		if r.Username != "" && !accounts[strings.ToLower(r.Username)] {
+			accounts[strings.ToLower(r.Username)] = true
+			m.complete.accounts = append(m.complete.accounts, app.Suggestion{Value: "@" + r.Username, Label: r.Author})
+		}
+		for _, match := range seenTagRe.FindAllStringSubmatch(r.Content, -1) {
+			addTag(match[1])
+		}
+	}
+	return m
+}
+
+// completionOpen reports whether the suggestion popup is showing.
+func (m Model) completionOpen() bool {
+	return m.complete.token != "" && len(m.complete.items) > 0
+}
+
+// popupKey reports whether msg triggers b in the popup. Printable keys, such
+// as the k and j of up and down, stay text so they keep narrowing the list.
+func popupKey(msg tea.KeyMsg, b key.Binding) bool {
+	return msg.Type != tea.KeyRunes && key.Matches(msg, b)
+}
+
+// handleCompletionKey handles keys while the popup is showing. It reports
+// false for keys the popup does not use.
+func (m *Model) handleCompletionKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case popupKey(msg, m.keys.Up):
+		if m.complete.cursor > 0 {
+			m.complete.cursor--
+		}
+		return nil, true
+	case popupKey(msg, m.keys.Down):
+		if m.complete.cursor < len(m.complete.items)-1 {
+			m.complete.cursor++
+		}
+		return nil, true
+	case popupKey(msg, m.keys.NextTab), popupKey(msg, m.keys.Select):
+		m.acceptSuggestion(m.complete.items[m.complete.cursor])
+		return m.scheduleAutosave(), true
+	case popupKey(msg, m.keys.Cancel):
+		m.complete.dismissed = m.complete.token
+		m.closeCompletion()
+		return nil, true
+	}
+	return nil, false
+}
+
+// acceptSuggestion replaces the token under the cursor with s.
+func (m *Model) acceptSuggestion(s app.Suggestion) {
+	for range []rune(m.complete.token) {
+		m.textarea, _ = m.textarea.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.textarea.InsertString(s.Value + " ")
+	m.closeCompletion()
+}
+
+func (m *Model) closeCompletion() {
+	m.complete.token = ""
+	m.complete.items = nil
+	m.complete.cursor = 0
+}
+
+// refreshCompletion follows the token under the cursor after an edit,
+// showing local matches at once and debouncing the server search.
+func (m *Model) refreshCompletion() tea.Cmd {
+	if m.complete.accounts == nil && m.complete.tags == nil && m.complete.search == nil {
+		return nil
+	}
+	token := m.tokenAtCursor()
+	if token == m.complete.token {
+		return nil
+	}
+	m.closeCompletion()
+	if token == "" || token == m.complete.dismissed {
+		return nil
+	}
+	m.complete.dismissed = ""
+	m.complete.token = token
+	m.complete.items = mergeSuggestions(m.localSuggestions(token), nil)
+	if m.complete.search == nil {
+		return nil
+	}
+	m.complete.seq++
+	seq := m.complete.seq
+	return tea.Tick(completeDelay, func(time.Time) tea.Msg { return completeTickMsg{seq: seq} })
+}
+
+// searchCmd asks the server for suggestions for the current token.
+func (m Model) searchCmd() tea.Cmd {
+	search, token, seq := m.complete.search, m.complete.token, m.complete.seq
+	return func() tea.Msg {
+		var (
+			items []app.Suggestion
+			err   error
+		)
+		if strings.HasPrefix(token, "@") {
+			items, err = search.SearchAccounts(context.Background(), token[1:], maxSuggestions)
+		} else {
+			items, err = search.SearchHashtags(context.Background(), token[1:], maxSuggestions)
+		}
+		return suggestionsMsg{seq: seq, token: token, items: items, err: err}
+	}
+}
+
+// handleCompletionMsg handles the debounce tick and search results.
+func (m Model) handleCompletionMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case completeTickMsg:
+		if msg.seq != m.complete.seq || m.complete.token == "" {
+			return m, nil
+		}
+		return m, m.searchCmd()
+	case suggestionsMsg:
+		if msg.seq != m.complete.seq || msg.token != m.complete.token || msg.err != nil {
+			// Stale, or the server is unreachable: keep the local matches.
+			return m, nil
+		}
+		m.complete.items = mergeSuggestions(m.complete.items, msg.items)
+	}
+	return m, nil
+}
+
+// localSuggestions matches token against accounts and tags seen in the feed.
+func (m Model) localSuggestions(token string) []app.Suggestion {
+	pool := m.complete.tags
+	if strings.HasPrefix(token, "@") {
+		pool = m.complete.accounts
+	}
+	query := strings.ToLower(token[1:])
+	var out []app.Suggestion
+	for _, s := range pool {
+		if strings.HasPrefix(strings.ToLower(s.Value[1:]), query) || strings.HasPrefix(strings.ToLower(s.Label), query) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// mergeSuggestions appends extra to base without duplicates, up to
+// maxSuggestions.
+func mergeSuggestions(base, extra []app.Suggestion) []app.Suggestion {
+	seen := map[string]bool{}
+	var out []app.Suggestion
+	for _, s := range append(append([]app.Suggestion{}, base...), extra...) {
+		key := strings.ToLower(s.Value)
+		if seen[key] || len(out) == maxSuggestions {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+// tokenAtCursor returns the @mention or #hashtag being typed just before
+// the cursor, sigil included, or "" when there is none.
+func (m Model) tokenAtCursor() string {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	row := m.textarea.Line()
+	if row < 0 || row >= len(lines) {
+		return ""
+	}
+	line := []rune(lines[row])
+	info := m.textarea.LineInfo()
+	col := min(info.StartColumn+info.ColumnOffset, len(line))
+
+	start := col
+	for start > 0 && isHandleRune(line[start-1]) {
+		start--
+	}
+	if start > 0 && line[start-1] == '#' {
+		start--
+	}
+	if col-start < 2 || (line[start] != '@' && line[start] != '#') {
+		return ""
+	}
+	if start > 0 && !unicode.IsSpace(line[start-1]) && !strings.ContainsRune("([{\"'", line[start-1]) {
+		return ""
+	}
+	return string(line[start:col])
+}
+
+// isHandleRune reports whether r can appear in an acct ("user@domain") or a
+// hashtag after its sigil.
+func isHandleRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-@", r)
+}
@@ -0,0 +1,119 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
+)
+
+type fakeSearch struct {
+	accountQueries []string
+	tagQueries     []string
+}
+
+func (f *fakeSearch) SearchAccounts(_ context.Context, query string, _ int) ([]app.Suggestion, error) {
+	f.accountQueries = append(f.accountQueries, query)
+	return []app.Suggestion{{Value: "@alan@remote.example", Label: "Alan"}}, nil
+}
+
+func (f *fakeSearch) SearchHashtags(_ context.Context, query string, _ int) ([]app.Suggestion, error) {
+	f.tagQueries = append(f.tagQueries, query)
+	return []app.Suggestion{{Value: "#golang"}, {Value: "#gotchas"}}, nil
+}
+
+var seenRants = []domain.Rant{
+	{Username: "alice@example.social", Author: "Alice", Content: "flaky again #ci #golang"},
+	{Username: "bob", Author: "Bob", Content: "same #ci"},
+}
+
+func TestCompletion_LocalMentionSelectedWithKeyboard(t *testing.T) {
+	m := NewInline(nil, "terminalrant").WithCompletion(nil, seenRants)
+	m, _ = typeText(m, "hi @al")
+	if !m.completionOpen() || m.complete.items[0].Value != "@alice@example.social" {
+		t.Fatalf("expected local mention suggestion, got %#v", m.complete.items)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.textarea.Value(); got != "hi @alice@example.social " {
+		t.Fatalf("unexpected text after accepting: %q", got)
+	}
+	if m.completionOpen() {
+		t.Fatalf("expected popup to close after accepting")
+	}
+}
+
+func TestCompletion_DebouncedSearchMergesServerResults(t *testing.T) {
+	search := &fakeSearch{}
+	m := NewInline(nil, "terminalrant").WithCompletion(search, seenRants)
+	m, _ = typeText(m, "#go")
+	if len(m.complete.items) != 1 || m.complete.items[0].Value != "#golang" {
+		t.Fatalf("expected the seen tag first, got %#v", m.complete.items)
+	}
+
+	if _, cmd := m.Update(completeTickMsg{seq: m.complete.seq - 1}); cmd != nil {
+		t.Fatalf("expected superseded tick to be ignored")
+	}
+	_, cmd := m.Update(completeTickMsg{seq: m.complete.seq})
+	if cmd == nil {
+		t.Fatalf("expected current tick to search")
+	}
+	m, _ = m.Update(cmd())
+	if len(search.tagQueries) != 1 || search.tagQueries[0] != "go" {
+		t.Fatalf("unexpected search queries: %v", search.tagQueries)
+	}
+	if len(m.complete.items) != 2 || m.complete.items[1].Value != "#gotchas" {
+		t.Fatalf("expected deduplicated merge, got %#v", m.complete.items)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.textarea.Value(); got != "#gotchas " {
+		t.Fatalf("unexpected text after choosing with arrows: %q", got)
+	}
+}
+
+func TestCompletion_EscDismissesWithoutCancelling(t *testing.T) {
+	m := NewInline(nil, "terminalrant").WithCompletion(nil, seenRants)
+	m, _ = typeText(m, "cc @bo")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.completionOpen() {
+		t.Fatalf("expected esc to close only the popup")
+	}
+
+	m.textarea.SetValue("")
+	m, _ = typeText(m, "mail me@bo")
+	if m.completionOpen() {
+		t.Fatalf("an address is not a mention")
+	}
+}
+
+func TestCompletion_FollowsKeyMap(t *testing.T) {
+	emacs, err := common.PresetKeyMap("emacs")
+	if err != nil {
+		t.Fatalf("emacs preset: %v", err)
+	}
+	rants := []domain.Rant{{Username: "jake"}, {Username: "jakob"}}
+	m := NewInline(nil, "terminalrant").WithKeys(emacs).WithCompletion(nil, rants)
+	m, _ = typeText(m, "cc @ja")
+	if len(m.complete.items) != 2 {
+		t.Fatalf("expected several mentions, got %#v", m.complete.items)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.complete.cursor != 1 {
+		t.Fatalf("expected ctrl+n to move down the popup, cursor %d", m.complete.cursor)
+	}
+	// k moves up in the emacs preset too, but here it is part of a name.
+	m, _ = typeText(m, "k")
+	if m.textarea.Value() != "cc @jak" || !m.completionOpen() {
+		t.Fatalf("expected letters to keep filtering, got %q", m.textarea.Value())
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if cmd != nil || m.completionOpen() {
+		t.Fatalf("expected ctrl+g to close only the popup")
+	}
+}
@@ -0,0 +1,124 @@
+package compose
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// autosaveDelay is how long typing must pause before the inline buffer is
+// written to the draft store.
+const autosaveDelay = time.Second
+
+type autosaveMsg struct {
+	seq int
+}
+
+type draftSavedMsg struct {
+	content string
+	err     error
+}
+
+// WithDrafts enables autosave into store. Without it the composer keeps no
+// drafts.
+func (m Model) WithDrafts(store app.DraftStore) Model {
+	m.drafts = store
+	if m.draftID == "" {
+		m.draftID = app.NewDraftID()
+	}
+	return m
+}
+
+// WithDraft resumes d: its text replaces the initial content and later saves
+// update d instead of creating a new draft.
+func (m Model) WithDraft(d app.Draft) Model {
+	m.draftID = d.ID
+	m.draftBody = d.Content
+	m.opts = d.PostOptions()
+	m.savedDraft = d.Content
+	if m.mode == inlineMode {
+		if !d.IsEdit() && (hasThreadSeparator(d.Content) || m.tooLong(d.Content)) {
+			m.setThread(true)
+		}
+		m.textarea.SetValue(d.Content)
+	}
+	return m
+}
+
+// keepsDraft reports whether content is worth keeping as a draft: anything
+// non-empty that differs from the rant being edited.
+func (m Model) keepsDraft(content string) bool {
+	return strings.TrimSpace(content) != "" && content != m.content
+}
+
+func (m Model) draftFor(content string) app.Draft {
+	d := app.Draft{
+		ID:          m.draftID,
+		Content:     content,
+		Hashtags:    m.hashtag,
+		Visibility:  m.opts.Visibility,
+		SpoilerText: m.opts.SpoilerText,
+		MediaIDs:    m.opts.MediaIDs,
+	}
+	if m.isEdit {
+		d.RantID = m.rantID
+	}
+	if m.isReply {
+		d.ParentID = m.parentID
+		d.ParentAuthor = m.parentAuthor
+		d.ParentSummary = m.parentSummary
+	}
+	return d
+}
+
+// saveDraftCmd writes content to the draft store, or deletes the draft when
+// content is not worth keeping.
+func (m Model) saveDraftCmd(content string) tea.Cmd {
+	if m.drafts == nil || m.draftID == "" {
+		return nil
+	}
+	store := m.drafts
+	keep := m.keepsDraft(content)
+	d := m.draftFor(content)
+	return func() tea.Msg {
+		if !keep {
+			return draftSavedMsg{err: store.DeleteDraft(d.ID)}
+		}
+		_, err := store.SaveDraft(d)
+		return draftSavedMsg{content: content, err: err}
+	}
+}
+
+// scheduleAutosave debounces inline autosave after an edit.
+func (m *Model) scheduleAutosave() tea.Cmd {
+	if m.drafts == nil {
+		return nil
+	}
+	target := m.textarea.Value()
+	if !m.keepsDraft(target) {
+		target = ""
+	}
+	if target == m.savedDraft {
+		return nil
+	}
+	m.autosaveSeq++
+	seq := m.autosaveSeq
+	return tea.Tick(autosaveDelay, func(time.Time) tea.Msg { return autosaveMsg{seq: seq} })
+}
+
+// finish saves or discards the draft for content, then delivers msg. The
+// DraftID of msg is set when a draft was kept.
+func (m Model) finish(content string, msg DoneMsg) tea.Cmd {
+	msg.Hashtag = m.hashtag
+	msg.Options = m.opts
+	if m.drafts == nil {
+		return done(msg)
+	}
+	if m.keepsDraft(content) {
+		msg.DraftID = m.draftID
+	}
+	return tea.Sequence(m.saveDraftCmd(content), done(msg))
+}
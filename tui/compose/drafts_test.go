@@ -0,0 +1,115 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+type memoryDrafts struct {
+	saved   map[string]app.Draft
+	deleted []string
+}
+
+func (s *memoryDrafts) ListDrafts() ([]app.Draft, error) { return nil, nil }
+
+func (s *memoryDrafts) SaveDraft(d app.Draft) (app.Draft, error) {
+	if s.saved == nil {
+		s.saved = map[string]app.Draft{}
+	}
+	s.saved[d.ID] = d
+	return d, nil
+}
+
+func (s *memoryDrafts) DeleteDraft(id string) error {
+	delete(s.saved, id)
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func typeText(m Model, text string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, r := range text {
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m, cmd
+}
+
+func TestInlineAutosave_SavesReplyContextAfterPause(t *testing.T) {
+	store := &memoryDrafts{}
+	m := NewInlineWithContent(nil, "#terminalrant #golang", "42", "", false, true, "alice", "@alice: hi").WithDrafts(store)
+
+	m, cmd := typeText(m, "same")
+	if cmd == nil {
+		t.Fatalf("expected autosave to be scheduled after typing")
+	}
+	stale := autosaveMsg{seq: m.autosaveSeq - 1}
+	if _, cmd := m.Update(stale); cmd != nil {
+		t.Fatalf("expected superseded autosave tick to be ignored")
+	}
+
+	m, cmd = m.Update(autosaveMsg{seq: m.autosaveSeq})
+	if cmd == nil {
+		t.Fatalf("expected current autosave tick to save")
+	}
+	m, _ = m.Update(cmd())
+
+	d, ok := store.saved[m.draftID]
+	if !ok || d.Content != "same" {
+		t.Fatalf("expected draft saved with typed content, got %#v", store.saved)
+	}
+	if d.ParentID != "42" || d.ParentAuthor != "alice" || d.RantID != "" || d.Hashtags != "#terminalrant #golang" {
+		t.Fatalf("expected reply context on draft, got %#v", d)
+	}
+	if m.savedDraft != "same" {
+		t.Fatalf("expected saved content to be tracked")
+	}
+}
+
+func TestDraftCmd_DeletesUnchangedEditAndKeepsResumedText(t *testing.T) {
+	store := &memoryDrafts{}
+	edit := NewInlineWithContent(nil, "terminalrant", "7", "original", true, false, "", "").WithDrafts(store)
+	if edit.keepsDraft("original") {
+		t.Fatalf("unchanged edit should not be kept as a draft")
+	}
+	edit.saveDraftCmd("original")()
+	if len(store.deleted) != 1 {
+		t.Fatalf("expected unchanged edit to delete its draft")
+	}
+
+	resumed := NewInline(nil, "terminalrant").WithDrafts(store).WithDraft(app.Draft{ID: "d1", Content: "left off here"})
+	if resumed.textarea.Value() != "left off here" || resumed.draftID != "d1" {
+		t.Fatalf("expected resumed draft text and ID")
+	}
+	if !resumed.keepsDraft(resumed.textarea.Value()) {
+		t.Fatalf("resumed draft text should be kept on cancel")
+	}
+	if _, cmd := resumed.Update(tea.KeyMsg{Type: tea.KeyRight}); cmd != nil {
+		if _, ok := cmd().(autosaveMsg); ok {
+			t.Fatalf("expected no autosave when content is unchanged")
+		}
+	}
+}
+
+func TestWithDraft_CarriesRedraftOptionsToPostAndDraft(t *testing.T) {
+	d := app.Draft{ID: "d1", Content: "again", Visibility: "unlisted", SpoilerText: "spoilers", MediaIDs: []string{"m1"}}
+	m := NewInline(nil, "").WithDraft(d)
+	if got := m.draftFor("again, edited"); got.Visibility != "unlisted" || got.SpoilerText != "spoilers" || len(got.MediaIDs) != 1 {
+		t.Fatalf("expected autosaved draft to keep the redraft settings, got %#v", got)
+	}
+	if view := m.View(); !strings.Contains(view, "CW: spoilers") || !strings.Contains(view, "1 media") {
+		t.Fatalf("expected kept settings in the composer, got %q", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd == nil {
+		t.Fatalf("expected send to finish the composer")
+	}
+	done, ok := cmd().(DoneMsg)
+	if !ok || done.Content != "again" || done.Options.Visibility != "unlisted" || done.Options.SpoilerText != "spoilers" || done.Options.MediaIDs[0] != "m1" {
+		t.Fatalf("expected redraft options on the done message, got %#v", done)
+	}
+}
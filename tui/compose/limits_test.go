@@ -0,0 +1,86 @@
+package compose
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+func TestPostLength_FollowsMastodonCountingRules(t *testing.T) {
+	limits := app.DefaultInstanceConfig()
+	tags := []string{domain.AppHashTag}
+	tag := len("\n\n" + domain.AppHashTag)
+
+	url := "https://example.com/" + strings.Repeat("a", 80)
+	if got := limits.PostLength("see "+url+".", tags); got != len("see .")+23+tag {
+		t.Fatalf("expected URL to count as 23, got %d", got)
+	}
+	if got := limits.PostLength("hi @bob@mastodon.example", tags); got != len("hi @bob")+tag {
+		t.Fatalf("expected remote mention to count its local part, got %d", got)
+	}
+	if got := limits.PostLength("already tagged "+domain.AppHashTag, tags); got != len("already tagged "+domain.AppHashTag) {
+		t.Fatalf("expected no reserve when the hashtag is present, got %d", got)
+	}
+	if got := limits.PostLength("   ", tags); got != 0 {
+		t.Fatalf("expected empty content to count zero, got %d", got)
+	}
+}
+
+func TestInlineSubmit_EnforcesInstanceLimit(t *testing.T) {
+	limits := app.DefaultInstanceConfig()
+	limits.MaxCharacters = 40
+	m := NewInline(nil, "terminalrant").WithLimits(limits)
+	m.textarea.SetValue(strings.Repeat("rant ", 10))
+	if !strings.Contains(m.View(), "64/40 chars") {
+		t.Fatalf("expected counter against the instance limit")
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd != nil {
+		t.Fatalf("expected over-limit rant not to be submitted")
+	}
+	if !strings.Contains(m.status, "ctrl+s") {
+		t.Fatalf("expected status to suggest a thread, got %q", m.status)
+	}
+
+	// A link counts as 23 characters however long it is.
+	m.textarea.SetValue("https://example.com/" + strings.Repeat("x", 100))
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd == nil {
+		t.Fatalf("expected a long link to fit")
+	}
+	if msg, ok := cmd().(DoneMsg); !ok || msg.Content == "" {
+		t.Fatalf("expected DoneMsg with content, got %#v", msg)
+	}
+}
+
+func TestCheckLength_WrapsErrRantTooLong(t *testing.T) {
+	limits := app.DefaultInstanceConfig()
+	err := limits.CheckLength(strings.Repeat("x", limits.MaxCharacters), []string{domain.AppHashTag})
+	if !errors.Is(err, domain.ErrRantTooLong) {
+		t.Fatalf("expected ErrRantTooLong once the hashtag is added, got %v", err)
+	}
+}
+
+func TestInlinePreview_ShowsFinalTags(t *testing.T) {
+	m := NewInline(nil, "#terminalrant #golang")
+	m.textarea.SetValue("generics again #Golang #ci")
+	view := m.View()
+	if !strings.Contains(view, "Tags: #Golang #ci +#terminalrant") {
+		t.Fatalf("expected final tags with appended ones marked, got %q", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if msg, ok := cmd().(DoneMsg); !ok || msg.Hashtag != "#terminalrant #golang" {
+		t.Fatalf("expected the composer's tags on DoneMsg, got %#v", msg)
+	}
+
+	if view := NewInline(nil, "").View(); !strings.Contains(view, "Tags: none") {
+		t.Fatalf("expected no tags preview, got %q", view)
+	}
+}
@@ -1,13 +1,22 @@
 package compose
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
 	"github.com/CrestNiraj12/terminalrant/infra/editor"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
 )
 
 // --- Mode ---
@@ -29,8 +38,26 @@ type DoneMsg struct {
 	IsEdit   bool
 	IsReply  bool
 	Err      error
+	// ScheduledAt is set when the rant should be published later instead of now.
+	ScheduledAt time.Time
+	// DraftID names the draft holding this content. On a cancel or error it
+	// means the text was kept; after a successful post the draft can go.
+	DraftID string
+	// Thread holds the unnumbered posts when the content is published as a
+	// reply chain; it is empty for a single post.
+	Thread []string
+	// Hashtag lists the tags to append, as given to the composer.
+	Hashtag string
+	// Options carries the visibility, content warning and media of a
+	// redrafted rant; zero for a fresh one.
+	Options app.PostOptions
 }
 
+var (
+	errEditSchedule   = errors.New("edits cannot be scheduled")
+	errThreadSchedule = errors.New("threads cannot be scheduled")
+)
+
 // editorFinishedMsg is sent after the external editor exits.
 type editorFinishedMsg struct {
 	tmpPath string
@@ -44,7 +71,7 @@ type Model struct {
 	mode          mode
 	post          app.PostService
 	editor        *editor.EnvEditor
-	hashtag       string
+	hashtag       string // Tags appended on send, as passed to app.PostService
 	status        string
 	err           error
 	textarea      textarea.Model // Only used in inline mode
@@ -55,7 +82,30 @@ type Model struct {
 	parentID      string
 	parentAuthor  string
 	parentSummary string
-	content       string // Initial content for editing
+	content       string          // Initial content for editing
+	schedule      textinput.Model // Inline schedule field (keys.Schedule)
+	scheduleOpen  bool
+	scheduleFocus bool
+	drafts        app.DraftStore
+	draftID       string
+	draftBody     string // Resumed draft text, opened instead of content
+	savedDraft    string // Content last written to the draft store
+	autosaveSeq   int
+	thread        bool // Inline thread mode (keys.Thread): no length limit, split on post
+	limits        app.InstanceConfig
+	unlimited     bool // Not a post (e.g. the profile form): no length check
+	complete      completion
+	keys          common.KeyMap
+	opts          app.PostOptions // Kept from a redrafted rant
+}
+
+func newScheduleInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "tomorrow 9am, in 2h, 2026-03-05T09:00:00Z"
+	ti.CharLimit = 64
+	ti.Width = 40
+	return ti
 }
 
 // NewEditor creates a compose model that opens $EDITOR via tea.Exec.
@@ -66,6 +116,7 @@ func NewEditor(post app.PostService, ed *editor.EnvEditor, hashtag string) Model
 		editor:  ed,
 		hashtag: hashtag,
 		status:  "Opening editor...",
+		limits:  app.DefaultInstanceConfig(),
 	}
 }
 
@@ -88,6 +139,7 @@ func NewEditorWithContent(post app.PostService, ed *editor.EnvEditor, hashtag st
 		parentAuthor:  parentAuthor,
 		parentSummary: parentSummary,
 		content:       content,
+		limits:        app.DefaultInstanceConfig(),
 	}
 }
 
@@ -95,7 +147,7 @@ func NewEditorWithContent(post app.PostService, ed *editor.EnvEditor, hashtag st
 func NewInline(post app.PostService, hashtag string) Model {
 	ta := textarea.New()
 	ta.Placeholder = "What's grinding your gears?"
-	ta.CharLimit = 500
+	ta.CharLimit = 0 // The instance limit is checked on submit; see WithLimits.
 	ta.SetWidth(72)
 	ta.SetHeight(6)
 	ta.Focus()
@@ -105,12 +157,16 @@ func NewInline(post app.PostService, hashtag string) Model {
 		post:     post,
 		hashtag:  hashtag,
 		textarea: ta,
+		schedule: newScheduleInput(),
+		limits:   app.DefaultInstanceConfig(),
+		keys:     common.DefaultKeyMap(),
 	}
 }
 
 // NewInlineWithContent creates a compose model for editing or replying to a rant inline.
 func NewInlineWithContent(post app.PostService, hashtag string, rantID string, content string, isEdit bool, isReply bool, parentAuthor string, parentSummary string) Model {
 	ta := textarea.New()
+	ta.CharLimit = 0
 	if isReply {
 		ta.Placeholder = fmt.Sprintf("Reply to %s...", parentAuthor)
 	} else {
@@ -125,6 +181,7 @@ func NewInlineWithContent(post app.PostService, hashtag string, rantID string, c
 		post:          post,
 		hashtag:       hashtag,
 		textarea:      ta,
+		schedule:      newScheduleInput(),
 		isEdit:        isEdit,
 		isReply:       isReply,
 		rantID:        rantID,
@@ -132,7 +189,31 @@ func NewInlineWithContent(post app.PostService, hashtag string, rantID string, c
 		parentAuthor:  parentAuthor,
 		parentSummary: parentSummary,
 		content:       content,
+		limits:        app.DefaultInstanceConfig(),
+		keys:          common.DefaultKeyMap(),
+	}
+}
+
+// WithKeys sets the key bindings of the inline composer.
+func (m Model) WithKeys(keys common.KeyMap) Model {
+	m.keys = keys
+	return m
+}
+
+// WithLimits sets the instance limits used to count and check the rant.
+// Zero limits keep the defaults.
+func (m Model) WithLimits(limits app.InstanceConfig) Model {
+	if limits.MaxCharacters > 0 {
+		m.limits = limits
 	}
+	return m
+}
+
+// WithoutLimit turns off length counting and checks, for text that is not
+// published as a post.
+func (m Model) WithoutLimit() Model {
+	m.unlimited = true
+	return m
 }
 
 // Init returns the initial command for the active mode.
@@ -149,7 +230,7 @@ func (m Model) Init() tea.Cmd {
 // launchEditor prepares the editor command and uses tea.Exec to properly
 // suspend Bubble Tea's raw terminal mode while the editor runs.
 func (m *Model) launchEditor() tea.Cmd {
-	cmd, tmpPath, err := m.editor.Cmd(m.content, m.parentAuthor)
+	cmd, tmpPath, err := m.editor.Cmd(m.openingText(), m.parentAuthor, m.tags()...)
 	if err != nil {
 		return func() tea.Msg {
 			return DoneMsg{Err: fmt.Errorf("preparing editor: %w", err)}
@@ -172,20 +253,69 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case editorFinishedMsg:
 		if msg.err != nil {
-			return m, done(DoneMsg{Err: fmt.Errorf("editor: %w", msg.err), IsEdit: m.isEdit})
+			// Keep whatever was written before the editor failed.
+			raw, _ := m.editor.ReadContent(msg.tmpPath)
+			return m, m.finish(raw, DoneMsg{Err: fmt.Errorf("editor: %w", msg.err), IsEdit: m.isEdit})
 		}
 
 		// Read content from temp file.
-		content, err := m.editor.ReadContent(msg.tmpPath)
+		raw, err := m.editor.ReadContent(msg.tmpPath)
 		if err != nil {
 			return m, done(DoneMsg{Err: err, IsEdit: m.isEdit, RantID: m.rantID})
 		}
 
-		if content == "" || content == m.content {
-			return m, done(DoneMsg{IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID}) // Cancel
+		fields, content := splitFrontMatter(raw)
+		scheduledAt, err := resolveSchedule(fields["schedule"], time.Now())
+		if err == nil && m.isEdit && !scheduledAt.IsZero() {
+			err = errEditSchedule
+		}
+		if err != nil {
+			return m, m.finish(raw, DoneMsg{Err: err, IsEdit: m.isEdit, RantID: m.rantID})
 		}
 
-		return m, done(DoneMsg{Content: content, IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID})
+		if content == "" || content == m.openingText() {
+			return m, m.finish(content, DoneMsg{IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID}) // Cancel
+		}
+
+		var thread []string
+		if !m.isEdit && (hasThreadSeparator(content) || m.tooLong(content)) {
+			thread = m.planThread(content)
+		}
+		if len(thread) > 0 && !scheduledAt.IsZero() {
+			return m, m.finish(raw, DoneMsg{Err: errThreadSchedule, IsReply: m.isReply, ParentID: m.parentID})
+		}
+		if len(thread) == 0 {
+			if err := m.checkLength(content); err != nil {
+				return m, m.finish(raw, DoneMsg{Err: err, IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID})
+			}
+		}
+
+		return m, m.finish(content, DoneMsg{Content: content, IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID, ScheduledAt: scheduledAt, Thread: thread})
+
+	// --- Draft autosave ---
+
+	case autosaveMsg:
+		if msg.seq != m.autosaveSeq {
+			return m, nil
+		}
+		content := m.textarea.Value()
+		if !m.keepsDraft(content) {
+			content = ""
+		}
+		return m, m.saveDraftCmd(content)
+
+	case draftSavedMsg:
+		if msg.err != nil {
+			m.status = "Draft autosave failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.savedDraft = msg.content
+		return m, nil
+
+	// --- Autocomplete ---
+
+	case completeTickMsg, suggestionsMsg:
+		return m.handleCompletionMsg(msg)
 
 	// --- Inline mode messages ---
 
@@ -194,22 +324,93 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			break
 		}
 
-		switch msg.String() {
-		case "esc":
-			return m, done(DoneMsg{IsEdit: m.isEdit}) // Cancel.
+		if m.scheduleFocus {
+			switch {
+			case key.Matches(msg, m.keys.Cancel):
+				// Drop the schedule and go back to the rant body.
+				m.schedule.SetValue("")
+				m.scheduleOpen = false
+				m.blurSchedule()
+				return m, textarea.Blink
+			case key.Matches(msg, m.keys.Submit) || key.Matches(msg, m.keys.NextTab):
+				if strings.TrimSpace(m.schedule.Value()) == "" {
+					m.scheduleOpen = false
+				}
+				m.blurSchedule()
+				return m, textarea.Blink
+			case key.Matches(msg, m.keys.Send):
+				// Fall through to submit below.
+			default:
+				var cmd tea.Cmd
+				m.schedule, cmd = m.schedule.Update(msg)
+				return m, cmd
+			}
+		}
 
-		case "ctrl+d":
+		if m.completionOpen() {
+			if cmd, ok := m.handleCompletionKey(msg); ok {
+				return m, cmd
+			}
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Cancel):
+			return m, m.finish(m.textarea.Value(), DoneMsg{IsEdit: m.isEdit}) // Cancel.
+
+		case key.Matches(msg, m.keys.Schedule):
+			if m.isEdit {
+				m.status = "Edits cannot be scheduled."
+				return m, nil
+			}
+			m.status = ""
+			m.scheduleOpen = true
+			m.scheduleFocus = true
+			m.textarea.Blur()
+			return m, m.schedule.Focus()
+
+		case key.Matches(msg, m.keys.Thread):
+			if m.isEdit {
+				m.status = "Edits cannot be split into a thread."
+				return m, nil
+			}
+			m.status = ""
+			m.setThread(!m.thread)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Send):
+			scheduledAt, err := m.inlineScheduledAt()
+			if err != nil {
+				m.status = err.Error()
+				return m, nil
+			}
 			content := m.textarea.Value()
 			if content == "" || content == m.content {
-				return m, done(DoneMsg{IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID})
+				return m, m.finish(content, DoneMsg{IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID})
+			}
+			var thread []string
+			if m.thread {
+				thread = m.planThread(content)
+			}
+			if len(thread) > 0 && !scheduledAt.IsZero() {
+				m.status = errThreadSchedule.Error()
+				return m, nil
+			}
+			if len(thread) == 0 {
+				if err := m.checkLength(content); err != nil {
+					m.status = capitalize(err.Error())
+					if !m.isEdit {
+						m.status += " • " + m.keys.Thread.Help().Key + " splits it into a thread"
+					}
+					return m, nil
+				}
 			}
-			return m, done(DoneMsg{Content: content, IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID})
+			return m, m.finish(content, DoneMsg{Content: content, IsEdit: m.isEdit, IsReply: m.isReply, RantID: m.rantID, ParentID: m.parentID, ScheduledAt: scheduledAt, Thread: thread})
 		}
 
 		// Delegate to textarea for normal typing.
 		var cmd tea.Cmd
 		m.textarea, cmd = m.textarea.Update(msg)
-		return m, cmd
+		return m, tea.Batch(cmd, m.scheduleAutosave(), m.refreshCompletion())
 
 		// --- Shared messages ---
 
@@ -225,6 +426,72 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// planThread returns the posts for content, or nil when it fits in one.
+func (m Model) planThread(content string) []string {
+	parts := PlanThread(content, m.limits, m.tags())
+	if len(parts) < 2 {
+		return nil
+	}
+	return parts
+}
+
+// tooLong reports whether content, as the instance counts it, does not fit
+// in one post.
+func (m Model) tooLong(content string) bool {
+	return !m.unlimited && m.limits.PostLength(content, m.tags()) > m.limits.MaxCharacters
+}
+
+func (m Model) checkLength(content string) error {
+	if m.unlimited {
+		return nil
+	}
+	return m.limits.CheckLength(content, m.tags())
+}
+
+// setThread switches inline thread mode, which splits the rant into posts
+// instead of enforcing the per-post limit.
+func (m *Model) setThread(on bool) {
+	m.thread = on
+}
+
+// tags returns the hashtags appended on send.
+func (m Model) tags() []string {
+	return domain.ParseHashtags(m.hashtag)
+}
+
+// capitalize upper-cases the first letter of an error for the status line.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// openingText is the text the composer starts from: a resumed draft, or the
+// rant being edited.
+func (m Model) openingText() string {
+	if m.draftBody != "" {
+		return m.draftBody
+	}
+	return m.content
+}
+
+func (m *Model) blurSchedule() {
+	m.scheduleFocus = false
+	m.schedule.Blur()
+	m.textarea.Focus()
+}
+
+// inlineScheduledAt parses the inline schedule field; an empty or closed
+// field means publish now.
+func (m Model) inlineScheduledAt() (time.Time, error) {
+	if !m.scheduleOpen {
+		return time.Time{}, nil
+	}
+	return resolveSchedule(m.schedule.Value(), time.Now())
+}
+
 // done wraps a DoneMsg into a tea.Cmd for immediate delivery.
 func done(msg DoneMsg) tea.Cmd {
 	return func() tea.Msg { return msg }
@@ -0,0 +1,69 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CrestNiraj12/terminalrant/tui/common"
+)
+
+// splitFrontMatter separates an optional leading front matter block from
+// editor content:
+//
+//	---
+//	schedule: tomorrow 9am
+//	---
+//	rant body
+//
+// A block only counts as front matter when every line in it is a known
+// "key: value"; anything else, such as a thread that opens with a ---
+// separator, is returned unchanged as the body.
+func splitFrontMatter(content string) (fields map[string]string, body string) {
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return nil, content
+	}
+	rest := normalized[len("---\n"):]
+	block, after, ok := strings.Cut(rest, "\n---")
+	if !ok {
+		return nil, content
+	}
+	if after != "" && !strings.HasPrefix(after, "\n") {
+		return nil, content
+	}
+	fields = map[string]string{}
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, content
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "schedule", "scheduled_at", "at":
+			fields["schedule"] = strings.Trim(strings.TrimSpace(value), `"'`)
+		default:
+			return nil, content
+		}
+	}
+	if len(fields) == 0 {
+		return nil, content
+	}
+	return fields, strings.TrimSpace(after)
+}
+
+// resolveSchedule parses a schedule expression, returning the zero time for
+// an empty one.
+func resolveSchedule(expr string, now time.Time) (time.Time, error) {
+	if strings.TrimSpace(expr) == "" {
+		return time.Time{}, nil
+	}
+	at, err := common.ParseScheduleTime(expr, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule: %w", err)
+	}
+	return at, nil
+}
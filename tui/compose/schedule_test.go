@@ -0,0 +1,53 @@
+package compose
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	fields, body := splitFrontMatter("---\nschedule: tomorrow 9am\n---\n\nshipping friday")
+	if fields["schedule"] != "tomorrow 9am" {
+		t.Fatalf("expected schedule field, got %#v", fields)
+	}
+	if body != "shipping friday" {
+		t.Fatalf("expected body without front matter, got %q", body)
+	}
+
+	for _, content := range []string{
+		"--- not front matter",
+		"---\nschedule: 9am\nstill writing",
+		// Unknown keys and plain text mean the block is not front matter.
+		"---\nvisibility: direct\n---\nhi",
+		// A thread that starts with a separator keeps its first post.
+		"---\nfirst post\n---\nsecond post",
+		"---\nschedule: 9am\nand some text\n---\nmore",
+		"---\n\n---\nhi",
+	} {
+		fields, body := splitFrontMatter(content)
+		if fields != nil || body != content {
+			t.Fatalf("expected %q unchanged, got %#v, %q", content, fields, body)
+		}
+	}
+}
+
+func TestResolveSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	at, err := resolveSchedule("", now)
+	if err != nil || !at.IsZero() {
+		t.Fatalf("expected zero time for empty schedule, got %v, %v", at, err)
+	}
+
+	at, err = resolveSchedule("tomorrow 9am", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, at)
+	}
+
+	if _, err := resolveSchedule("in 1m", now); err == nil {
+		t.Fatal("expected error for time inside the minimum lead")
+	}
+}
@@ -0,0 +1,180 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// threadSeparator on a line of its own forces a new post in a thread.
+const threadSeparator = "---"
+
+// PlanThread splits text into the posts of a thread, each short enough to
+// carry its " (i/n)" number and the appended tags within the instance
+// character limit, counted the way the instance counts them. Lines
+// containing only "---" force a break; otherwise posts are broken at
+// sentence boundaries, then between words. A text that fits in one post is
+// returned as a single part. The parts are returned unnumbered; see
+// NumberThread.
+func PlanThread(text string, limits app.InstanceConfig, tags []string) []string {
+	sections := splitSections(text)
+	if len(sections) == 0 {
+		return nil
+	}
+	limit := limits.MaxCharacters
+	count := func(s string) int { return domain.CountCharacters(s, limits.CharactersReservedPerURL) }
+	// Room for the tags, assuming no post already carries them.
+	hashtagReserve := utf8.RuneCountInString(domain.AppendHashtags("", tags))
+	if len(sections) == 1 && count(sections[0]) <= limit-hashtagReserve {
+		return sections
+	}
+	var parts []string
+	// The number suffix grows with the part count, so retry with a wider
+	// suffix until the count fits the digits reserved for it.
+	for digits := 1; digits <= 4; digits++ {
+		n := strings.Repeat("9", digits)
+		budget := limit - hashtagReserve - utf8.RuneCountInString(" ("+n+"/"+n+")")
+		parts = parts[:0]
+		for _, s := range sections {
+			parts = append(parts, splitToBudget(s, max(budget, 1), count)...)
+		}
+		if len(strconv.Itoa(len(parts))) <= digits {
+			break
+		}
+	}
+	return parts
+}
+
+// NumberThread appends " (i/n)" to each part of a multi-post thread.
+func NumberThread(parts []string) []string {
+	if len(parts) <= 1 {
+		return parts
+	}
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = fmt.Sprintf("%s (%d/%d)", p, i+1, len(parts))
+	}
+	return out
+}
+
+// JoinThread is the inverse of splitting on separators: parts joined so
+// that PlanThread keeps them as separate posts.
+func JoinThread(parts []string) string {
+	return strings.Join(parts, "\n"+threadSeparator+"\n")
+}
+
+// hasThreadSeparator reports whether text contains an explicit post break.
+func hasThreadSeparator(text string) bool {
+	return len(splitSections(text)) > 1
+}
+
+// splitSections splits text on separator lines, dropping empty sections.
+func splitSections(text string) []string {
+	var (
+		sections []string
+		cur      []string
+	)
+	flush := func() {
+		if s := strings.TrimSpace(strings.Join(cur, "\n")); s != "" {
+			sections = append(sections, s)
+		}
+		cur = cur[:0]
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == threadSeparator {
+			flush()
+			continue
+		}
+		cur = append(cur, line)
+	}
+	flush()
+	return sections
+}
+
+// splitToBudget greedily packs sentences into posts of at most budget
+// characters as measured by count, falling back to words and then to hard
+// breaks for pieces that are too long on their own.
+func splitToBudget(text string, budget int, count func(string) int) []string {
+	var (
+		parts []string
+		cur   strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			parts = append(parts, s)
+		}
+		cur.Reset()
+	}
+	for _, unit := range splitUnits(text, budget, count) {
+		if count(strings.TrimSpace(cur.String()+unit)) > budget {
+			flush()
+		}
+		cur.WriteString(unit)
+	}
+	flush()
+	return parts
+}
+
+// splitUnits breaks text into sentences, breaking any sentence longer than
+// budget into words and any word longer than budget into fixed chunks.
+func splitUnits(text string, budget int, count func(string) int) []string {
+	var units []string
+	for _, sentence := range splitAfter(text, isSentenceEnd) {
+		if count(strings.TrimSpace(sentence)) <= budget {
+			units = append(units, sentence)
+			continue
+		}
+		for _, word := range splitAfter(sentence, isWordEnd) {
+			r := []rune(word)
+			for len(r) > budget && count(strings.TrimSpace(string(r))) > budget {
+				units = append(units, string(r[:budget]))
+				r = r[budget:]
+			}
+			units = append(units, string(r))
+		}
+	}
+	return units
+}
+
+// splitAfter cuts text after every rune for which end reports true, keeping
+// the whitespace that follows with the preceding piece.
+func splitAfter(text string, end func(r []rune, i int) bool) []string {
+	r := []rune(text)
+	var out []string
+	start := 0
+	for i := 0; i < len(r); i++ {
+		if !end(r, i) {
+			continue
+		}
+		j := i + 1
+		for j < len(r) && unicode.IsSpace(r[j]) {
+			j++
+		}
+		out = append(out, string(r[start:j]))
+		start = j
+		i = j - 1
+	}
+	if start < len(r) {
+		out = append(out, string(r[start:]))
+	}
+	return out
+}
+
+func isSentenceEnd(r []rune, i int) bool {
+	if r[i] == '\n' {
+		return true
+	}
+	if !strings.ContainsRune(".!?…", r[i]) {
+		return false
+	}
+	return i+1 == len(r) || unicode.IsSpace(r[i+1])
+}
+
+func isWordEnd(r []rune, i int) bool {
+	return !unicode.IsSpace(r[i]) && (i+1 == len(r) || unicode.IsSpace(r[i+1]))
+}
@@ -0,0 +1,92 @@
+package compose
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+func TestPlanThread_ShortTextIsSinglePost(t *testing.T) {
+	parts := PlanThread("  just one rant  ", app.DefaultInstanceConfig(), []string{domain.AppHashTag})
+	if len(parts) != 1 || parts[0] != "just one rant" {
+		t.Fatalf("expected single trimmed part, got %q", parts)
+	}
+	if got := NumberThread(parts); got[0] != "just one rant" {
+		t.Fatalf("single post should not be numbered, got %q", got[0])
+	}
+}
+
+func TestPlanThread_SplitsAtSentencesWithinLimit(t *testing.T) {
+	sentence := "This build has been red since Tuesday and nobody knows why. "
+	text := strings.Repeat(sentence, 20)
+
+	parts := NumberThread(PlanThread(text, app.DefaultInstanceConfig(), []string{domain.AppHashTag}))
+	if len(parts) < 3 {
+		t.Fatalf("expected several parts, got %d", len(parts))
+	}
+	for i, p := range parts {
+		if n := utf8.RuneCountInString(domain.AppendHashtags(p, []string{domain.AppHashTag})); n > app.DefaultInstanceConfig().MaxCharacters {
+			t.Fatalf("part %d is %d chars with hashtag, over limit", i+1, n)
+		}
+		body := p[:strings.LastIndex(p, " (")]
+		if !strings.HasSuffix(body, "why.") {
+			t.Fatalf("part %d does not end at a sentence boundary: %q", i+1, body)
+		}
+	}
+	if !strings.HasSuffix(parts[0], " (1/"+strconv.Itoa(len(parts))+")") {
+		t.Fatalf("expected numbering suffix, got %q", parts[0])
+	}
+}
+
+func TestPlanThread_ExplicitSeparatorsAndLongWords(t *testing.T) {
+	parts := PlanThread("first post\n---\n\nsecond post\n---\n", app.DefaultInstanceConfig(), []string{domain.AppHashTag})
+	if len(parts) != 2 || parts[0] != "first post" || parts[1] != "second post" {
+		t.Fatalf("expected separator split, got %q", parts)
+	}
+	if !hasThreadSeparator("a\n---\nb") || hasThreadSeparator("a --- b") {
+		t.Fatalf("separator must be a line of its own")
+	}
+
+	long := strings.Repeat("x", 1200)
+	parts = PlanThread(long, app.DefaultInstanceConfig(), []string{domain.AppHashTag})
+	if strings.Join(parts, "") != long {
+		t.Fatalf("expected hard-split word to be preserved")
+	}
+	for _, p := range NumberThread(parts) {
+		if utf8.RuneCountInString(domain.AppendHashtags(p, []string{domain.AppHashTag})) > app.DefaultInstanceConfig().MaxCharacters {
+			t.Fatalf("hard-split part over limit")
+		}
+	}
+}
+
+func TestInlineThreadMode_SubmitsPlannedPosts(t *testing.T) {
+	m := NewInline(nil, "terminalrant")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.thread || m.textarea.CharLimit != 0 {
+		t.Fatalf("expected thread mode to lift the length limit")
+	}
+	long := strings.Repeat("Another flaky test. ", 40)
+	m.textarea.SetValue(long)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	msg, ok := cmd().(DoneMsg)
+	if !ok {
+		t.Fatalf("expected DoneMsg")
+	}
+	if len(msg.Thread) < 2 || msg.Content != long {
+		t.Fatalf("expected thread parts and full content, got %d parts", len(msg.Thread))
+	}
+
+	m.schedule.SetValue("tomorrow 9am")
+	m.scheduleOpen = true
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd != nil || m.status != errThreadSchedule.Error() {
+		t.Fatalf("expected scheduled thread to be refused, status %q", m.status)
+	}
+}
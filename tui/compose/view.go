@@ -2,6 +2,7 @@ package compose
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/CrestNiraj12/terminalrant/domain"
@@ -35,14 +36,55 @@ func (m Model) View() string {
 		}
 		b.WriteString(m.textarea.View())
 		b.WriteString("\n\n")
+		if m.completionOpen() {
+			b.WriteString(m.completionView())
+			b.WriteString("\n\n")
+		}
+		if m.scheduleOpen {
+			b.WriteString(m.scheduleView())
+			b.WriteString("\n\n")
+		}
+		if m.thread {
+			b.WriteString(m.threadView())
+			b.WriteString("\n\n")
+		}
+		if !m.unlimited {
+			b.WriteString(m.tagsView())
+			b.WriteString("\n\n")
+		}
+		if kept := m.keptView(); kept != "" {
+			b.WriteString(kept)
+			b.WriteString("\n\n")
+		}
 
 		if m.status != "" {
 			b.WriteString(common.StatusBarStyle.Render(m.status))
-		} else {
+		} else if m.completionOpen() {
+			b.WriteString(common.StatusBarStyle.Render(
+				"  tab/enter: insert • ↑/↓: choose • esc: dismiss",
+			))
+		} else if m.scheduleFocus {
 			b.WriteString(common.StatusBarStyle.Render(
-				fmt.Sprintf("  ctrl+d: post • esc: cancel • %d/500 chars",
-					len(m.textarea.Value())),
+				fmt.Sprintf("  %s: back to rant • %s: clear schedule • %s: schedule",
+					m.keys.Submit.Help().Key, m.keys.Cancel.Help().Key, m.keys.Send.Help().Key),
 			))
+		} else {
+			action, threadHint := "post", "thread"
+			if m.scheduleOpen {
+				action = "schedule"
+			}
+			if m.thread {
+				threadHint = "single post"
+			}
+			hint := fmt.Sprintf("  %s: %s • %s: schedule • %s: %s • %s: cancel",
+				m.keys.Send.Help().Key, action, m.keys.Schedule.Help().Key,
+				m.keys.Thread.Help().Key, threadHint, m.keys.Cancel.Help().Key)
+			counter := m.counterView()
+			if counter != "" {
+				hint += " • "
+			}
+			b.WriteString(common.StatusBarStyle.Render(hint))
+			b.WriteString(counter)
 		}
 
 		return b.String()
@@ -50,3 +92,106 @@ func (m Model) View() string {
 
 	return ""
 }
+
+// scheduleView renders the schedule field with a preview of the parsed time.
+func (m Model) scheduleView() string {
+	line := "  Schedule: " + m.schedule.View()
+	if strings.TrimSpace(m.schedule.Value()) == "" {
+		return line
+	}
+	at, err := m.inlineScheduledAt()
+	if err != nil {
+		return line + "\n" + common.ErrorStyle.Render("  "+err.Error())
+	}
+	return line + "\n" + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555")).
+		Render("  → "+common.FormatScheduleTime(at))
+}
+
+// threadView previews how the inline text will be split.
+func (m Model) threadView() string {
+	parts := NumberThread(PlanThread(m.textarea.Value(), m.limits, m.tags()))
+	label := "  Thread: "
+	switch len(parts) {
+	case 0:
+		label += "write a long rant or separate posts with a --- line"
+	case 1:
+		label += "fits in a single post"
+	default:
+		sizes := make([]string, len(parts))
+		for i, p := range parts {
+			sizes[i] = strconv.Itoa(m.limits.PostLength(p, m.tags()))
+		}
+		label += fmt.Sprintf("%d posts (%s chars)", len(parts), strings.Join(sizes, " · "))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render(label)
+}
+
+// counterView renders the live character count as the instance will count
+// it: URLs as a fixed length, remote mentions by their local part, and the
+// required hashtag included. In thread mode the total is shown instead.
+func (m Model) counterView() string {
+	if m.unlimited {
+		return ""
+	}
+	// The status bar style pads above, so the counter uses its colour only
+	// to stay on the hint line.
+	style := lipgloss.NewStyle().Foreground(common.StatusBarStyle.GetForeground())
+	n := m.limits.PostLength(m.textarea.Value(), m.tags())
+	if m.thread {
+		return style.Render(fmt.Sprintf("%d chars", n))
+	}
+	if n > m.limits.MaxCharacters {
+		style = common.ErrorStyle
+	}
+	return style.Render(fmt.Sprintf("%d/%d chars", n, m.limits.MaxCharacters))
+}
+
+// completionView renders the autocomplete popup.
+func (m Model) completionView() string {
+	lines := make([]string, len(m.complete.items))
+	for i, s := range m.complete.items {
+		style, marker := common.ActionInactiveStyle, "  "
+		if i == m.complete.cursor {
+			style, marker = common.ActionActiveStyle, "> "
+		}
+		lines[i] = style.Render(marker + s.Value)
+		if s.Label != "" {
+			lines[i] += " " + common.MetadataStyle.Render(s.Label)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tagsView previews the hashtags the rant will carry once sent; "+" marks
+// those appended on send.
+// keptView lists what a redrafted rant keeps from the original, or "".
+func (m Model) keptView() string {
+	var kept []string
+	if m.opts.SpoilerText != "" {
+		kept = append(kept, "CW: "+m.opts.SpoilerText)
+	}
+	if m.opts.Visibility != "" {
+		kept = append(kept, m.opts.Visibility)
+	}
+	if n := len(m.opts.MediaIDs); n > 0 {
+		kept = append(kept, fmt.Sprintf("%d media", n))
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render("  Kept: " + strings.Join(kept, " • "))
+}
+
+func (m Model) tagsView() string {
+	content := m.textarea.Value()
+	tags := domain.HashtagsIn(content)
+	for _, t := range domain.MissingHashtags(content, m.tags()) {
+		tags = append(tags, "+"+t)
+	}
+	label := "  Tags: none"
+	if len(tags) > 0 {
+		label = "  Tags: " + strings.Join(tags, " ")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render(label)
+}
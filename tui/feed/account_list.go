@@ -0,0 +1,183 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
+)
+
+// accountListKind names the server list an accountList pages through.
+type accountListKind int
+
+const (
+	listFavouritedBy accountListKind = iota
+	listRebloggedBy
+	listFollowers
+	listFollowing
+	listFollowRequests
+)
+
+// accountListPageSize is how many accounts are requested per page.
+const accountListPageSize = 40
+
+// accountList is a paged list of accounts with a cursor.
+type accountList struct {
+	kind    accountListKind
+	ownerID string // Rant or account the list belongs to; "" for follow requests
+	items   []app.AccountSummary
+	cursor  int
+	next    string // maxID of the next page; "" when exhausted
+	loading bool
+	err     error
+	seq     int
+}
+
+// AccountsPageMsg carries one page of an account list.
+type AccountsPageMsg struct {
+	Kind     accountListKind
+	OwnerID  string
+	Seq      int
+	Accounts []app.AccountSummary
+	Next     string
+	Err      error
+}
+
+// reset starts the list over for kind and ownerID and returns the command
+// loading its first page.
+func (l *accountList) reset(m Model, kind accountListKind, ownerID string) tea.Cmd {
+	l.kind = kind
+	l.ownerID = ownerID
+	l.items = nil
+	l.cursor = 0
+	l.next = ""
+	l.err = nil
+	return l.load(m, "")
+}
+
+// load requests the page after maxID. A newer request makes older results
+// stale.
+func (l *accountList) load(m Model, maxID string) tea.Cmd {
+	l.loading = true
+	l.seq++
+	kind, ownerID, seq := l.kind, l.ownerID, l.seq
+	timeline, account := m.timelineFor(ownerID), m.account
+	return func() tea.Msg {
+		var (
+			accounts []app.AccountSummary
+			next     string
+			err      error
+		)
+		ctx := context.Background()
+		switch kind {
+		case listFavouritedBy:
+			accounts, next, err = timeline.FavouritedBy(ctx, ownerID, accountListPageSize, maxID)
+		case listRebloggedBy:
+			accounts, next, err = timeline.RebloggedBy(ctx, ownerID, accountListPageSize, maxID)
+		case listFollowers:
+			accounts, next, err = account.Followers(ctx, ownerID, accountListPageSize, maxID)
+		case listFollowing:
+			accounts, next, err = account.Following(ctx, ownerID, accountListPageSize, maxID)
+		case listFollowRequests:
+			accounts, next, err = account.FollowRequests(ctx, accountListPageSize, maxID)
+		}
+		return AccountsPageMsg{Kind: kind, OwnerID: ownerID, Seq: seq, Accounts: accounts, Next: next, Err: err}
+	}
+}
+
+// apply adds a page to the list, reporting false for stale pages.
+func (l *accountList) apply(msg AccountsPageMsg) bool {
+	if msg.Kind != l.kind || msg.OwnerID != l.ownerID || msg.Seq != l.seq {
+		return false
+	}
+	l.loading = false
+	l.err = msg.Err
+	if msg.Err != nil {
+		return true
+	}
+	l.items = append(l.items, msg.Accounts...)
+	l.next = msg.Next
+	return true
+}
+
+// move shifts the cursor by delta. Moving down past the last account loads
+// the next page, if there is one.
+func (l *accountList) move(m Model, delta int) tea.Cmd {
+	target := l.cursor + delta
+	if target >= len(l.items) {
+		if l.next != "" && !l.loading {
+			return l.load(m, l.next)
+		}
+		return nil
+	}
+	l.cursor = max(target, 0)
+	return nil
+}
+
+// remove drops the account with id, keeping the cursor in range.
+func (l *accountList) remove(id string) {
+	kept := l.items[:0]
+	for _, a := range l.items {
+		if a.ID != id {
+			kept = append(kept, a)
+		}
+	}
+	l.items = kept
+	if l.cursor >= len(l.items) && l.cursor > 0 {
+		l.cursor = len(l.items) - 1
+	}
+}
+
+// window is the range of accounts to draw in rows lines; see listWindow.
+func (l accountList) window(rows int) (start, end int) {
+	return listWindow(len(l.items), l.cursor, rows)
+}
+
+func (l accountList) selected() (app.AccountSummary, bool) {
+	if l.cursor < 0 || l.cursor >= len(l.items) {
+		return app.AccountSummary{}, false
+	}
+	return l.items[l.cursor], true
+}
+
+// dialogChromeLines is roughly how many lines a list dialog spends on the
+// title, crumb, border, headings and key hints around its rows.
+const dialogChromeLines = 22
+
+// listRows is how many rows of a list dialog fit on screen; 0 means all of
+// them, before the terminal size is known.
+func (m Model) listRows() int {
+	if m.height <= 0 {
+		return 0
+	}
+	return max(m.height-dialogChromeLines, 5)
+}
+
+// listWindow returns the range [start, end) of n rows to draw so the cursor
+// stays in view, keeping it centred once the list outgrows rows.
+func listWindow(n, cursor, rows int) (start, end int) {
+	if rows <= 0 || n <= rows {
+		return 0, n
+	}
+	start = min(max(cursor-rows/2, 0), n-rows)
+	return start, start + rows
+}
+
+// windowMarker notes the n rows hidden above or below a list window.
+func windowMarker(arrow string, n int) string {
+	return common.MetadataStyle.Render(fmt.Sprintf("%s %d more", arrow, n))
+}
+
+// accountIDs returns the IDs of accounts in page, for relationship lookups.
+func accountIDs(page []app.AccountSummary) []string {
+	ids := make([]string, 0, len(page))
+	for _, a := range page {
+		if a.ID != "" {
+			ids = append(ids, a.ID)
+		}
+	}
+	return ids
+}
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"os/exec"
 	"sort"
@@ -15,7 +16,7 @@ import (
 )
 
 func (m Model) fetchThread(id string) tea.Cmd {
-	timeline := m.timeline
+	timeline := m.timelineFor(id)
 	return func() tea.Msg {
 		ancestors, descendants, err := timeline.FetchThread(context.Background(), id)
 		if err != nil {
@@ -95,8 +96,15 @@ type DeleteRantMsg struct {
 	ID string
 }
 
+// RedraftRantMsg asks the app to delete an own rant and reopen its text in
+// the composer.
+type RedraftRantMsg struct {
+	Rant      domain.Rant
+	UseInline bool
+}
+
 func (m Model) fetchRants(reqSeq int) tea.Cmd {
-	timeline := m.timeline
+	timeline := m.feedTimeline()
 	account := m.account
 	hashtag := m.hashtag
 	defaultHashtag := m.defaultHashtag
@@ -115,6 +123,10 @@ func (m Model) fetchRants(reqSeq int) tea.Cmd {
 			rants, err = timeline.FetchByHashtag(context.Background(), hashtag, defaultLimit)
 		case sourceTrending:
 			rants, err = timeline.FetchTrendingPage(context.Background(), defaultLimit, "")
+		case sourceLocal:
+			rants, err = timeline.FetchLocalPage(context.Background(), defaultLimit, "")
+		case sourceFederated:
+			rants, err = timeline.FetchPublicPage(context.Background(), defaultLimit, "")
 		case sourceFollowing:
 			rants, err = timeline.FetchHomePage(context.Background(), defaultLimit, "")
 			if err == nil && len(rants) == 0 && len(recentFollows) > 0 && account != nil {
@@ -167,7 +179,7 @@ func (m Model) fetchOlderRants(reqSeq int) tea.Cmd {
 	if m.loading || !m.hasMoreFeed || m.oldestFeedID == "" {
 		return nil
 	}
-	timeline := m.timeline
+	timeline := m.feedTimeline()
 	hashtag := m.hashtag
 	defaultHashtag := m.defaultHashtag
 	source := m.feedSource
@@ -185,6 +197,10 @@ func (m Model) fetchOlderRants(reqSeq int) tea.Cmd {
 			rants, err = timeline.FetchByHashtagPage(context.Background(), hashtag, defaultLimit, maxID)
 		case sourceTrending:
 			rants, err = timeline.FetchTrendingPage(context.Background(), defaultLimit, maxID)
+		case sourceLocal:
+			rants, err = timeline.FetchLocalPage(context.Background(), defaultLimit, maxID)
+		case sourceFederated:
+			rants, err = timeline.FetchPublicPage(context.Background(), defaultLimit, maxID)
 		case sourceFollowing:
 			rants, err = timeline.FetchHomePage(context.Background(), defaultLimit, maxID)
 		}
@@ -217,7 +233,7 @@ func (m Model) fetchRelationshipsForRants(rants []domain.Rant) tea.Cmd {
 	seen := make(map[string]struct{}, len(rants))
 	for _, r := range rants {
 		id := strings.TrimSpace(r.AccountID)
-		if id == "" || r.IsOwn {
+		if id == "" || r.IsOwn || m.isRemoteRant(r) {
 			continue
 		}
 		if _, ok := seen[id]; ok {
@@ -226,13 +242,18 @@ func (m Model) fetchRelationshipsForRants(rants []domain.Rant) tea.Cmd {
 		seen[id] = struct{}{}
 		ids = append(ids, id)
 	}
-	if len(ids) == 0 {
+	return m.fetchRelationships(ids)
+}
+
+// fetchRelationships looks up how the user relates to each of ids.
+func (m Model) fetchRelationships(ids []string) tea.Cmd {
+	if m.account == nil || len(ids) == 0 {
 		return nil
 	}
 	acct := m.account
 	return func() tea.Msg {
-		following, err := acct.LookupFollowing(context.Background(), ids)
-		return RelationshipsLoadedMsg{Following: following, Err: err}
+		rels, err := acct.LookupRelationships(context.Background(), ids)
+		return RelationshipsLoadedMsg{Relationships: rels, Err: err}
 	}
 }
 
@@ -274,6 +295,26 @@ func (m Model) fetchProfile(accountID string) tea.Cmd {
 	}
 }
 
+// resolveLink looks link up as a post first, then as a profile.
+func (m Model) resolveLink(link string) tea.Cmd {
+	timeline, account := m.timeline, m.account
+	return func() tea.Msg {
+		r, err := timeline.ResolveStatus(context.Background(), link)
+		if err == nil {
+			return LinkResolvedMsg{URL: link, Rant: &r}
+		}
+		if !errors.Is(err, app.ErrNotFound) || account == nil {
+			return LinkResolvedMsg{URL: link, Err: err}
+		}
+		acct, err := account.ResolveAccount(context.Background(), link)
+		if err != nil {
+			return LinkResolvedMsg{URL: link, Err: err}
+		}
+		ownID, err := account.CurrentAccountID(context.Background())
+		return LinkResolvedMsg{URL: link, Account: acct, Own: err == nil && ownID != "" && ownID == acct.ID}
+	}
+}
+
 func (m Model) fetchOwnProfile() tea.Cmd {
 	if m.account == nil {
 		return nil
@@ -297,3 +338,81 @@ func (m Model) fetchOwnProfile() tea.Cmd {
 }
 
 // Rants returns the current rants for external access.
+
+func (m Model) fetchHistory(id string) tea.Cmd {
+	timeline := m.timelineFor(id)
+	return func() tea.Msg {
+		revisions, err := timeline.FetchHistory(context.Background(), id)
+		return HistoryLoadedMsg{ID: id, Revisions: revisions, Err: err}
+	}
+}
+
+// followedTagsPageSize is how many followed hashtags are requested per page.
+const followedTagsPageSize = 40
+
+func (m Model) fetchFollowedTags(seq int, maxID string) tea.Cmd {
+	if m.account == nil {
+		return nil
+	}
+	acct := m.account
+	return func() tea.Msg {
+		tags, next, err := acct.FollowedTags(context.Background(), followedTagsPageSize, maxID)
+		return FollowedTagsPageMsg{Seq: seq, Tags: tags, Next: next, Err: err}
+	}
+}
+
+// lookupTags fetches the follow state of names not seen yet.
+func (m Model) lookupTags(names []string) tea.Cmd {
+	var unknown []string
+	for _, name := range names {
+		if _, ok := m.followedTags[tagKey(name)]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if m.account == nil || len(unknown) == 0 {
+		return nil
+	}
+	acct := m.account
+	return func() tea.Msg {
+		tags := make([]app.Tag, 0, len(unknown))
+		for _, name := range unknown {
+			t, err := acct.LookupTag(context.Background(), strings.TrimPrefix(name, "#"))
+			if err != nil {
+				return TagsLookedUpMsg{Tags: tags, Err: err}
+			}
+			tags = append(tags, t)
+		}
+		return TagsLookedUpMsg{Tags: tags}
+	}
+}
+
+// trendsLimit is how many trending hashtags or links are requested; Mastodon
+// caps both at 20.
+const trendsLimit = 20
+
+func (m Model) fetchTrends(tab trendsTab, seq int) tea.Cmd {
+	timeline := m.timeline
+	return func() tea.Msg {
+		msg := TrendsLoadedMsg{Tab: tab, Seq: seq}
+		if tab == trendsTabLinks {
+			msg.Links, msg.Err = timeline.TrendingLinks(context.Background(), trendsLimit)
+		} else {
+			msg.Tags, msg.Err = timeline.TrendingTags(context.Background(), trendsLimit)
+		}
+		return msg
+	}
+}
+
+// reportPostsLimit is how many of the author's recent rants a report offers.
+const reportPostsLimit = 20
+
+func (m Model) fetchReportPosts(accountID string, seq int) tea.Cmd {
+	if m.account == nil {
+		return nil
+	}
+	acct := m.account
+	return func() tea.Msg {
+		posts, err := acct.PostsByAccount(context.Background(), accountID, reportPostsLimit, "")
+		return ReportPostsLoadedMsg{Seq: seq, Posts: posts, Err: err}
+	}
+}
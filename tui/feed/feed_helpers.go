@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 	"github.com/CrestNiraj12/terminalrant/tui/common"
 )
@@ -52,6 +53,46 @@ func (m Model) canDeleteRant(r domain.Rant) bool {
 	return false
 }
 
+// openProfile shows the profile view for accountID and starts loading it.
+func (m Model) openProfile(accountID string, isOwn bool) (Model, tea.Cmd) {
+	m.startProfile(isOwn)
+	return m, m.fetchProfile(accountID)
+}
+
+// startProfile shows an empty, loading profile view on its posts tab.
+func (m *Model) startProfile(isOwn bool) {
+	m.showProfile = true
+	m.profileIsOwn = isOwn
+	m.profileLoading = true
+	m.profileErr = nil
+	m.profile = app.Profile{}
+	m.profilePosts = nil
+	m.profileCursor = 0
+	m.profileStart = 0
+	m.detailScrollLine = 0
+	m.profileTab = profileTabPosts
+	m.profileAccounts = accountList{seq: m.profileAccounts.seq}
+}
+
+// leaveDetailAfterDelete closes the detail view, returning to the profile it
+// was opened from, once its post is being deleted.
+func (m *Model) leaveDetailAfterDelete() {
+	if !m.showDetail {
+		return
+	}
+	if m.returnToProfile {
+		m.showProfile = true
+	}
+	m.showDetail = false
+	m.returnToProfile = false
+	m.focusedRant = nil
+	m.viewStack = nil
+	m.detailCursor = 0
+	m.detailStart = 0
+	m.detailScrollLine = 0
+	m.loadingReplies = false
+}
+
 func (m *Model) removeRantByID(id string) {
 	id = strings.TrimSpace(id)
 	if id == "" {
@@ -154,7 +195,7 @@ func (m Model) feedChromeLines() int {
 	if len(m.rants) > 0 {
 		bottom += 2
 	}
-	if m.hashtagInput {
+	if m.hashtagInput || m.instanceInput || m.linkInput {
 		bottom++
 	}
 	bottom += lineCount(m.helpView())
@@ -265,10 +306,14 @@ func (m Model) feedVisibleSpans(visible []int) []feedItemSpan {
 		return nil
 	}
 	cardWidth, bodyWidth := m.feedCardWidthsForModel()
+	dividerID := m.readDividerID()
 	spans := make([]feedItemSpan, 0, len(visible))
 	linePos := 0
 	for i, idx := range visible {
 		lines := m.feedItemRenderedLines(m.rants[idx].Rant, cardWidth, bodyWidth)
+		if dividerID != "" && m.rants[idx].Rant.ID == dividerID {
+			lines++ // read divider above the card
+		}
 		top := linePos
 		bottom := top + lines - 1
 		spans = append(spans, feedItemSpan{
@@ -441,14 +486,23 @@ func (m *Model) restoreFeedTopAnchor(id string, offset int) {
 }
 
 func (m Model) currentFeedQueryKey() string {
+	key := ""
 	switch m.feedSource {
 	case sourceTrending:
-		return "trending"
+		key = "trending"
 	case sourceFollowing:
-		return "following"
+		key = "following"
 	case sourceCustomHashtag:
-		return "tag:" + strings.ToLower(strings.TrimSpace(m.hashtag))
+		key = "tag:" + strings.ToLower(strings.TrimSpace(m.hashtag))
+	case sourceLocal:
+		key = "local"
+	case sourceFederated:
+		key = "federated"
 	default:
-		return "tag:" + strings.ToLower(strings.TrimSpace(m.defaultHashtag))
+		key = "tag:" + strings.ToLower(strings.TrimSpace(m.defaultHashtag))
+	}
+	if m.remote != nil {
+		key = "remote:" + m.remoteHost + ":" + key
 	}
+	return key
 }
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
 
@@ -224,6 +226,59 @@ func TestFollowToggleResultInFollowing_RefreshesFeedState(t *testing.T) {
 	}
 }
 
+func TestRelationships_BadgesAndPendingRequest(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.showProfile = true
+	m.profile = appProfile("42", "dave")
+	m.profile.Followers = 3
+
+	m, _ = m.Update(RelationshipsLoadedMsg{Relationships: map[string]app.Relationship{
+		"42": {ID: "42", FollowedBy: true, Muting: true, Note: "from the meetup"},
+	}})
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"[follows you] [muted]", "Note: from the meetup", "Follow: not following"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in profile view:\n%s", want, view)
+		}
+	}
+
+	// A locked account answers a follow with a pending request.
+	m, _ = m.Update(FollowToggleResultMsg{AccountID: "42", Username: "dave", Follow: true,
+		Relationship: app.Relationship{ID: "42", FollowedBy: true, Requested: true}})
+	if m.isFollowing("42") || m.profile.Followers != 3 {
+		t.Fatalf("a pending request must not count as following")
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Follow: requested") {
+		t.Fatalf("expected requested state:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("f"))
+	if !m.confirmFollow || m.followTarget {
+		t.Fatalf("expected confirmation to withdraw the request")
+	}
+	if got := m.followPrompt(); got != "Cancel follow request to @dave? (y/n)" {
+		t.Fatalf("unexpected prompt %q", got)
+	}
+	_, cmd := m.Update(keyRunes("y"))
+	if msg, ok := cmd().(FollowToggleMsg); !ok || msg.Follow {
+		t.Fatalf("expected unfollow to withdraw the request, got %#v", msg)
+	}
+}
+
+func TestRelationships_FollowsYouBadgeOnFeedCard(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.width = 120
+	m.height = 40
+	m.loading = false
+	m.rants = []RantItem{{Rant: makeRant("id-1", time.Now(), "acct-a"), Status: StatusNormal}}
+	m, _ = m.Update(RelationshipsLoadedMsg{Relationships: map[string]app.Relationship{
+		"acct-a": {ID: "acct-a", FollowedBy: true},
+	}})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "follows you") {
+		t.Fatalf("expected follows-you badge on feed card:\n%s", view)
+	}
+}
+
 func TestNoLoadMoreWhileInitialFeedLoading(t *testing.T) {
 	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
 	m.width = 100
@@ -329,11 +384,29 @@ func TestNextFeedSource_OrderIncludesCustomLast(t *testing.T) {
 		t.Fatalf("next from trending: got %v", got)
 	}
 	m.feedSource = sourceFollowing
-	if got := m.nextFeedSource(1); got != sourceCustomHashtag {
+	if got := m.nextFeedSource(1); got != sourceLocal {
 		t.Fatalf("next from following: got %v", got)
 	}
+	m.feedSource = sourceFederated
+	if got := m.nextFeedSource(1); got != sourceCustomHashtag {
+		t.Fatalf("next from federated: got %v", got)
+	}
 	m.feedSource = sourceCustomHashtag
 	if got := m.nextFeedSource(1); got != sourceTerminalRant {
 		t.Fatalf("next from custom: got %v", got)
 	}
 }
+
+func TestWithTabs_LimitsAndOrdersTabs(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "trending").WithTabs([]string{"following", "terminalrant"})
+	if m.feedSource != sourceFollowing {
+		t.Fatalf("expected an unlisted start tab to give way to the first tab, got %v", m.feedSource)
+	}
+	if got := m.nextFeedSource(1); got != sourceTerminalRant {
+		t.Fatalf("next from following: got %v", got)
+	}
+	m.feedSource = sourceTerminalRant
+	if got := m.nextFeedSource(1); got != sourceFollowing {
+		t.Fatalf("expected tabs to wrap to following, got %v", got)
+	}
+}
@@ -63,6 +63,14 @@ func (m Model) previewSizing(total int) (asciiW, asciiH, tileW, tileH int) {
 	return previewASCIIWidth, previewASCIIHeight, previewTileWidth, previewTileHeight
 }
 
+// WithMedia sets whether media previews start shown and whether animated
+// media plays or shows a still frame.
+func (m Model) WithMedia(previews, animate bool) Model {
+	m.showMediaPreview = previews
+	m.animateMedia = animate
+	return m
+}
+
 func (m *Model) ensureMediaPreviewCmd() tea.Cmd {
 	if m.showProfile {
 		return m.ensureProfileAvatarPreviewCmd()
@@ -93,7 +101,7 @@ func (m *Model) ensureMediaPreviewCmd() tea.Cmd {
 			continue
 		}
 		m.mediaLoading[baseKey] = true
-		cmds = append(cmds, fetchMediaPreview(target.URL, target.FallbackURL, baseKey, asciiW, asciiH, target.Animated))
+		cmds = append(cmds, fetchMediaPreview(target.URL, target.FallbackURL, baseKey, asciiW, asciiH, target.Animated && m.animateMedia))
 	}
 	if len(cmds) == 0 {
 		return nil
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -67,10 +68,11 @@ type FollowToggleMsg struct {
 }
 
 type FollowToggleResultMsg struct {
-	AccountID string
-	Username  string
-	Follow    bool
-	Err       error
+	AccountID    string
+	Username     string
+	Follow       bool
+	Relationship app.Relationship // As reported by the server; empty ID if unknown
+	Err          error
 }
 
 type HideAuthorPostsMsg struct {
@@ -80,8 +82,9 @@ type HideAuthorPostsMsg struct {
 type RequestBlockedUsersMsg struct{}
 
 type BlockedUsersLoadedMsg struct {
-	Users []app.BlockedUser
-	Err   error
+	Users   []app.BlockedUser
+	Domains []string
+	Err     error
 }
 
 type UnblockUserMsg struct {
@@ -95,11 +98,238 @@ type UnblockResultMsg struct {
 	Err       error
 }
 
-type RelationshipsLoadedMsg struct {
-	Following map[string]bool
+type BlockDomainMsg struct {
+	Domain string
+}
+
+type UnblockDomainMsg struct {
+	Domain string
+}
+
+type DomainBlockResultMsg struct {
+	Domain string
+	Block  bool
+	Err    error
+}
+
+// ResolveFollowRequestMsg asks to accept or reject a follow request.
+type ResolveFollowRequestMsg struct {
+	AccountID string
+	Username  string
+	Authorize bool
+}
+
+type FollowRequestResolvedMsg struct {
+	AccountID string
+	Username  string
+	Authorize bool
+	Err       error
+}
+
+// RequestScheduledPostsMsg asks for the page of scheduled posts after MaxID.
+type RequestScheduledPostsMsg struct {
+	Seq   int
+	MaxID string
+}
+
+// ScheduledPostsLoadedMsg carries one page of the scheduled posts manager.
+type ScheduledPostsLoadedMsg struct {
+	Seq   int
+	Posts []app.ScheduledPost
+	Next  string
+	Err   error
+}
+
+type RescheduleScheduledMsg struct {
+	ID string
+	At time.Time
+}
+
+type RescheduleResultMsg struct {
+	Post app.ScheduledPost
+	Err  error
+}
+
+type CancelScheduledMsg struct {
+	ID string
+}
+
+type CancelScheduledResultMsg struct {
+	ID  string
+	Err error
+}
+
+type RequestDraftsMsg struct{}
+
+type DraftsLoadedMsg struct {
+	Drafts []app.Draft
+	Err    error
+}
+
+// ResumeDraftMsg asks the app to reopen a draft in the composer.
+type ResumeDraftMsg struct {
+	Draft     app.Draft
+	UseInline bool
+}
+
+type DuplicateDraftMsg struct {
+	Draft app.Draft
+}
+
+type DuplicateDraftResultMsg struct {
+	Draft app.Draft
+	Err   error
+}
+
+type DiscardDraftMsg struct {
+	ID string
+}
+
+type DiscardDraftResultMsg struct {
+	ID  string
+	Err error
+}
+
+// HistoryLoadedMsg carries the revisions of an edited rant.
+type HistoryLoadedMsg struct {
+	ID        string
+	Revisions []app.Revision
+	Err       error
+}
+
+// FollowedTagsPageMsg carries one page of the followed-tags manager.
+type FollowedTagsPageMsg struct {
+	Seq  int
+	Tags []app.Tag
+	Next string
+	Err  error
+}
+
+// TagsLookedUpMsg reports the follow state of hashtags shown in detail.
+type TagsLookedUpMsg struct {
+	Tags []app.Tag
+	Err  error
+}
+
+// ToggleTagFollowMsg asks to follow or unfollow a hashtag.
+type ToggleTagFollowMsg struct {
+	Name   string // without the leading #
+	Follow bool
+}
+
+type TagFollowResultMsg struct {
+	Name   string
+	Follow bool
+	Tag    app.Tag
+	Err    error
+}
+
+// TrendsLoadedMsg carries trending hashtags or, for the links tab, links.
+type TrendsLoadedMsg struct {
+	Tab   trendsTab
+	Seq   int
+	Tags  []app.TrendingTag
+	Links []app.TrendingLink
+	Err   error
+}
+
+// RantResolvedMsg carries the user's instance copy of a remotely browsed
+// rant, and the key that asked for it, to replay once it is in place.
+type RantResolvedMsg struct {
+	RemoteID string
+	Rant     domain.Rant
+	Key      tea.KeyMsg
+	Err      error
+}
+
+// MarkersLoadedMsg carries the read positions saved on the server.
+type MarkersLoadedMsg struct {
+	Markers map[app.MarkerTimeline]app.Marker
+	Err     error
+}
+
+// markerSaveMsg fires once the read position of timeline has settled.
+type markerSaveMsg struct {
+	timeline app.MarkerTimeline
+	seq      int
+}
+
+// MarkerSavedMsg reports the outcome of saving the read position.
+type MarkerSavedMsg struct {
+	Err error
+}
+
+// homeUnreadMsg carries the unread count of the home timeline, fetched while
+// another tab is shown.
+type homeUnreadMsg struct {
+	count int
+	err   error
+}
+
+// notificationsUnreadMsg carries the unread count of the notifications
+// timeline, fetched once the markers are known.
+type notificationsUnreadMsg struct {
+	count int
+	err   error
+}
+
+// NotificationsPageMsg carries one page of the notifications dialog.
+type NotificationsPageMsg struct {
+	Seq           int
+	Notifications []app.Notification
+	Next          string
+	Err           error
+}
+
+// OpenLinkMsg asks the feed to open the post or profile at URL.
+type OpenLinkMsg struct {
+	URL string
+}
+
+// LinkResolvedMsg carries what a pasted link points at: a post, or else an
+// account.
+type LinkResolvedMsg struct {
+	URL     string
+	Rant    *domain.Rant
+	Account app.AccountSummary
+	Own     bool // Account is the signed-in user
+	Err     error
+}
+
+// RequestReportRulesMsg asks for the instance rules a report can cite.
+type RequestReportRulesMsg struct {
+	Seq int
+}
+
+type ReportRulesLoadedMsg struct {
+	Seq   int
+	Rules []app.Rule
+	Err   error
+}
+
+// ReportPostsLoadedMsg carries the reported author's recent rants.
+type ReportPostsLoadedMsg struct {
+	Seq   int
+	Posts []domain.Rant
+	Err   error
+}
+
+type SubmitReportMsg struct {
+	Report   app.Report
+	Username string
+}
+
+type ReportResultMsg struct {
+	AccountID string
+	Username  string
 	Err       error
 }
 
+type RelationshipsLoadedMsg struct {
+	Relationships map[string]app.Relationship
+	Err           error
+}
+
 type OpenProfileMsg struct {
 	AccountID string
 }
@@ -187,6 +417,8 @@ const (
 	sourceTrending
 	sourceFollowing
 	sourceCustomHashtag
+	sourceLocal     // public posts made on the instance
+	sourceFederated // public posts the instance knows of
 )
 
 type FeedPrefsChangedMsg struct {
@@ -205,6 +437,7 @@ type ResultMsg struct {
 	IsEdit     bool
 	Err        error
 	OldContent string
+	DraftID    string // Draft holding the content, discarded on success
 }
 
 // --- Optimistic Update Messages ---
@@ -213,6 +446,25 @@ type AddOptimisticRantMsg struct {
 	Content string
 }
 
+// AddOptimisticThreadMsg shows every post of a new thread as pending.
+type AddOptimisticThreadMsg struct {
+	LocalIDs []string
+	Parts    []string
+}
+
+// ThreadPartResultMsg reconciles one post of a thread with the server.
+type ThreadPartResultMsg struct {
+	LocalID string
+	Rant    domain.Rant
+	Err     error
+}
+
+// ThreadAbortedMsg marks thread posts that were not sent after a failure.
+type ThreadAbortedMsg struct {
+	LocalIDs []string
+	Err      error
+}
+
 type UpdateOptimisticRantMsg struct {
 	ID      string
 	Content string
@@ -246,6 +498,10 @@ type RantItem struct {
 type modelServices struct {
 	timeline app.TimelineService
 	account  app.AccountService
+	// remoteTimelines opens another instance for anonymous browsing.
+	remoteTimelines func(instanceURL string) app.TimelineService
+	markers         app.MarkerService
+	notifications   app.NotificationService
 }
 
 type feedState struct {
@@ -261,6 +517,7 @@ type feedState struct {
 	err            error
 	pagingNotice   string
 	feedReqSeq     int
+	tabs           []feedSource // Configured tab order; nil shows every tab
 }
 
 type uiState struct {
@@ -277,6 +534,8 @@ type uiState struct {
 type detailState struct {
 	confirmDelete    bool // Whether we are in the 'Are you sure?' delete step
 	deleteTargetID   string
+	redraftRant      *domain.Rant // Set when the pending delete is a redraft
+	redraftInline    bool
 	showDetail       bool // Whether we are in full-post view
 	ancestors        []domain.Rant
 	replies          []domain.Rant
@@ -306,6 +565,157 @@ type moderationState struct {
 	blockedCursor  int
 	confirmUnblock bool
 	unblockTarget  app.BlockedUser
+	unblockDomain  string
+	blockDomain    string          // set while confirmBlock asks about a whole domain
+	blockedDomains map[string]bool // lowercased; their posts are filtered locally
+	domainBlocks   []string        // listed in the blocked users dialog
+}
+
+type scheduleState struct {
+	showScheduled          bool
+	loadingScheduled       bool
+	scheduledErr           error
+	scheduled              []app.ScheduledPost
+	scheduledCursor        int
+	scheduledNext          string // maxID of the next page; "" when exhausted
+	scheduledSeq           int
+	confirmCancelScheduled bool
+	rescheduleInput        bool
+	rescheduleBuffer       string
+}
+
+type draftsState struct {
+	showDrafts          bool
+	loadingDrafts       bool
+	draftsErr           error
+	drafts              []app.Draft
+	draftCursor         int
+	confirmDiscardDraft bool
+}
+
+type historyState struct {
+	showHistory    bool
+	loadingHistory bool
+	historyErr     error
+	historyID      string
+	history        []app.Revision
+	historyCursor  int  // Revision whose changes are shown
+	historyByLine  bool // Line diff instead of word diff
+}
+
+type followRequestsState struct {
+	showFollowRequests bool
+	followRequests     accountList // Pending requests to follow the user
+	// Waiting for y/n before rejecting the selected request.
+	confirmRejectRequest bool
+}
+
+type tagFollowState struct {
+	followedTags map[string]bool // Lowercase tag name -> followed, as last seen
+
+	// Detail view: picking one of the post's hashtag capsules.
+	tagSelect bool
+	tagCursor int
+
+	// Followed-tags manager dialog.
+	showFollowedTags bool
+	tagList          []app.Tag
+	tagListCursor    int
+	tagListNext      string
+	tagListLoading   bool
+	tagListErr       error
+	tagListSeq       int
+}
+
+// trendsTab is the list shown in the trends dialog.
+type trendsTab int
+
+const (
+	trendsTabTags trendsTab = iota
+	trendsTabLinks
+)
+
+type trendsState struct {
+	showTrends    bool
+	trendsTab     trendsTab
+	trendTags     []app.TrendingTag
+	trendLinks    []app.TrendingLink
+	trendsCursor  int
+	trendsLoading bool
+	trendsErr     error
+	trendsSeq     int
+}
+
+type reportState struct {
+	showReport       bool
+	reportAccountID  string
+	reportUsername   string
+	reportCategory   int // index into app.ReportCategories
+	reportRules      []app.Rule
+	reportRuleIDs    map[string]bool
+	reportPosts      []domain.Rant // rants by the author that can be attached
+	reportStatusIDs  map[string]bool
+	reportComment    string
+	reportEditing    bool // typing the comment
+	reportForward    bool
+	reportCursor     int // category, then rules (violation only), then posts
+	reportLoading    bool
+	reportSubmitting bool
+	reportErr        error
+	reportSeq        int
+}
+
+// remoteState is the read-only browsing of another instance's public and
+// hashtag timelines.
+type remoteState struct {
+	remote         app.TimelineService // set while browsing
+	remoteHost     string
+	remoteReturn   feedSource // tab to go back to when browsing ends
+	instanceInput  bool
+	instanceBuffer string
+	// resolvedIDs holds rants that were browsed remotely and then replaced
+	// by their copy on the user's instance.
+	resolvedIDs map[string]bool
+}
+
+// markerState is the read position in the following tab, kept on the server
+// so it survives restarts.
+type markerState struct {
+	markersLoaded bool
+	homeReadID    string // newest read post of the home timeline
+	restoreRead   bool   // move to homeReadID once the following tab loads
+	restorePages  int    // older pages fetched while looking for homeReadID
+	homeUnread    int    // unread count of the following tab while another tab is shown
+	markerSeq     int    // debounces saves
+}
+
+// notificationsState is the notifications dialog and its read position,
+// kept on the server like the home timeline's.
+type notificationsState struct {
+	showNotifications    bool
+	notificationList     []app.Notification
+	notificationCursor   int
+	notificationsNext    string // maxID of the next page; "" when exhausted
+	notificationsLoading bool
+	notificationsErr     error
+	notificationsSeq     int
+	notificationsReadID  string // newest read notification
+	restoreNotifications bool   // move to notificationsReadID once it loads
+	notificationPages    int    // older pages fetched while looking for it
+	notificationsUnread  int    // unread count while the dialog is closed
+	notificationSaveSeq  int    // debounces saves
+}
+
+// linkState is the "go to URL" prompt and the link being looked up.
+type linkState struct {
+	linkInput   bool
+	linkBuffer  string
+	openingLink string // URL whose lookup is in flight
+}
+
+type interactionsState struct {
+	showInteractions bool
+	interactions     accountList // Who liked or boosted a rant
 }
 
 type relationshipState struct {
@@ -314,6 +724,7 @@ type relationshipState struct {
 	followUsername  string
 	followTarget    bool
 	followingByID   map[string]bool
+	relationships   map[string]app.Relationship // Full relationship, when looked up
 	recentFollows   []string
 	followingDirty  bool
 }
@@ -331,12 +742,24 @@ type profileState struct {
 	profileErr      error
 	profile         app.Profile
 	profilePosts    []domain.Rant
-	profileCursor   int // 0 for profile card, 1...n for profile posts
+	profileCursor   int // 0 for profile card, 1...n for profile posts or accounts
 	profileStart    int // first visible profile post
+	profileTab      profileTab
+	profileAccounts accountList // Followers or following, on those tabs
 }
 
+// profileTab is the list shown under the profile card.
+type profileTab int
+
+const (
+	profileTabPosts profileTab = iota
+	profileTabFollowers
+	profileTabFollowing
+)
+
 type mediaState struct {
 	showMediaPreview bool
+	animateMedia     bool
 	mediaPreview     map[string]string
 	mediaFrames      map[string][]string
 	mediaFrameIndex  map[string]int
@@ -350,6 +773,18 @@ type Model struct {
 	uiState
 	detailState
 	moderationState
+	scheduleState
+	draftsState
+	historyState
+	interactionsState
+	followRequestsState
+	tagFollowState
+	trendsState
+	reportState
+	remoteState
+	linkState
+	markerState
+	notificationsState
 	relationshipState
 	hashtagState
 	profileState
@@ -390,14 +825,23 @@ func New(timeline app.TimelineService, account app.AccountService, hashtag, init
 			threadCache: make(map[string]threadData),
 		},
 		moderationState: moderationState{
-			hiddenIDs:     make(map[string]bool),
-			hiddenAuthors: make(map[string]bool),
+			hiddenIDs:      make(map[string]bool),
+			hiddenAuthors:  make(map[string]bool),
+			blockedDomains: make(map[string]bool),
 		},
 		relationshipState: relationshipState{
 			followingByID: make(map[string]bool),
+			relationships: make(map[string]app.Relationship),
+		},
+		tagFollowState: tagFollowState{
+			followedTags: make(map[string]bool),
+		},
+		remoteState: remoteState{
+			resolvedIDs: make(map[string]bool),
 		},
 		mediaState: mediaState{
 			showMediaPreview: true,
+			animateMedia:     true,
 			mediaPreview:     make(map[string]string),
 			mediaFrames:      make(map[string][]string),
 			mediaFrameIndex:  make(map[string]int),
@@ -406,11 +850,19 @@ func New(timeline app.TimelineService, account app.AccountService, hashtag, init
 	}
 }
 
+// WithKeys replaces the default key bindings, e.g. with the user's remapped
+// ones.
+func (m Model) WithKeys(keys common.KeyMap) Model {
+	m.keys = keys
+	return m
+}
+
 // Init starts the initial feed fetch.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.fetchRants(m.feedReqSeq),
 		m.spinner.Tick,
+		m.fetchMarkers(),
 	)
 }
 
@@ -22,12 +22,42 @@ func (stubTimeline) FetchHomePage(context.Context, int, string) ([]domain.Rant,
 func (stubTimeline) FetchPublicPage(context.Context, int, string) ([]domain.Rant, error) {
 	return nil, nil
 }
+func (stubTimeline) FetchLocalPage(context.Context, int, string) ([]domain.Rant, error) {
+	return nil, nil
+}
 func (stubTimeline) FetchTrendingPage(context.Context, int, string) ([]domain.Rant, error) {
 	return nil, nil
 }
+func (stubTimeline) FetchBookmarksPage(context.Context, int, string) ([]domain.Rant, string, error) {
+	return nil, "", nil
+}
+func (stubTimeline) FetchFavouritesPage(context.Context, int, string) ([]domain.Rant, string, error) {
+	return nil, "", nil
+}
+func (stubTimeline) FetchStatus(context.Context, string) (domain.Rant, error) {
+	return domain.Rant{}, nil
+}
+func (stubTimeline) ResolveStatus(context.Context, string) (domain.Rant, error) {
+	return domain.Rant{}, nil
+}
 func (stubTimeline) FetchThread(context.Context, string) ([]domain.Rant, []domain.Rant, error) {
 	return nil, nil, nil
 }
+func (stubTimeline) FetchHistory(context.Context, string) ([]app.Revision, error) {
+	return nil, nil
+}
+func (stubTimeline) FavouritedBy(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return nil, "", nil
+}
+func (stubTimeline) RebloggedBy(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return nil, "", nil
+}
+func (stubTimeline) TrendingTags(context.Context, int) ([]app.TrendingTag, error) {
+	return nil, nil
+}
+func (stubTimeline) TrendingLinks(context.Context, int) ([]app.TrendingLink, error) {
+	return nil, nil
+}
 
 type stubAccount struct{}
 
@@ -37,10 +67,20 @@ func (stubAccount) UpdateProfile(context.Context, string, string) error
 func (stubAccount) BlockUser(context.Context, string) error                          { return nil }
 func (stubAccount) ListBlockedUsers(context.Context, int) ([]app.BlockedUser, error) { return nil, nil }
 func (stubAccount) UnblockUser(context.Context, string) error                        { return nil }
-func (stubAccount) FollowUser(context.Context, string) error                         { return nil }
-func (stubAccount) UnfollowUser(context.Context, string) error                       { return nil }
-func (stubAccount) LookupFollowing(context.Context, []string) (map[string]bool, error) {
-	return map[string]bool{}, nil
+func (stubAccount) ListDomainBlocks(context.Context, int) ([]string, error)          { return nil, nil }
+func (stubAccount) BlockDomain(context.Context, string) error                        { return nil }
+func (stubAccount) UnblockDomain(context.Context, string) error                      { return nil }
+func (stubAccount) ResolveAccount(context.Context, string) (app.AccountSummary, error) {
+	return app.AccountSummary{}, app.ErrNotFound
+}
+func (stubAccount) FollowUser(_ context.Context, id string) (app.Relationship, error) {
+	return app.Relationship{ID: id, Following: true}, nil
+}
+func (stubAccount) UnfollowUser(_ context.Context, id string) (app.Relationship, error) {
+	return app.Relationship{ID: id}, nil
+}
+func (stubAccount) LookupRelationships(context.Context, []string) (map[string]app.Relationship, error) {
+	return map[string]app.Relationship{}, nil
 }
 func (stubAccount) ProfileByID(context.Context, string) (app.Profile, error) {
 	return app.Profile{}, nil
@@ -49,6 +89,30 @@ func (stubAccount) PostsByAccount(context.Context, string, int, string) ([]domai
 	return nil, nil
 }
 
+func (stubAccount) Followers(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return nil, "", nil
+}
+func (stubAccount) Following(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return nil, "", nil
+}
+func (stubAccount) FollowRequests(context.Context, int, string) ([]app.AccountSummary, string, error) {
+	return nil, "", nil
+}
+func (stubAccount) AuthorizeFollowRequest(context.Context, string) error { return nil }
+func (stubAccount) RejectFollowRequest(context.Context, string) error    { return nil }
+func (stubAccount) FollowedTags(context.Context, int, string) ([]app.Tag, string, error) {
+	return nil, "", nil
+}
+func (stubAccount) LookupTag(_ context.Context, name string) (app.Tag, error) {
+	return app.Tag{Name: name}, nil
+}
+func (stubAccount) FollowTag(_ context.Context, name string) (app.Tag, error) {
+	return app.Tag{Name: name, Following: true}, nil
+}
+func (stubAccount) UnfollowTag(_ context.Context, name string) (app.Tag, error) {
+	return app.Tag{Name: name}, nil
+}
+
 func makeRant(id string, createdAt time.Time, accountID string) domain.Rant {
 	return domain.Rant{
 		ID:        id,
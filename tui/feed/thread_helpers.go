@@ -208,7 +208,11 @@ func (m Model) isMarkedHidden(r domain.Rant) bool {
 	if m.hiddenIDs[r.ID] {
 		return true
 	}
-	if r.AccountID != "" && m.hiddenAuthors[r.AccountID] {
+	// Account IDs of another instance say nothing about accounts here.
+	if r.AccountID != "" && m.hiddenAuthors[r.AccountID] && !m.isRemoteRant(r) {
+		return true
+	}
+	if _, domain := splitUsernameDomain(r.Username); domain != "" && m.blockedDomains[strings.ToLower(domain)] {
 		return true
 	}
 	return false
@@ -348,6 +352,27 @@ func (m *Model) reconcileReplyResult(localID string, server domain.Rant) {
 	}
 }
 
+// dropLocalReplies removes optimistic replies that will never be sent.
+func (m *Model) dropLocalReplies(ids map[string]bool) {
+	keep := func(list []domain.Rant) []domain.Rant {
+		out := list[:0]
+		for _, r := range list {
+			if !ids[r.ID] {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+	m.replyAll = keep(m.replyAll)
+	m.replies = m.replyAll
+	m.replyVisible = min(m.replyVisible, len(m.replyAll))
+	threadID := m.currentThreadRootID()
+	if data, ok := m.threadCache[threadID]; ok {
+		data.Descendants = keep(data.Descendants)
+		m.threadCache[threadID] = data
+	}
+}
+
 type AddOptimisticReplyMsg struct {
 	LocalID  string
 	ParentID string
@@ -23,6 +23,10 @@ func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg.(type) {
 	case SwitchToTerminalRantMsg:
+		if m.remote != nil {
+			m.remoteReturn = sourceTerminalRant
+			return m.leaveRemote()
+		}
 		if m.feedSource == sourceTerminalRant {
 			return m, nil
 		}
@@ -32,15 +36,45 @@ func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 		m.feedReqSeq++
 		return m, tea.Batch(m.fetchRants(m.feedReqSeq), m.emitPrefsChanged())
 	case RantsLoadedMsg, RantsErrorMsg, RantsPageLoadedMsg, RantsPageErrorMsg:
-		return m.handleFeedLoadingMsg(msg)
+		m, cmd = m.handleFeedLoadingMsg(msg)
+		restore := m.restoreReadPosition()
+		m.noteHomeUnread()
+		return m, tea.Batch(cmd, restore)
 	case ResetFeedStateMsg, OpenDetailWithoutRepliesMsg, ThreadLoadedMsg, ThreadErrorMsg, MediaPreviewLoadedMsg:
 		return m.handleDetailThreadMsg(msg)
-	case HideAuthorPostsMsg, BlockResultMsg, RelationshipsLoadedMsg, ProfileLoadedMsg, FollowToggleResultMsg, BlockedUsersLoadedMsg, UnblockResultMsg:
+	case HideAuthorPostsMsg, BlockResultMsg, RelationshipsLoadedMsg, ProfileLoadedMsg, FollowToggleResultMsg, BlockedUsersLoadedMsg, UnblockResultMsg, DomainBlockResultMsg:
 		return m.handleProfileBlockFollowMsg(msg)
-	case AddOptimisticRantMsg, AddOptimisticReplyMsg, LikeRantMsg, LikeResultMsg, UpdateOptimisticRantMsg, DeleteOptimisticRantMsg, ResultMsg, DeleteResultMsg:
+	case ScheduledPostsLoadedMsg, RescheduleResultMsg, CancelScheduledResultMsg:
+		return m.handleScheduledMsg(msg)
+	case DraftsLoadedMsg, DuplicateDraftResultMsg, DiscardDraftResultMsg:
+		return m.handleDraftsMsg(msg)
+	case HistoryLoadedMsg:
+		return m.handleHistoryMsg(msg)
+	case AccountsPageMsg:
+		return m.handleAccountsPageMsg(msg)
+	case FollowRequestResolvedMsg:
+		return m.handleFollowRequestsMsg(msg)
+	case FollowedTagsPageMsg, TagsLookedUpMsg, TagFollowResultMsg:
+		return m.handleTagFollowMsg(msg)
+	case TrendsLoadedMsg:
+		return m.handleTrendsMsg(msg)
+	case RantResolvedMsg:
+		return m.handleRemoteMsg(msg)
+	case OpenLinkMsg, LinkResolvedMsg:
+		return m.handleLinkMsg(msg)
+	case MarkersLoadedMsg, markerSaveMsg, MarkerSavedMsg, homeUnreadMsg:
+		return m.handleMarkerMsg(msg)
+	case NotificationsPageMsg, notificationsUnreadMsg:
+		return m.handleNotificationsMsg(msg)
+	case ReportRulesLoadedMsg, ReportPostsLoadedMsg, ReportResultMsg:
+		return m.handleReportMsg(msg)
+	case AddOptimisticRantMsg, AddOptimisticReplyMsg, AddOptimisticThreadMsg, ThreadPartResultMsg, ThreadAbortedMsg, LikeRantMsg, LikeResultMsg, UpdateOptimisticRantMsg, DeleteOptimisticRantMsg, ResultMsg, DeleteResultMsg:
 		return m.handleOptimisticMsg(msg)
 	case tea.KeyMsg:
-		return m.handleKeyMsg(msg)
+		m, cmd = m.handleKeyMsg(msg)
+		advance := m.advanceReadMarker()
+		m.noteHomeUnread()
+		return m, tea.Batch(cmd, advance)
 	}
 
 	return m, nil
@@ -40,6 +40,8 @@ func (m Model) handleDetailThreadMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.blockedCursor = 0
 			m.confirmUnblock = false
 			m.unblockTarget = app.BlockedUser{}
+			m.closeScheduled()
+			m.closeDrafts()
 			m.showProfile = false
 			m.returnToProfile = false
 			m.profileIsOwn = false
@@ -75,6 +77,11 @@ func (m Model) handleDetailThreadMsg(msg tea.Msg) (Model, tea.Cmd) {
 		return m, m.ensureMediaPreviewCmd()
 
 	case ThreadLoadedMsg:
+		if m.resolvedIDs[msg.ID] {
+			// A resolved rant's thread comes from the user's instance.
+			m.markResolved(msg.Ancestors)
+			m.markResolved(msg.Descendants)
+		}
 		replies := organizeThreadReplies(msg.ID, msg.Descendants)
 		m.threadCache[msg.ID] = threadData{
 			Ancestors:   msg.Ancestors,
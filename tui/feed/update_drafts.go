@@ -0,0 +1,128 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+func (m Model) openDrafts() (Model, tea.Cmd) {
+	m.showDrafts = true
+	m.loadingDrafts = true
+	m.draftsErr = nil
+	m.drafts = nil
+	m.draftCursor = 0
+	m.confirmDiscardDraft = false
+	return m, func() tea.Msg { return RequestDraftsMsg{} }
+}
+
+func (m *Model) closeDrafts() {
+	m.showDrafts = false
+	m.confirmDiscardDraft = false
+}
+
+func (m Model) selectedDraft() (app.Draft, bool) {
+	if m.draftCursor < 0 || m.draftCursor >= len(m.drafts) {
+		return app.Draft{}, false
+	}
+	return m.drafts[m.draftCursor], true
+}
+
+func (m Model) handleDraftsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.confirmDiscardDraft && !key.Matches(msg, m.keys.Yes, m.keys.No) {
+		m.confirmDiscardDraft = false
+	}
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit):
+		m.closeDrafts()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		if m.draftCursor > 0 {
+			m.draftCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		if m.draftCursor < len(m.drafts)-1 {
+			m.draftCursor++
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select, m.keys.NewInline, m.keys.NewEditor):
+		d, ok := m.selectedDraft()
+		if !ok {
+			return m, nil
+		}
+		useInline := !key.Matches(msg, m.keys.NewEditor)
+		m.closeDrafts()
+		return m, func() tea.Msg { return ResumeDraftMsg{Draft: d, UseInline: useInline} }
+	case key.Matches(msg, m.keys.Duplicate):
+		d, ok := m.selectedDraft()
+		if !ok {
+			return m, nil
+		}
+		return m, func() tea.Msg { return DuplicateDraftMsg{Draft: d} }
+	case key.Matches(msg, m.keys.Delete):
+		if _, ok := m.selectedDraft(); ok {
+			m.confirmDiscardDraft = true
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Yes):
+		d, ok := m.selectedDraft()
+		if !m.confirmDiscardDraft || !ok {
+			return m, nil
+		}
+		m.confirmDiscardDraft = false
+		return m, func() tea.Msg { return DiscardDraftMsg{ID: d.ID} }
+	case key.Matches(msg, m.keys.No):
+		m.confirmDiscardDraft = false
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleDraftsMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case DraftsLoadedMsg:
+		m.loadingDrafts = false
+		m.draftsErr = msg.Err
+		m.drafts = msg.Drafts
+		if m.draftCursor >= len(m.drafts) {
+			m.draftCursor = 0
+		}
+		return m, nil
+
+	case DuplicateDraftResultMsg:
+		if msg.Err != nil {
+			m.draftsErr = msg.Err
+			return m, nil
+		}
+		m.draftsErr = nil
+		// The copy is the most recently updated draft, so it goes first.
+		m.drafts = append([]app.Draft{msg.Draft}, m.drafts...)
+		m.draftCursor = 0
+		m.pagingNotice = "Draft duplicated"
+		return m, nil
+
+	case DiscardDraftResultMsg:
+		m.confirmDiscardDraft = false
+		if msg.Err != nil {
+			m.draftsErr = msg.Err
+			return m, nil
+		}
+		m.draftsErr = nil
+		filtered := make([]app.Draft, 0, len(m.drafts))
+		for _, d := range m.drafts {
+			if d.ID == msg.ID {
+				continue
+			}
+			filtered = append(filtered, d)
+		}
+		m.drafts = filtered
+		if m.draftCursor >= len(m.drafts) && m.draftCursor > 0 {
+			m.draftCursor--
+		}
+		m.pagingNotice = "Draft discarded"
+		return m, nil
+	}
+	return m, nil
+}
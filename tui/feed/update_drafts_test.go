@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+func TestUpdateDrafts_ResumeInEditorClosesDialog(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+
+	m, cmd := m.Update(keyRunes("D"))
+	if !m.showDrafts || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected drafts dialog to open and request drafts")
+	}
+	if _, ok := cmd().(RequestDraftsMsg); !ok {
+		t.Fatalf("expected RequestDraftsMsg")
+	}
+	m, _ = m.Update(DraftsLoadedMsg{Drafts: []app.Draft{
+		{ID: "a", Content: "new one"},
+		{ID: "b", Content: "reply", ParentID: "9", ParentAuthor: "bob"},
+	}})
+
+	m, _ = m.Update(keyRunes("j"))
+	m, cmd = m.Update(keyRunes("p"))
+	if m.showDrafts || cmd == nil {
+		t.Fatalf("expected dialog to close and resume command")
+	}
+	msg, ok := cmd().(ResumeDraftMsg)
+	if !ok || msg.Draft.ID != "b" || msg.UseInline {
+		t.Fatalf("expected editor resume of draft b, got %#v", msg)
+	}
+}
+
+func TestUpdateDrafts_DuplicateAndDiscard(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m, _ = m.Update(keyRunes("D"))
+	m, _ = m.Update(DraftsLoadedMsg{Drafts: []app.Draft{{ID: "a", Content: "keep me"}}})
+
+	_, cmd := m.Update(keyRunes("c"))
+	if dup, ok := cmd().(DuplicateDraftMsg); !ok || dup.Draft.ID != "a" {
+		t.Fatalf("expected DuplicateDraftMsg for a")
+	}
+	m, _ = m.Update(DuplicateDraftResultMsg{Draft: app.Draft{ID: "a2", Content: "keep me"}})
+	if len(m.drafts) != 2 || m.drafts[0].ID != "a2" || m.draftCursor != 0 {
+		t.Fatalf("expected copy listed first and selected")
+	}
+
+	m, cmd = m.Update(keyRunes("d"))
+	if cmd != nil || !m.confirmDiscardDraft {
+		t.Fatalf("expected discard confirmation")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.confirmDiscardDraft {
+		t.Fatalf("expected unrelated key to clear discard confirmation")
+	}
+	m, _ = m.Update(keyRunes("d"))
+	_, cmd = m.Update(keyRunes("y"))
+	discard, ok := cmd().(DiscardDraftMsg)
+	if !ok || discard.ID != "a" {
+		t.Fatalf("expected discard of selected draft a, got %#v", discard)
+	}
+	m, _ = m.Update(DiscardDraftResultMsg{ID: "a"})
+	if len(m.drafts) != 1 || m.draftCursor != 0 {
+		t.Fatalf("expected one draft left with cursor clamped")
+	}
+}
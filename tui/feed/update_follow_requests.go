@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openFollowRequests lists accounts waiting to follow the user. It is only
+// reachable from the user's own profile.
+func (m Model) openFollowRequests() (Model, tea.Cmd) {
+	if !m.profileIsOwn || m.profileLoading {
+		return m, nil
+	}
+	m.showFollowRequests = true
+	m.confirmRejectRequest = false
+	cmd := m.followRequests.reset(m, listFollowRequests, "")
+	return m, cmd
+}
+
+func (m *Model) closeFollowRequests() {
+	m.showFollowRequests = false
+	m.confirmRejectRequest = false
+	m.followRequests = accountList{seq: m.followRequests.seq}
+}
+
+// resolveFollowRequest accepts or rejects the selected request.
+func (m Model) resolveFollowRequest(authorize bool) tea.Cmd {
+	a, ok := m.followRequests.selected()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		return ResolveFollowRequestMsg{AccountID: a.ID, Username: a.Username, Authorize: authorize}
+	}
+}
+
+func (m Model) handleFollowRequestsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.confirmRejectRequest {
+		m.confirmRejectRequest = false
+		if key.Matches(msg, m.keys.Yes) {
+			return m, m.resolveFollowRequest(false)
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.FollowRequests):
+		m.closeFollowRequests()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		return m, m.followRequests.move(m, -1)
+	case key.Matches(msg, m.keys.Down):
+		return m, m.followRequests.move(m, 1)
+	case key.Matches(msg, m.keys.Accept):
+		return m, m.resolveFollowRequest(true)
+	case key.Matches(msg, m.keys.Reject):
+		if _, ok := m.followRequests.selected(); ok {
+			m.confirmRejectRequest = true
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select, m.keys.OpenProfile):
+		a, ok := m.followRequests.selected()
+		if !ok {
+			return m, nil
+		}
+		m.closeFollowRequests()
+		return m.openProfile(a.ID, false)
+	}
+	return m, nil
+}
+
+func (m Model) handleFollowRequestsMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FollowRequestResolvedMsg:
+		if msg.Err != nil {
+			m.followRequests.err = msg.Err
+			return m, nil
+		}
+		m.followRequests.err = nil
+		m.followRequests.remove(msg.AccountID)
+		if m.showProfile && m.profileIsOwn {
+			if m.profile.FollowRequests > 0 {
+				m.profile.FollowRequests--
+			}
+			if msg.Authorize {
+				m.profile.Followers++
+			}
+		}
+		if rel, ok := m.relationship(msg.AccountID); ok {
+			rel.FollowedBy = msg.Authorize
+			m.relationships[msg.AccountID] = rel
+		}
+		if msg.Authorize {
+			m.pagingNotice = "Accepted follow request from @" + msg.Username
+		} else {
+			m.pagingNotice = "Rejected follow request from @" + msg.Username
+		}
+		return m, nil
+	}
+	return m, nil
+}
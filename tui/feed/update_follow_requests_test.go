@@ -0,0 +1,89 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// lockedAccount has two pending follow requests.
+type lockedAccount struct {
+	stubAccount
+}
+
+func (lockedAccount) FollowRequests(context.Context, int, string) ([]app.AccountSummary, string, error) {
+	return []app.AccountSummary{{ID: "7", Username: "alice"}, {ID: "8", Username: "bob"}}, "", nil
+}
+
+func TestUpdateFollowRequests_AcceptAndReject(t *testing.T) {
+	m := New(stubTimeline{}, lockedAccount{}, "terminalrant", "terminalrant")
+	m.showProfile = true
+	m.profileIsOwn = true
+	m.profile = appProfile("1", "me")
+	m.profile.Locked = true
+	m.profile.FollowRequests = 2
+	m.profile.Followers = 5
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Follow requests: 2 pending (F)") {
+		t.Fatalf("expected pending count badge:\n%s", view)
+	}
+
+	m, cmd := m.Update(keyRunes("F"))
+	if !m.showFollowRequests || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected follow requests dialog to open and load")
+	}
+	m, _ = m.Update(cmd())
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "@alice") || !strings.Contains(view, "@bob") {
+		t.Fatalf("expected requests in view:\n%s", view)
+	}
+
+	m, cmd = m.Update(keyRunes("a"))
+	msg, ok := cmd().(ResolveFollowRequestMsg)
+	if !ok || msg.AccountID != "7" || !msg.Authorize {
+		t.Fatalf("expected accept for alice, got %#v", msg)
+	}
+	m, _ = m.Update(FollowRequestResolvedMsg{AccountID: "7", Username: "alice", Authorize: true})
+	if len(m.followRequests.items) != 1 || m.profile.FollowRequests != 1 || m.profile.Followers != 6 {
+		t.Fatalf("expected alice accepted, got items=%v requests=%d followers=%d",
+			m.followRequests.items, m.profile.FollowRequests, m.profile.Followers)
+	}
+
+	// Rejecting asks first; anything but y keeps the request.
+	m, _ = m.Update(keyRunes("r"))
+	if !m.confirmRejectRequest {
+		t.Fatalf("expected reject confirmation")
+	}
+	m, cmd = m.Update(keyRunes("n"))
+	if m.confirmRejectRequest || cmd != nil {
+		t.Fatalf("expected reject to be cancelled")
+	}
+	m, _ = m.Update(keyRunes("r"))
+	m, cmd = m.Update(keyRunes("y"))
+	if msg, ok := cmd().(ResolveFollowRequestMsg); !ok || msg.AccountID != "8" || msg.Authorize {
+		t.Fatalf("expected reject for bob, got %#v", msg)
+	}
+	m, _ = m.Update(FollowRequestResolvedMsg{AccountID: "8", Username: "bob", Err: errors.New("boom")})
+	if len(m.followRequests.items) != 1 || m.followRequests.err == nil {
+		t.Fatalf("a failed reject must keep the request and show the error")
+	}
+
+	m, _ = m.Update(keyRunes("q"))
+	if m.showFollowRequests || !m.showProfile {
+		t.Fatalf("expected esc/q to return to the profile")
+	}
+}
+
+func TestUpdateFollowRequests_OnlyFromOwnProfile(t *testing.T) {
+	m := New(stubTimeline{}, lockedAccount{}, "terminalrant", "terminalrant")
+	m.showProfile = true
+	m.profile = appProfile("42", "dave")
+
+	m, cmd := m.Update(keyRunes("F"))
+	if m.showFollowRequests || cmd != nil {
+		t.Fatalf("follow requests must not open from another profile")
+	}
+}
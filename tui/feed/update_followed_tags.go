@@ -0,0 +1,179 @@
+package feed
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// tagKey normalises a hashtag, with or without '#', for followedTags.
+func tagKey(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
+
+// detailRant is the rant whose card heads the detail view.
+func (m Model) detailRant() domain.Rant {
+	if m.focusedRant != nil {
+		return *m.focusedRant
+	}
+	if m.cursor < 0 || m.cursor >= len(m.rants) {
+		return domain.Rant{}
+	}
+	return m.rants[m.cursor].Rant
+}
+
+// detailTags lists the hashtag capsules of the detail card, "#" included.
+func (m Model) detailTags() []string {
+	_, tags := splitContentAndTags(m.detailRant().Content)
+	return tags
+}
+
+// toggleTagFollow flips the follow state of a hashtag.
+func (m Model) toggleTagFollow(name string) tea.Cmd {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	if name == "" {
+		return nil
+	}
+	follow := !m.followedTags[tagKey(name)]
+	return func() tea.Msg { return ToggleTagFollowMsg{Name: name, Follow: follow} }
+}
+
+// startTagSelect lets the user pick one of the detail card's hashtags.
+func (m Model) startTagSelect() (Model, tea.Cmd) {
+	tags := m.detailTags()
+	if len(tags) == 0 {
+		m.pagingNotice = "Post has no hashtags."
+		return m, nil
+	}
+	m.tagSelect = true
+	m.tagCursor = 0
+	return m, m.lookupTags(tags)
+}
+
+func (m Model) handleTagSelectKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	tags := m.detailTags()
+	if len(tags) == 0 {
+		m.tagSelect = false
+		return m, nil
+	}
+	m.tagCursor = min(m.tagCursor, len(tags)-1)
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.FollowedTags):
+		m.tagSelect = false
+	case key.Matches(msg, m.keys.PickPrev):
+		m.tagCursor = (m.tagCursor - 1 + len(tags)) % len(tags)
+	case key.Matches(msg, m.keys.PickNext):
+		m.tagCursor = (m.tagCursor + 1) % len(tags)
+	case key.Matches(msg, m.keys.FollowTag, m.keys.Select):
+		return m, m.toggleTagFollow(tags[m.tagCursor])
+	}
+	return m, nil
+}
+
+func (m Model) openFollowedTags() (Model, tea.Cmd) {
+	m.showFollowedTags = true
+	m.tagList = nil
+	m.tagListCursor = 0
+	m.tagListNext = ""
+	m.tagListErr = nil
+	m.tagListLoading = true
+	m.tagListSeq++
+	return m, m.fetchFollowedTags(m.tagListSeq, "")
+}
+
+func (m *Model) closeFollowedTags() {
+	m.showFollowedTags = false
+	m.tagList = nil
+	m.tagListLoading = false
+	m.tagListSeq++
+}
+
+func (m Model) handleFollowedTagsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.FollowedTags):
+		m.closeFollowedTags()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		if m.tagListCursor > 0 {
+			m.tagListCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		if m.tagListCursor < len(m.tagList)-1 {
+			m.tagListCursor++
+			return m, nil
+		}
+		if m.tagListNext != "" && !m.tagListLoading {
+			m.tagListLoading = true
+			m.tagListSeq++
+			return m, m.fetchFollowedTags(m.tagListSeq, m.tagListNext)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.FollowTag):
+		if m.tagListCursor < len(m.tagList) {
+			return m, m.toggleTagFollow(m.tagList[m.tagListCursor].Name)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		if m.tagListCursor < len(m.tagList) {
+			tag := m.tagList[m.tagListCursor].Name
+			m.closeFollowedTags()
+			return m.switchToHashtag(tag)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleTagFollowMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FollowedTagsPageMsg:
+		if !m.showFollowedTags || msg.Seq != m.tagListSeq {
+			return m, nil
+		}
+		m.tagListLoading = false
+		m.tagListErr = msg.Err
+		if msg.Err != nil {
+			return m, nil
+		}
+		for _, t := range msg.Tags {
+			m.followedTags[tagKey(t.Name)] = true
+		}
+		m.tagList = append(m.tagList, msg.Tags...)
+		m.tagListNext = msg.Next
+		return m, nil
+
+	case TagsLookedUpMsg:
+		// Tags looked up before an error are still worth keeping.
+		for _, t := range msg.Tags {
+			m.followedTags[tagKey(t.Name)] = t.Following
+		}
+		return m, nil
+
+	case TagFollowResultMsg:
+		if msg.Err != nil {
+			m.tagListErr = msg.Err
+			m.pagingNotice = "Tag follow update failed: " + msg.Err.Error()
+			return m, nil
+		}
+		m.tagListErr = nil
+		// The manager keeps unfollowed tags listed so they can be followed
+		// again until it is closed.
+		m.followedTags[tagKey(msg.Name)] = msg.Tag.Following
+		for i := range m.tagList {
+			if tagKey(m.tagList[i].Name) == tagKey(msg.Name) {
+				m.tagList[i].Following = msg.Tag.Following
+			}
+		}
+		if msg.Tag.Following {
+			m.pagingNotice = "Following #" + msg.Name
+		} else {
+			m.pagingNotice = "Unfollowed #" + msg.Name
+		}
+		return m, nil
+	}
+	return m, nil
+}
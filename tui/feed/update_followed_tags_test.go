@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// tagAccount follows #golang and serves it over two pages.
+type tagAccount struct {
+	stubAccount
+}
+
+func (tagAccount) FollowedTags(_ context.Context, _ int, maxID string) ([]app.Tag, string, error) {
+	if maxID == "" {
+		return []app.Tag{{Name: "golang", Following: true}}, "p2", nil
+	}
+	return []app.Tag{{Name: "rust", Following: true}}, "", nil
+}
+
+func (tagAccount) LookupTag(_ context.Context, name string) (app.Tag, error) {
+	return app.Tag{Name: name, Following: name == "golang"}, nil
+}
+
+func TestUpdateFollowedTags_ToggleFromDetailCapsules(t *testing.T) {
+	m := New(stubTimeline{}, tagAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello #golang #tui"}}}
+	m.showDetail = true
+
+	m, cmd := m.Update(keyRunes("#"))
+	if !m.tagSelect || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected tag selection with a follow-state lookup")
+	}
+	m, _ = m.Update(cmd())
+	if !m.followedTags["golang"] || m.followedTags["tui"] {
+		t.Fatalf("unexpected follow state: %#v", m.followedTags)
+	}
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "✓ #golang") || !strings.Contains(view, "f: unfollow #golang") {
+		t.Fatalf("expected followed capsule and hint:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, cmd = m.Update(keyRunes("f"))
+	msg, ok := cmd().(ToggleTagFollowMsg)
+	if !ok || msg.Name != "tui" || !msg.Follow {
+		t.Fatalf("expected follow for #tui, got %#v", msg)
+	}
+	m, _ = m.Update(TagFollowResultMsg{Name: "tui", Follow: true, Tag: app.Tag{Name: "tui", Following: true}})
+	if !m.followedTags["tui"] {
+		t.Fatalf("expected #tui followed")
+	}
+
+	// Lookups are cached, so re-entering needs no request.
+	m, _ = m.Update(keyRunes("q"))
+	if m.tagSelect || !m.showDetail {
+		t.Fatalf("expected esc/q to leave tag selection only")
+	}
+	if _, cmd = m.Update(keyRunes("#")); cmd != nil {
+		t.Fatalf("expected cached follow state")
+	}
+}
+
+func TestUpdateFollowedTags_ManagerPagesAndUnfollows(t *testing.T) {
+	m := New(stubTimeline{}, tagAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello"}}}
+
+	m, cmd := m.Update(keyRunes("#"))
+	if !m.showFollowedTags || cmd == nil {
+		t.Fatalf("expected followed tags manager to open and load")
+	}
+	m, _ = m.Update(cmd())
+	m, cmd = m.Update(keyRunes("j"))
+	if cmd == nil {
+		t.Fatalf("expected next page request")
+	}
+	m, _ = m.Update(cmd())
+	m, _ = m.Update(keyRunes("j"))
+	if len(m.tagList) != 2 || m.tagListCursor != 1 {
+		t.Fatalf("expected two tags with rust selected, got %#v cursor=%d", m.tagList, m.tagListCursor)
+	}
+
+	m, cmd = m.Update(keyRunes("u"))
+	if msg, ok := cmd().(ToggleTagFollowMsg); !ok || msg.Name != "rust" || msg.Follow {
+		t.Fatalf("expected unfollow for #rust, got %#v", msg)
+	}
+	m, _ = m.Update(TagFollowResultMsg{Name: "rust", Tag: app.Tag{Name: "rust"}})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "#rust (unfollowed)") {
+		t.Fatalf("expected unfollowed tag to stay listed:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("k"))
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showFollowedTags || m.feedSource != sourceCustomHashtag || m.hashtag != "golang" || cmd == nil {
+		t.Fatalf("expected enter to open the #golang feed")
+	}
+}
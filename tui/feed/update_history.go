@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+func (m Model) openHistory(r domain.Rant) (Model, tea.Cmd) {
+	if !r.IsEdited() {
+		m.pagingNotice = "Post has not been edited."
+		return m, nil
+	}
+	m.showHistory = true
+	m.loadingHistory = true
+	m.historyErr = nil
+	m.historyID = r.ID
+	m.history = nil
+	m.historyCursor = 0
+	return m, m.fetchHistory(r.ID)
+}
+
+func (m *Model) closeHistory() {
+	m.showHistory = false
+	m.history = nil
+}
+
+func (m Model) handleHistoryKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.History):
+		m.closeHistory()
+	case key.Matches(msg, m.keys.Up):
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.historyCursor < len(m.history)-1 {
+			m.historyCursor++
+		}
+	case key.Matches(msg, m.keys.DiffMode):
+		m.historyByLine = !m.historyByLine
+	}
+	return m, nil
+}
+
+func (m Model) handleHistoryMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case HistoryLoadedMsg:
+		if !m.showHistory || msg.ID != m.historyID {
+			return m, nil
+		}
+		m.loadingHistory = false
+		m.historyErr = msg.Err
+		m.history = msg.Revisions
+		// Start on the latest edit.
+		m.historyCursor = max(len(m.history)-1, 0)
+		return m, nil
+	}
+	return m, nil
+}
@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+func TestUpdateHistory_OpensForEditedPostAndShowsDiff(t *testing.T) {
+	now := time.Now()
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello", EditedAt: now}}}
+	m.showDetail = true
+
+	m, cmd := m.Update(keyRunes("R"))
+	if !m.showHistory || !m.loadingHistory || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected history dialog to open and load")
+	}
+	if msg, ok := cmd().(HistoryLoadedMsg); !ok || msg.ID != "root" {
+		t.Fatalf("expected HistoryLoadedMsg for root, got %#v", msg)
+	}
+
+	m, _ = m.Update(HistoryLoadedMsg{ID: "root", Revisions: []app.Revision{
+		{Content: "the quick fox", CreatedAt: now.Add(-2 * time.Hour)},
+		{Content: "the slow fox", CreatedAt: now.Add(-time.Hour)},
+		{Content: "the slow brown fox", CreatedAt: now},
+	}})
+	if m.loadingHistory || m.historyCursor != 2 {
+		t.Fatalf("expected cursor on latest revision, got %d", m.historyCursor)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Edit History", "original", "edit 2 (current)", "Changes from edit 1:", "brown"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in history view:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(keyRunes("k"))
+	m, _ = m.Update(keyRunes("w"))
+	view = ansi.Strip(m.View())
+	if !strings.Contains(view, "- the quick fox") || !strings.Contains(view, "+ the slow fox") {
+		t.Fatalf("expected line diff of edit 1:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("q"))
+	if m.showHistory || !m.showDetail {
+		t.Fatalf("expected esc/q to return to the detail view")
+	}
+}
+
+func TestUpdateHistory_UneditedPostShowsNotice(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello"}}}
+	m.showDetail = true
+
+	m, cmd := m.Update(keyRunes("R"))
+	if m.showHistory || cmd != nil || m.pagingNotice != "Post has not been edited." {
+		t.Fatalf("expected notice for unedited post, got show=%v notice=%q", m.showHistory, m.pagingNotice)
+	}
+}
@@ -0,0 +1,104 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// openInteractions shows who liked r; tab switches to who boosted it.
+func (m Model) openInteractions(r domain.Rant) (Model, tea.Cmd) {
+	if r.ID == "" {
+		return m, nil
+	}
+	m.showInteractions = true
+	m.cancelFollowConfirm()
+	cmd := m.interactions.reset(m, listFavouritedBy, r.ID)
+	return m, cmd
+}
+
+func (m *Model) closeInteractions() {
+	m.showInteractions = false
+	m.interactions = accountList{seq: m.interactions.seq}
+	m.cancelFollowConfirm()
+}
+
+func (m *Model) cancelFollowConfirm() {
+	m.confirmFollow = false
+	m.followAccountID = ""
+	m.followUsername = ""
+	m.followTarget = false
+}
+
+// confirmFollowAccount asks to follow or unfollow a.
+func (m *Model) confirmFollowAccount(a app.AccountSummary) {
+	m.confirmFollow = true
+	m.followAccountID = a.ID
+	m.followUsername = a.Username
+	m.followTarget = m.followTargetFor(a.ID)
+}
+
+// followConfirmed sends the pending follow change, if any.
+func (m Model) followConfirmed() tea.Cmd {
+	if !m.confirmFollow || m.followAccountID == "" {
+		return nil
+	}
+	accountID, username, follow := m.followAccountID, m.followUsername, m.followTarget
+	return func() tea.Msg {
+		return FollowToggleMsg{AccountID: accountID, Username: username, Follow: follow}
+	}
+}
+
+func (m Model) handleInteractionsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.confirmFollow {
+		if key.Matches(msg, m.keys.Yes) {
+			return m, m.followConfirmed()
+		}
+		m.cancelFollowConfirm()
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit):
+		m.closeInteractions()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		return m, m.interactions.move(m, -1)
+	case key.Matches(msg, m.keys.Down):
+		return m, m.interactions.move(m, 1)
+	case key.Matches(msg, m.keys.NextTab):
+		kind := listRebloggedBy
+		if m.interactions.kind == listRebloggedBy {
+			kind = listFavouritedBy
+		}
+		return m, m.interactions.reset(m, kind, m.interactions.ownerID)
+	case key.Matches(msg, m.keys.Select, m.keys.OpenProfile):
+		a, ok := m.interactions.selected()
+		if !ok {
+			return m, nil
+		}
+		m.closeInteractions()
+		return m.openProfile(a.ID, false)
+	case key.Matches(msg, m.keys.FollowUser):
+		if a, ok := m.interactions.selected(); ok {
+			m.confirmFollowAccount(a)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleAccountsPageMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AccountsPageMsg:
+		applied := m.showInteractions && m.interactions.apply(msg)
+		applied = applied || (m.showProfile && m.profileAccounts.apply(msg))
+		applied = applied || (m.showFollowRequests && m.followRequests.apply(msg))
+		if !applied {
+			return m, nil
+		}
+		return m, m.fetchRelationships(accountIDs(msg.Accounts))
+	}
+	return m, nil
+}
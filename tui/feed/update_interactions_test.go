@@ -0,0 +1,133 @@
+package feed
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// pagedTimeline serves two pages of likers and one page of boosters.
+type pagedTimeline struct {
+	stubTimeline
+}
+
+func (pagedTimeline) FavouritedBy(_ context.Context, id string, _ int, maxID string) ([]app.AccountSummary, string, error) {
+	if maxID == "" {
+		return []app.AccountSummary{{ID: "1", Username: "alice", DisplayName: "Alice"}}, "p2", nil
+	}
+	return []app.AccountSummary{{ID: "2", Username: "bob@remote.example"}}, "", nil
+}
+
+func (pagedTimeline) RebloggedBy(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return []app.AccountSummary{{ID: "3", Username: "carol"}}, "", nil
+}
+
+func TestUpdateInteractions_PagesAndSwitchesLists(t *testing.T) {
+	m := New(pagedTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello"}}}
+	m.showDetail = true
+
+	m, cmd := m.Update(keyRunes("L"))
+	if !m.showInteractions || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected interactions dialog to open and load")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.interactions.items) != 1 || m.interactions.next != "p2" {
+		t.Fatalf("unexpected first page: %#v", m.interactions)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "@alice") || !strings.Contains(view, "(Alice)") {
+		t.Fatalf("expected liker in view:\n%s", view)
+	}
+
+	// Moving past the last account loads the next page.
+	m, cmd = m.Update(keyRunes("j"))
+	if cmd == nil {
+		t.Fatalf("expected next page request")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.interactions.items) != 2 || m.interactions.next != "" {
+		t.Fatalf("expected second page appended, got %#v", m.interactions.items)
+	}
+	m, _ = m.Update(keyRunes("j"))
+	if m.interactions.cursor != 1 {
+		t.Fatalf("expected cursor on second account, got %d", m.interactions.cursor)
+	}
+
+	m, _ = m.Update(keyRunes("f"))
+	if !m.confirmFollow || m.followAccountID != "2" || !m.followTarget {
+		t.Fatalf("expected follow confirmation for bob")
+	}
+	m, cmd = m.Update(keyRunes("y"))
+	if msg, ok := cmd().(FollowToggleMsg); !ok || msg.AccountID != "2" || !msg.Follow {
+		t.Fatalf("expected FollowToggleMsg for bob, got %#v", msg)
+	}
+	m, _ = m.Update(FollowToggleResultMsg{AccountID: "2", Follow: true})
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(cmd())
+	if m.interactions.kind != listRebloggedBy || len(m.interactions.items) != 1 || m.interactions.items[0].Username != "carol" {
+		t.Fatalf("expected boosters after tab, got %#v", m.interactions.items)
+	}
+
+	m, cmd = m.Update(keyRunes("z"))
+	if m.showInteractions || !m.showProfile || cmd == nil {
+		t.Fatalf("expected profile to open from the list")
+	}
+}
+
+func TestUpdateInteractions_DropsStalePages(t *testing.T) {
+	m := New(pagedTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root"}}}
+	m.showDetail = true
+
+	m, first := m.Update(keyRunes("L"))
+	m, second := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(first())
+	if len(m.interactions.items) != 0 {
+		t.Fatalf("stale liked-by page must be ignored")
+	}
+	m, _ = m.Update(second())
+	if len(m.interactions.items) != 1 || m.interactions.items[0].ID != "3" {
+		t.Fatalf("expected boosted-by page, got %#v", m.interactions.items)
+	}
+}
+
+func TestUpdateInteractions_WindowFollowsCursor(t *testing.T) {
+	m := New(pagedTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.width, m.height = 100, 32
+	m.showInteractions = true
+	for i := range 60 {
+		m.interactions.items = append(m.interactions.items, app.AccountSummary{ID: strconv.Itoa(i), Username: "user" + strconv.Itoa(i)})
+	}
+	m.interactions.cursor = 40
+
+	view := ansi.Strip(m.renderInteractionsDialog())
+	if !strings.Contains(view, "▶ @user40") || strings.Contains(view, "@user0 ") {
+		t.Fatalf("expected the window around the cursor:\n%s", view)
+	}
+	start, end := m.interactions.window(m.listRows())
+	if end-start != m.listRows() || !strings.Contains(view, "▲ "+strconv.Itoa(start)+" more") || !strings.Contains(view, "▼ "+strconv.Itoa(60-end)+" more") {
+		t.Fatalf("expected markers for hidden rows around [%d, %d):\n%s", start, end, view)
+	}
+}
+
+func TestListWindow(t *testing.T) {
+	for _, tc := range []struct{ n, cursor, rows, start, end int }{
+		{n: 3, cursor: 2, rows: 10, start: 0, end: 3},
+		{n: 50, cursor: 0, rows: 10, start: 0, end: 10},
+		{n: 50, cursor: 25, rows: 10, start: 20, end: 30},
+		{n: 50, cursor: 49, rows: 10, start: 40, end: 50},
+		{n: 50, cursor: 49, rows: 0, start: 0, end: 50},
+	} {
+		if start, end := listWindow(tc.n, tc.cursor, tc.rows); start != tc.start || end != tc.end {
+			t.Fatalf("listWindow(%d, %d, %d) = %d, %d; want %d, %d", tc.n, tc.cursor, tc.rows, start, end, tc.start, tc.end)
+		}
+	}
+}
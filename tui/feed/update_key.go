@@ -13,25 +13,60 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if m.showAllHints {
-			if key.Matches(msg, m.keys.ToggleHints) || msg.String() == "esc" || msg.String() == "q" || msg.String() == "enter" {
+			if key.Matches(msg, m.keys.ToggleHints, m.keys.Back, m.keys.Quit, m.keys.Select) {
 				m.showAllHints = false
 			}
 			return m, nil
 		}
+		if m.showScheduled {
+			return m.handleScheduledKey(msg)
+		}
+		if m.showDrafts {
+			return m.handleDraftsKey(msg)
+		}
+		if m.showHistory {
+			return m.handleHistoryKey(msg)
+		}
+		if m.showInteractions {
+			return m.handleInteractionsKey(msg)
+		}
+		if m.showFollowRequests {
+			return m.handleFollowRequestsKey(msg)
+		}
+		if m.showFollowedTags {
+			return m.handleFollowedTagsKey(msg)
+		}
+		if m.showNotifications {
+			return m.handleNotificationsKey(msg)
+		}
+		if m.showTrends {
+			return m.handleTrendsKey(msg)
+		}
+		if m.showReport {
+			return m.handleReportKey(msg)
+		}
+		if m.tagSelect {
+			return m.handleTagSelectKey(msg)
+		}
 		if m.showProfile {
-			if m.confirmFollow && msg.String() != "y" && msg.String() != "n" {
+			if m.confirmFollow && !key.Matches(msg, m.keys.Yes, m.keys.No) {
 				m.confirmFollow = false
 				m.followAccountID = ""
 				m.followUsername = ""
 				m.followTarget = false
 			}
+			if m.profileTab != profileTabPosts || key.Matches(msg, m.keys.NextTab, m.keys.PrevTab) {
+				if next, cmd, ok := m.handleProfileAccountsKey(msg); ok {
+					return next, cmd
+				}
+			}
 			switch {
-			case msg.String() == "left":
+			case key.Matches(msg, m.keys.Left):
 				if m.hScroll > 0 {
 					m.hScroll = max(m.hScroll-4, 0)
 				}
 				return m, nil
-			case msg.String() == "right":
+			case key.Matches(msg, m.keys.Right):
 				m.hScroll += 4
 				if m.hScroll < 0 {
 					m.hScroll = 0
@@ -51,12 +86,12 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					return m, func() tea.Msg { return EditProfileMsg{UseInline: false} }
 				}
 				return m, nil
-			case msg.String() == "V":
+			case key.Matches(msg, m.keys.EditProfileInline):
 				if m.profileIsOwn && !m.profileLoading {
 					return m, func() tea.Msg { return EditProfileMsg{UseInline: true} }
 				}
 				return m, nil
-			case msg.String() == "H":
+			case key.Matches(msg, m.keys.SetHashtag):
 				// H: go to feed home.
 				m.showProfile = false
 				m.returnToProfile = false
@@ -79,7 +114,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				m.confirmUnblock = false
 				m.unblockTarget = app.BlockedUser{}
 				return m, nil
-			case msg.String() == "esc" || msg.String() == "q":
+			case key.Matches(msg, m.keys.Back, m.keys.Quit):
 				m.showProfile = false
 				m.returnToProfile = false
 				m.profileIsOwn = false
@@ -129,9 +164,8 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				if strings.TrimSpace(m.profile.ID) == "" || m.profileIsOwn {
 					return m, nil
 				}
-				already := m.followingByID[m.profile.ID]
-				if already {
-					// Unfollow requires confirmation.
+				if !m.followTargetFor(m.profile.ID) {
+					// Unfollowing or withdrawing a request requires confirmation.
 					m.confirmFollow = true
 					m.followAccountID = m.profile.ID
 					m.followUsername = m.profile.Username
@@ -153,17 +187,25 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				m.loadingBlocked = true
 				m.blockedErr = nil
 				m.blockedUsers = nil
+				m.domainBlocks = nil
 				m.blockedCursor = 0
 				m.confirmUnblock = false
 				m.unblockTarget = app.BlockedUser{}
+				m.unblockDomain = ""
 				return m, func() tea.Msg { return RequestBlockedUsersMsg{} }
-			case msg.String() == "i":
+			case key.Matches(msg, m.keys.FollowRequests):
+				return m.openFollowRequests()
+			case key.Matches(msg, m.keys.ManageScheduled):
+				return m.openScheduled()
+			case key.Matches(msg, m.keys.ManageDrafts):
+				return m.openDrafts()
+			case key.Matches(msg, m.keys.ToggleMedia):
 				m.showMediaPreview = !m.showMediaPreview
 				if m.showMediaPreview {
 					return m, m.ensureProfileAvatarPreviewCmd()
 				}
 				return m, nil
-			case msg.String() == "I":
+			case key.Matches(msg, m.keys.OpenMedia):
 				if strings.TrimSpace(m.profile.AvatarURL) != "" {
 					return m, openURL(m.profile.AvatarURL)
 				}
@@ -173,7 +215,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					return m, openURL(m.profile.URL)
 				}
 				return m, nil
-			case msg.String() == "enter":
+			case key.Matches(msg, m.keys.Select):
 				if m.profileCursor > 0 && m.profileCursor <= len(m.profilePosts) {
 					target := m.profilePosts[m.profileCursor-1]
 					m.showProfile = false
@@ -195,7 +237,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					return m, m.loadThreadFromCacheOrFetch(target.ID)
 				}
 				return m, nil
-			case msg.String() == "y":
+			case key.Matches(msg, m.keys.Yes):
 				if m.confirmFollow && m.followAccountID != "" {
 					accountID := m.followAccountID
 					username := m.followUsername
@@ -209,7 +251,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					}
 				}
 				return m, nil
-			case msg.String() == "n":
+			case key.Matches(msg, m.keys.No):
 				m.confirmFollow = false
 				m.followAccountID = ""
 				m.followUsername = ""
@@ -220,10 +262,11 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		if m.showBlocked {
 			switch {
-			case msg.String() == "esc" || msg.String() == "q":
+			case key.Matches(msg, m.keys.Back, m.keys.Quit):
 				m.showBlocked = false
 				m.confirmUnblock = false
 				m.unblockTarget = app.BlockedUser{}
+				m.unblockDomain = ""
 				return m, nil
 			case key.Matches(msg, m.keys.Up):
 				if m.blockedCursor > 0 {
@@ -231,18 +274,28 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				}
 				return m, nil
 			case key.Matches(msg, m.keys.Down):
-				if m.blockedCursor < len(m.blockedUsers)-1 {
+				if m.blockedCursor < len(m.blockedUsers)+len(m.domainBlocks)-1 {
 					m.blockedCursor++
 				}
 				return m, nil
-			case msg.String() == "u":
-				if len(m.blockedUsers) == 0 || m.blockedCursor < 0 || m.blockedCursor >= len(m.blockedUsers) {
+			case key.Matches(msg, m.keys.Unblock):
+				if m.blockedCursor < 0 || m.blockedCursor >= len(m.blockedUsers)+len(m.domainBlocks) {
 					return m, nil
 				}
 				m.confirmUnblock = true
-				m.unblockTarget = m.blockedUsers[m.blockedCursor]
+				if m.blockedCursor < len(m.blockedUsers) {
+					m.unblockTarget = m.blockedUsers[m.blockedCursor]
+				} else {
+					m.unblockDomain = m.domainBlocks[m.blockedCursor-len(m.blockedUsers)]
+				}
 				return m, nil
-			case msg.String() == "y":
+			case key.Matches(msg, m.keys.Yes):
+				if m.confirmUnblock && m.unblockDomain != "" {
+					domain := m.unblockDomain
+					m.confirmUnblock = false
+					m.unblockDomain = ""
+					return m, func() tea.Msg { return UnblockDomainMsg{Domain: domain} }
+				}
 				if m.confirmUnblock && m.unblockTarget.AccountID != "" {
 					target := m.unblockTarget
 					m.confirmUnblock = false
@@ -252,49 +305,38 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					}
 				}
 				return m, nil
-			case msg.String() == "n":
+			case key.Matches(msg, m.keys.No):
 				if m.confirmUnblock {
 					m.confirmUnblock = false
 					m.unblockTarget = app.BlockedUser{}
+					m.unblockDomain = ""
 				}
 				return m, nil
 			}
 			return m, nil
 		}
-		if m.confirmFollow && msg.String() != "y" && msg.String() != "n" && msg.String() != "q" && msg.String() != "esc" {
+		if m.confirmFollow && !key.Matches(msg, m.keys.Yes, m.keys.No, m.keys.Quit, m.keys.Back) {
 			m.confirmFollow = false
 			m.followAccountID = ""
 			m.followUsername = ""
 			m.followTarget = false
 		}
 		if m.hashtagInput {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case key.Matches(msg, m.keys.Cancel):
 				m.hashtagInput = false
 				m.hashtagBuffer = ""
 				return m, nil
-			case "enter":
+			case key.Matches(msg, m.keys.Submit):
 				tag := strings.TrimSpace(strings.TrimPrefix(m.hashtagBuffer, "#"))
 				m.hashtagInput = false
 				if tag == "" {
 					m.hashtagBuffer = ""
 					return m, nil
 				}
-				m.hashtag = tag
-				if strings.EqualFold(tag, m.defaultHashtag) {
-					m.feedSource = sourceTerminalRant
-				} else {
-					m.feedSource = sourceCustomHashtag
-				}
 				m.hashtagBuffer = ""
-				m.prepareSourceChange()
-				m.pagingNotice = "Switched to #" + tag
-				m.feedReqSeq++
-				return m, tea.Batch(
-					m.fetchRants(m.feedReqSeq),
-					m.emitPrefsChanged(),
-				)
-			case "backspace":
+				return m.switchToHashtag(tag)
+			case msg.String() == "backspace":
 				if len(m.hashtagBuffer) > 0 {
 					r := []rune(m.hashtagBuffer)
 					m.hashtagBuffer = string(r[:len(r)-1])
@@ -306,14 +348,23 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.instanceInput {
+			return m.handleInstanceInputKey(msg)
+		}
+		if m.linkInput {
+			return m.handleLinkInputKey(msg)
+		}
+		if next, cmd, ok := m.resolveSelected(msg); ok {
+			return next, cmd
+		}
 
 		switch {
-		case msg.String() == "left":
+		case key.Matches(msg, m.keys.Left):
 			if m.hScroll > 0 {
 				m.hScroll = max(m.hScroll-4, 0)
 			}
 			return m, nil
-		case msg.String() == "right":
+		case key.Matches(msg, m.keys.Right):
 			m.hScroll += 4
 			if m.hScroll < 0 {
 				m.hScroll = 0
@@ -323,14 +374,14 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.showAllHints = true
 			return m, nil
 
-		case msg.String() == "i":
+		case key.Matches(msg, m.keys.ToggleMedia):
 			m.showMediaPreview = !m.showMediaPreview
 			if m.showMediaPreview {
 				return m, m.ensureMediaPreviewCmd()
 			}
 			return m, nil
 
-		case msg.String() == "I":
+		case key.Matches(msg, m.keys.OpenMedia):
 			r := m.getSelectedRant()
 			if m.showDetail {
 				if m.focusedRant != nil {
@@ -356,7 +407,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.feedReqSeq++
 			return m, tea.Batch(m.fetchRants(m.feedReqSeq), m.emitPrefsChanged())
 
-		case msg.String() == "T":
+		case key.Matches(msg, m.keys.PrevFeed):
 			if m.showDetail {
 				m.pagingNotice = "Exit detail view to switch tabs."
 				return m, nil
@@ -382,16 +433,35 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.hashtagBuffer = m.hashtag
 			return m, nil
 
+		case key.Matches(msg, m.keys.BrowseInstance):
+			return m.openInstanceInput()
+
+		case key.Matches(msg, m.keys.OpenLink):
+			if m.showDetail {
+				break
+			}
+			m.linkInput = true
+			m.linkBuffer = ""
+			return m, nil
+
 		case key.Matches(msg, m.keys.ManageBlocks):
 			m.showBlocked = true
 			m.loadingBlocked = true
 			m.blockedErr = nil
 			m.blockedUsers = nil
+			m.domainBlocks = nil
 			m.blockedCursor = 0
 			m.confirmUnblock = false
 			m.unblockTarget = app.BlockedUser{}
+			m.unblockDomain = ""
 			return m, func() tea.Msg { return RequestBlockedUsersMsg{} }
 
+		case key.Matches(msg, m.keys.ManageScheduled):
+			return m.openScheduled()
+
+		case key.Matches(msg, m.keys.ManageDrafts):
+			return m.openDrafts()
+
 		case key.Matches(msg, m.keys.ShowHidden):
 			m.showHidden = !m.showHidden
 			if m.showHidden {
@@ -512,7 +582,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.unblockTarget = app.BlockedUser{}
 			return m, nil
 
-		case msg.String() == "enter":
+		case key.Matches(msg, m.keys.Select):
 			if len(m.rants) > 0 {
 				if !m.showDetail {
 					m.showDetail = true
@@ -601,6 +671,39 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.GitHub):
 			return m, openURL(creatorGitHub)
 
+		case key.Matches(msg, m.keys.History):
+			if !m.showDetail {
+				break
+			}
+			return m.openHistory(m.getSelectedRant())
+
+		case key.Matches(msg, m.keys.Interactions):
+			if !m.showDetail {
+				break
+			}
+			return m.openInteractions(m.getSelectedRant())
+
+		case key.Matches(msg, m.keys.FollowedTags):
+			if m.showDetail {
+				return m.startTagSelect()
+			}
+			return m.openFollowedTags()
+
+		case key.Matches(msg, m.keys.Trends):
+			if m.showDetail {
+				break
+			}
+			return m.openTrends()
+
+		case key.Matches(msg, m.keys.Notifications):
+			if m.showDetail {
+				break
+			}
+			return m.openNotifications()
+
+		case key.Matches(msg, m.keys.Report):
+			return m.openReport(m.getSelectedRant())
+
 		case key.Matches(msg, m.keys.Edit):
 			if len(m.rants) == 0 {
 				break
@@ -652,6 +755,20 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.confirmBlock = true
 			m.blockAccountID = r.AccountID
 			m.blockUsername = r.Username
+			m.blockDomain = ""
+			return m, nil
+
+		case key.Matches(msg, m.keys.BlockDomain):
+			r := m.getSelectedRant()
+			_, domain := splitUsernameDomain(r.Username)
+			if r.IsOwn || domain == "" {
+				m.pagingNotice = "Only authors on other servers can have their domain blocked."
+				break
+			}
+			m.confirmBlock = true
+			m.blockAccountID = ""
+			m.blockUsername = r.Username
+			m.blockDomain = domain
 			return m, nil
 
 		case key.Matches(msg, m.keys.FollowUser):
@@ -663,7 +780,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			m.confirmFollow = true
 			m.followAccountID = r.AccountID
 			m.followUsername = r.Username
-			m.followTarget = !m.isFollowing(r.AccountID)
+			m.followTarget = m.followTargetFor(r.AccountID)
 			m.confirmBlock = false
 			m.blockAccountID = ""
 			m.blockUsername = ""
@@ -674,27 +791,10 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			if strings.TrimSpace(r.AccountID) == "" {
 				break
 			}
-			m.showProfile = true
-			m.profileIsOwn = r.IsOwn
-			m.profileLoading = true
-			m.profileErr = nil
-			m.profile = app.Profile{}
-			m.profilePosts = nil
-			m.profileCursor = 0
-			m.profileStart = 0
-			m.detailScrollLine = 0
-			return m, m.fetchProfile(r.AccountID)
+			return m.openProfile(r.AccountID, r.IsOwn)
 
 		case key.Matches(msg, m.keys.OpenOwnProfile):
-			m.showProfile = true
-			m.profileIsOwn = true
-			m.profileLoading = true
-			m.profileErr = nil
-			m.profile = app.Profile{}
-			m.profilePosts = nil
-			m.profileCursor = 0
-			m.profileStart = 0
-			m.detailScrollLine = 0
+			m.startProfile(true)
 			return m, m.fetchOwnProfile()
 
 		case key.Matches(msg, m.keys.Delete):
@@ -705,11 +805,26 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 			if m.canDeleteRant(r) {
 				m.confirmDelete = true
 				m.deleteTargetID = r.ID
+				m.redraftRant = nil
 			} else {
 				m.pagingNotice = "Cannot delete this post."
 			}
 
-		case msg.String() == "esc", msg.String() == "q":
+		case key.Matches(msg, m.keys.Redraft), key.Matches(msg, m.keys.RedraftInline):
+			if len(m.rants) == 0 {
+				break
+			}
+			r := m.getSelectedRant()
+			if !m.canDeleteRant(r) {
+				m.pagingNotice = "Cannot redraft this post."
+				break
+			}
+			m.confirmDelete = true
+			m.deleteTargetID = r.ID
+			m.redraftRant = &r
+			m.redraftInline = key.Matches(msg, m.keys.RedraftInline)
+
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
 			if m.confirmBlock {
 				m.confirmBlock = false
 				m.blockAccountID = ""
@@ -762,7 +877,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				m.detailScrollLine = 0
 				return m, nil
 			}
-			if msg.String() == "q" {
+			if key.Matches(msg, m.keys.Quit) {
 				return m, tea.Quit
 			}
 			if m.confirmDelete {
@@ -771,7 +886,15 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case msg.String() == "y":
+		case key.Matches(msg, m.keys.Yes):
+			if m.confirmDelete && m.redraftRant != nil {
+				r, useInline := *m.redraftRant, m.redraftInline
+				m.confirmDelete = false
+				m.deleteTargetID = ""
+				m.redraftRant = nil
+				m.leaveDetailAfterDelete()
+				return m, func() tea.Msg { return RedraftRantMsg{Rant: r, UseInline: useInline} }
+			}
 			if m.confirmDelete {
 				m.confirmDelete = false
 				targetID := strings.TrimSpace(m.deleteTargetID)
@@ -783,24 +906,20 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					return m, nil
 				}
 				m.removeRantByID(targetID)
-				if m.showDetail {
-					if m.returnToProfile {
-						m.showDetail = false
-						m.showProfile = true
-						m.returnToProfile = false
-					} else {
-						m.showDetail = false
-						m.returnToProfile = false
-					}
-					m.focusedRant = nil
-					m.viewStack = nil
-					m.detailCursor = 0
-					m.detailStart = 0
-					m.detailScrollLine = 0
-					m.loadingReplies = false
-				}
+				m.leaveDetailAfterDelete()
 				return m, m.deleteRant(targetID)
 			}
+			if m.confirmBlock && m.blockDomain != "" {
+				domain := m.blockDomain
+				m.confirmBlock = false
+				m.blockDomain = ""
+				m.blockUsername = ""
+				// Hide the domain's posts now; a failed block shows them again.
+				m.blockedDomains[strings.ToLower(domain)] = true
+				m.ensureVisibleCursor()
+				m.ensureFeedCursorVisible()
+				return m, func() tea.Msg { return BlockDomainMsg{Domain: domain} }
+			}
 			if m.confirmBlock && m.blockAccountID != "" {
 				accountID := m.blockAccountID
 				username := m.blockUsername
@@ -817,7 +936,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 					return FollowToggleMsg{AccountID: accountID, Username: username, Follow: follow}
 				}
 			}
-		case msg.String() == "n":
+		case key.Matches(msg, m.keys.No):
 			if m.confirmDelete {
 				m.confirmDelete = false
 				m.deleteTargetID = ""
@@ -833,7 +952,7 @@ func (m Model) handleKeyMsg(msg tea.Msg) (Model, tea.Cmd) {
 				m.followUsername = ""
 				m.followTarget = false
 			}
-		case msg.String() == "u":
+		case key.Matches(msg, m.keys.ParentPost):
 			if !m.showDetail {
 				break
 			}
@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
 
@@ -26,6 +27,56 @@ func TestUpdateKey_ManageBlocks_OpensDialogAndRequestsList(t *testing.T) {
 	}
 }
 
+func TestUpdateKey_BlockDomain_HidesPostsAndUnblocksFromDialog(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	noisy := domain.Rant{ID: "1", AccountID: "a1", Username: "bob@Noise.example"}
+	local := domain.Rant{ID: "2", AccountID: "a2", Username: "alice"}
+	m.rants = []RantItem{{Rant: noisy}, {Rant: local}}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if !m.confirmBlock || m.blockDomain != "Noise.example" || m.blockPrompt() != "Block everything from Noise.example? (y/n)" {
+		t.Fatalf("expected domain block confirmation, got domain %q", m.blockDomain)
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil || !m.isMarkedHidden(noisy) || m.isMarkedHidden(local) {
+		t.Fatalf("expected posts from the domain to be hidden right away")
+	}
+	if msg, ok := cmd().(BlockDomainMsg); !ok || msg.Domain != "Noise.example" {
+		t.Fatalf("expected BlockDomainMsg, got %#v", msg)
+	}
+	m, _ = m.Update(DomainBlockResultMsg{Domain: "Noise.example", Block: true, Err: errString("boom")})
+	if m.isMarkedHidden(noisy) {
+		t.Fatalf("expected a failed domain block to show the posts again")
+	}
+
+	m.blockedDomains["noise.example"] = true
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	m, _ = m.Update(BlockedUsersLoadedMsg{
+		Users:   []app.BlockedUser{{AccountID: "9", Username: "troll"}},
+		Domains: []string{"noise.example"},
+	})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if out := m.renderBlockedUsersDialog(); !strings.Contains(out, "Unblock domain noise.example? (y/n)") {
+		t.Fatalf("expected domain unblock confirmation: %q", out)
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if msg, ok := cmd().(UnblockDomainMsg); !ok || msg.Domain != "noise.example" {
+		t.Fatalf("expected UnblockDomainMsg, got %#v", msg)
+	}
+	m, _ = m.Update(DomainBlockResultMsg{Domain: "noise.example"})
+	if len(m.domainBlocks) != 0 || m.isMarkedHidden(noisy) || m.blockedCursor != 0 {
+		t.Fatalf("expected domain to be unblocked, got %v", m.domainBlocks)
+	}
+
+	m.showBlocked = false
+	m.cursor = 1
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if m.confirmBlock || m.pagingNotice == "" {
+		t.Fatalf("expected local authors not to be domain blockable")
+	}
+}
+
 func TestUpdateKey_ConfirmFollow_ClearsOnUnrelatedKey(t *testing.T) {
 	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
 	m.confirmFollow = true
@@ -263,3 +314,38 @@ func TestUpdateKey_ProfileDownUsesHeaderScrollGateBeforeMovingCursor(t *testing.
 		t.Fatalf("expected detailScrollLine increment, got %d", updated.detailScrollLine)
 	}
 }
+
+func TestUpdateKey_RedraftConfirmsThenRequestsRedraft(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{
+		{Rant: domain.Rant{ID: "own", IsOwn: true, Visibility: "public"}},
+		{Rant: domain.Rant{ID: "media", IsOwn: true, Media: []domain.MediaAttachment{{ID: "m1"}}}},
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if !updated.confirmDelete || updated.redraftRant == nil || !updated.redraftInline {
+		t.Fatalf("expected inline redraft confirmation")
+	}
+	if got := updated.deletePrompt("rant"); got != "Delete and redraft this rant? (y/n)" {
+		t.Fatalf("unexpected prompt %q", got)
+	}
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if updated.confirmDelete || updated.redraftRant != nil || cmd == nil {
+		t.Fatalf("expected confirmation cleared and redraft command")
+	}
+	msg, ok := cmd().(RedraftRantMsg)
+	if !ok || msg.Rant.ID != "own" || !msg.UseInline {
+		t.Fatalf("expected inline RedraftRantMsg for own, got %#v", msg)
+	}
+	if len(updated.rants) != 2 {
+		t.Fatalf("redraft must not drop the rant before it is deleted")
+	}
+
+	// Media, visibility and content warning are carried into the new post,
+	// so posts with media can be redrafted too.
+	updated.cursor = 1
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !updated.confirmDelete || updated.redraftRant == nil || updated.redraftRant.ID != "media" || updated.redraftInline {
+		t.Fatalf("expected editor redraft confirmation for the media post, notice=%q", updated.pagingNotice)
+	}
+}
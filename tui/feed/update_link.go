@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// parseLink accepts an http(s) URL with a host, as pasted from a browser.
+func parseLink(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", false
+	}
+	return raw, true
+}
+
+func (m Model) handleLinkInputKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.linkInput = false
+		m.linkBuffer = ""
+		return m, nil
+	case key.Matches(msg, m.keys.Submit):
+		m.linkInput = false
+		link := m.linkBuffer
+		m.linkBuffer = ""
+		return m.openLink(link)
+	case msg.String() == "backspace":
+		if r := []rune(m.linkBuffer); len(r) > 0 {
+			m.linkBuffer = string(r[:len(r)-1])
+		}
+		return m, nil
+	}
+	if len(msg.Runes) > 0 {
+		m.linkBuffer += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// openLink starts looking up the post or profile at raw.
+func (m Model) openLink(raw string) (Model, tea.Cmd) {
+	link, ok := parseLink(raw)
+	if !ok {
+		m.pagingNotice = "Not a post or profile URL."
+		return m, nil
+	}
+	m.openingLink = link
+	m.pagingNotice = "Opening " + link + "..."
+	return m, m.resolveLink(link)
+}
+
+// openRantDetail shows r in the detail view, whether or not it is in the
+// feed.
+func (m Model) openRantDetail(r domain.Rant) (Model, tea.Cmd) {
+	if m.remote != nil {
+		m.resolvedIDs[r.ID] = true
+	}
+	m.showProfile = false
+	m.returnToProfile = false
+	m.setCursorByID(r.ID)
+	m.showDetail = true
+	m.detailCursor = 0
+	m.detailStart = 0
+	m.detailScrollLine = 0
+	m.replies = nil
+	m.replyAll = nil
+	m.replyVisible = 0
+	m.hasMoreReplies = false
+	m.ancestors = nil
+	m.loadingReplies = true
+	m.focusedRant = &r
+	m.viewStack = nil
+	return m, tea.Batch(m.loadThreadFromCacheOrFetch(r.ID), m.fetchRelationshipsForRants([]domain.Rant{r}))
+}
+
+func (m Model) handleLinkMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenLinkMsg:
+		return m.openLink(msg.URL)
+
+	case LinkResolvedMsg:
+		if msg.URL != m.openingLink {
+			return m, nil
+		}
+		m.openingLink = ""
+		switch {
+		case errors.Is(msg.Err, app.ErrNotFound):
+			m.pagingNotice = "No post or profile found at that URL."
+			return m, nil
+		case msg.Err != nil:
+			m.pagingNotice = "Could not open link: " + msg.Err.Error()
+			return m, nil
+		}
+		m.pagingNotice = ""
+		if msg.Rant != nil {
+			return m.openRantDetail(*msg.Rant)
+		}
+		m.showDetail = false
+		m.focusedRant = nil
+		m.viewStack = nil
+		return m.openProfile(msg.Account.ID, msg.Own)
+	}
+	return m, nil
+}
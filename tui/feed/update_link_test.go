@@ -0,0 +1,109 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+type linkTimeline struct {
+	stubTimeline
+}
+
+func (linkTimeline) ResolveStatus(_ context.Context, statusURL string) (domain.Rant, error) {
+	if statusURL == "https://remote.example/@alice/1" {
+		return domain.Rant{ID: "s1", AccountID: "a1", Username: "alice@remote.example", URL: statusURL}, nil
+	}
+	return domain.Rant{}, app.ErrNotFound
+}
+
+type linkAccount struct {
+	stubAccount
+}
+
+func (linkAccount) ResolveAccount(_ context.Context, profileURL string) (app.AccountSummary, error) {
+	if profileURL == "https://remote.example/@alice" {
+		return app.AccountSummary{ID: "a1", Username: "alice@remote.example"}, nil
+	}
+	return app.AccountSummary{}, app.ErrNotFound
+}
+
+func TestUpdateLink_OpensPostInDetail(t *testing.T) {
+	m := New(linkTimeline{}, linkAccount{}, "terminalrant", "terminalrant")
+
+	m, _ = m.Update(keyRunes("U"))
+	if !m.linkInput || !m.IsDialogOpen() {
+		t.Fatalf("expected URL prompt to open")
+	}
+	m, _ = m.Update(keyRunes(" https://remote.example/@alice/1 "))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.linkInput || cmd == nil {
+		t.Fatalf("expected the link to be looked up")
+	}
+	m, _ = m.Update(cmd())
+	if !m.showDetail || m.focusedRant == nil || m.focusedRant.ID != "s1" || !m.loadingReplies {
+		t.Fatalf("expected detail view of the linked post, got %#v", m.focusedRant)
+	}
+	if got := m.renderDetailView(); got == "No rant selected." {
+		t.Fatalf("expected the linked post to render without a loaded feed")
+	}
+}
+
+func TestUpdateLink_FallsBackToProfile(t *testing.T) {
+	m := New(linkTimeline{}, linkAccount{}, "terminalrant", "terminalrant")
+
+	m, cmd := m.Update(OpenLinkMsg{URL: "https://remote.example/@alice"})
+	if cmd == nil {
+		t.Fatalf("expected the link to be looked up")
+	}
+	resolved, ok := cmd().(LinkResolvedMsg)
+	if !ok || resolved.Rant != nil || resolved.Account.ID != "a1" {
+		t.Fatalf("expected the link to resolve to an account, got %#v", resolved)
+	}
+	m, _ = m.Update(resolved)
+	if !m.showProfile || !m.profileLoading || m.showDetail || m.profileIsOwn {
+		t.Fatalf("expected profile view to open")
+	}
+}
+
+// ownLinkAccount is signed in as the account the profile link resolves to.
+type ownLinkAccount struct {
+	linkAccount
+}
+
+func (ownLinkAccount) CurrentAccountID(context.Context) (string, error) { return "a1", nil }
+
+func TestUpdateLink_MarksOwnProfile(t *testing.T) {
+	m := New(linkTimeline{}, ownLinkAccount{}, "terminalrant", "terminalrant")
+
+	m, cmd := m.Update(OpenLinkMsg{URL: "https://remote.example/@alice"})
+	m, _ = m.Update(cmd())
+	if !m.showProfile || !m.profileIsOwn {
+		t.Fatalf("expected the signed-in user's profile to open as their own")
+	}
+}
+
+func TestUpdateLink_ReportsUnknownLinks(t *testing.T) {
+	m := New(linkTimeline{}, linkAccount{}, "terminalrant", "terminalrant")
+
+	m, cmd := m.Update(OpenLinkMsg{URL: "not a url"})
+	if cmd != nil || m.pagingNotice != "Not a post or profile URL." {
+		t.Fatalf("expected invalid link to be refused, got %q", m.pagingNotice)
+	}
+	m, cmd = m.Update(OpenLinkMsg{URL: "https://remote.example/about"})
+	m, _ = m.Update(cmd())
+	if m.showDetail || m.showProfile || m.pagingNotice != "No post or profile found at that URL." {
+		t.Fatalf("expected a not-found notice, got %q", m.pagingNotice)
+	}
+
+	// A lookup overtaken by a newer one is dropped.
+	m, _ = m.Update(OpenLinkMsg{URL: "https://remote.example/@alice"})
+	m, _ = m.Update(LinkResolvedMsg{URL: "https://remote.example/@alice/1", Rant: &domain.Rant{ID: "s1"}})
+	if m.showDetail {
+		t.Fatalf("expected stale link result to be ignored")
+	}
+}
@@ -0,0 +1,217 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// markerSaveDelay is how long the read position must stay put before it is
+// saved, so scrolling does not send a request per post.
+const markerSaveDelay = 2 * time.Second
+
+// maxRestorePages bounds how far back the following tab pages to find the
+// last read post.
+const maxRestorePages = 10
+
+// WithMarkers lets the feed save and restore the read positions of the
+// following tab and the notifications dialog.
+func (m Model) WithMarkers(markers app.MarkerService) Model {
+	m.markers = markers
+	return m
+}
+
+func (m Model) fetchMarkers() tea.Cmd {
+	if m.markers == nil {
+		return nil
+	}
+	markers := m.markers
+	return func() tea.Msg {
+		got, err := markers.Markers(context.Background(), app.MarkerHome, app.MarkerNotifications)
+		return MarkersLoadedMsg{Markers: got, Err: err}
+	}
+}
+
+// idNewer reports whether status id a is newer than b. Mastodon ids are
+// numeric strings that grow over time, so longer means newer.
+func idNewer(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
+
+// tracksReadPosition reports whether the feed shows the home timeline the
+// marker belongs to.
+func (m Model) tracksReadPosition() bool {
+	return m.markers != nil && m.markersLoaded && m.remote == nil && m.feedSource == sourceFollowing
+}
+
+// unreadCount is how many loaded posts are newer than the read position.
+func (m Model) unreadCount() int {
+	if !m.tracksReadPosition() || m.homeReadID == "" {
+		return 0
+	}
+	n := 0
+	for _, idx := range m.visibleIndices() {
+		if idNewer(m.rants[idx].Rant.ID, m.homeReadID) {
+			n++
+		}
+	}
+	return n
+}
+
+// noteHomeUnread remembers the unread count of the following tab so the tab
+// bar can keep showing it after switching to another tab.
+func (m *Model) noteHomeUnread() {
+	if m.tracksReadPosition() && !m.loading && !m.restoreRead {
+		m.homeUnread = m.unreadCount()
+	}
+}
+
+// tabUnread is the unread count shown next to a tab label.
+func (m Model) tabUnread(source feedSource) int {
+	if source != sourceFollowing {
+		return 0
+	}
+	if m.feedSource == sourceFollowing && m.remote == nil {
+		return m.unreadCount()
+	}
+	return m.homeUnread
+}
+
+// fetchHomeUnread counts the newest home posts past the read position when
+// the feed opens on another tab.
+func (m Model) fetchHomeUnread() tea.Cmd {
+	if m.timeline == nil || m.homeReadID == "" {
+		return nil
+	}
+	timeline, readID := m.timeline, m.homeReadID
+	return func() tea.Msg {
+		rants, err := timeline.FetchHomePage(context.Background(), defaultLimit, "")
+		n := 0
+		for _, r := range rants {
+			if idNewer(r.ID, readID) {
+				n++
+			}
+		}
+		return homeUnreadMsg{count: n, err: err}
+	}
+}
+
+// readDividerID is the newest read post when unread posts sit above it; the
+// feed draws a divider over it.
+func (m Model) readDividerID() string {
+	if !m.tracksReadPosition() || m.homeReadID == "" {
+		return ""
+	}
+	seenUnread := false
+	for _, idx := range m.visibleIndices() {
+		id := m.rants[idx].Rant.ID
+		if id == "" {
+			continue
+		}
+		if idNewer(id, m.homeReadID) {
+			seenUnread = true
+			continue
+		}
+		if seenUnread {
+			return id
+		}
+		return ""
+	}
+	return ""
+}
+
+// restoreReadPosition moves the cursor to the last read post once the
+// following tab has loaded, paging older until it turns up.
+func (m *Model) restoreReadPosition() tea.Cmd {
+	if !m.restoreRead || !m.tracksReadPosition() || m.loading || m.loadingMore {
+		return nil
+	}
+	if m.homeReadID == "" {
+		m.restoreRead = false
+		return nil
+	}
+	for _, idx := range m.visibleIndices() {
+		if !idNewer(m.rants[idx].Rant.ID, m.homeReadID) {
+			m.restoreRead = false
+			m.setCursorByID(m.rants[idx].Rant.ID)
+			return nil
+		}
+	}
+	if !m.hasMoreFeed || m.oldestFeedID == "" || m.restorePages >= maxRestorePages {
+		// Everything loaded is unread; leave the cursor at the top.
+		m.restoreRead = false
+		return nil
+	}
+	m.restorePages++
+	m.loadingMore = true
+	m.feedReqSeq++
+	return m.fetchOlderRants(m.feedReqSeq)
+}
+
+// advanceReadMarker marks the selected post, and everything older, read when
+// it is newer than the saved position, and schedules a save.
+func (m *Model) advanceReadMarker() tea.Cmd {
+	if !m.tracksReadPosition() || m.restoreRead || m.showDetail || m.loading {
+		return nil
+	}
+	r, ok := m.selectedVisibleRant()
+	if !ok || r.ID == "" || !idNewer(r.ID, m.homeReadID) {
+		return nil
+	}
+	m.homeReadID = r.ID
+	m.markerSeq++
+	seq := m.markerSeq
+	return tea.Tick(markerSaveDelay, func(time.Time) tea.Msg { return markerSaveMsg{timeline: app.MarkerHome, seq: seq} })
+}
+
+func (m Model) handleMarkerMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case MarkersLoadedMsg:
+		if msg.Err != nil {
+			// Without markers the feed simply starts at the top.
+			return m, nil
+		}
+		m.markersLoaded = true
+		m.homeReadID = msg.Markers[app.MarkerHome].LastReadID
+		m.notificationsReadID = msg.Markers[app.MarkerNotifications].LastReadID
+		m.restoreRead = true
+		restore := m.restoreReadPosition()
+		if m.feedSource != sourceFollowing || m.remote != nil {
+			return m, tea.Batch(restore, m.fetchHomeUnread(), m.fetchNotificationsUnread())
+		}
+		return m, tea.Batch(restore, m.fetchNotificationsUnread())
+
+	case homeUnreadMsg:
+		if msg.err == nil {
+			m.homeUnread = msg.count
+		}
+		return m, nil
+
+	case markerSaveMsg:
+		id, seq := m.homeReadID, m.markerSeq
+		if msg.timeline == app.MarkerNotifications {
+			id, seq = m.notificationsReadID, m.notificationSaveSeq
+		}
+		if msg.seq != seq || m.markers == nil || id == "" {
+			return m, nil
+		}
+		markers, timeline := m.markers, msg.timeline
+		return m, func() tea.Msg {
+			_, err := markers.SaveMarker(context.Background(), timeline, id)
+			return MarkerSavedMsg{Err: err}
+		}
+
+	case MarkerSavedMsg:
+		if msg.Err != nil {
+			m.pagingNotice = "Could not save read position: " + msg.Err.Error()
+		}
+		return m, nil
+	}
+	return m, nil
+}
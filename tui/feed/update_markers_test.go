@@ -0,0 +1,250 @@
+package feed
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// pagedHome serves home pages of defaultLimit posts with ids counting down
+// from 200.
+type pagedHome struct {
+	stubTimeline
+}
+
+func (pagedHome) FetchHomePage(_ context.Context, limit int, maxID string) ([]domain.Rant, error) {
+	top := 200
+	if maxID != "" {
+		top, _ = strconv.Atoi(maxID)
+		top--
+	}
+	out := make([]domain.Rant, 0, limit)
+	for id := top; id > top-limit && id > 0; id-- {
+		out = append(out, domain.Rant{ID: strconv.Itoa(id), AccountID: "a1", Username: "alice"})
+	}
+	return out, nil
+}
+
+type stubMarkers struct {
+	saved *[]string
+}
+
+func (stubMarkers) Markers(context.Context, ...app.MarkerTimeline) (map[app.MarkerTimeline]app.Marker, error) {
+	return nil, nil
+}
+
+func (s stubMarkers) SaveMarker(_ context.Context, timeline app.MarkerTimeline, id string) (app.Marker, error) {
+	*s.saved = append(*s.saved, string(timeline)+":"+id)
+	return app.Marker{LastReadID: id}, nil
+}
+
+func newMarkedFollowing(t *testing.T, readID string) (Model, *[]string) {
+	t.Helper()
+	saved := &[]string{}
+	m := New(pagedHome{}, stubAccount{}, "terminalrant", "following").WithMarkers(stubMarkers{saved: saved})
+	m, _ = m.Update(MarkersLoadedMsg{Markers: map[app.MarkerTimeline]app.Marker{app.MarkerHome: {LastReadID: readID}}})
+	if !m.restoreRead {
+		t.Fatalf("expected restore to wait for the following tab")
+	}
+	m, cmd := m.Update(m.fetchRants(m.feedReqSeq)())
+	for i := 0; cmd != nil && m.restoreRead && i < maxRestorePages; i++ {
+		// Page older until the read post turns up.
+		m, cmd = m.Update(m.fetchOlderRants(m.feedReqSeq)())
+	}
+	return m, saved
+}
+
+func TestUpdateMarkers_RestoresReadPosition(t *testing.T) {
+	m, _ := newMarkedFollowing(t, "195")
+	if m.restoreRead || m.rants[m.cursor].Rant.ID != "195" {
+		t.Fatalf("expected cursor on the last read post, got %q", m.rants[m.cursor].Rant.ID)
+	}
+	if n := m.unreadCount(); n != 5 {
+		t.Fatalf("expected 5 unread posts, got %d", n)
+	}
+	if m.readDividerID() != "195" || !strings.Contains(m.renderTabs(), "following (5 new)") {
+		t.Fatalf("expected divider over 195 and unread count in tabs: %s", m.renderTabs())
+	}
+	m.width, m.height = 100, 200
+	if !strings.Contains(m.View(), "read up to here") {
+		t.Fatalf("expected the read divider in the feed")
+	}
+}
+
+func TestUpdateMarkers_PagesOlderToRestore(t *testing.T) {
+	m, _ := newMarkedFollowing(t, "170")
+	if m.restoreRead || m.rants[m.cursor].Rant.ID != "170" || m.restorePages != 1 {
+		t.Fatalf("expected one older page to reach 170, got cursor %q after %d pages", m.rants[m.cursor].Rant.ID, m.restorePages)
+	}
+	if n := m.unreadCount(); n != 30 {
+		t.Fatalf("expected 30 unread posts, got %d", n)
+	}
+}
+
+func TestUpdateMarkers_SavesNewerPositionAfterDelay(t *testing.T) {
+	m, saved := newMarkedFollowing(t, "195")
+
+	m, _ = m.Update(keyRunes("j"))
+	if m.homeReadID != "195" {
+		t.Fatalf("expected moving to older posts to keep the read position, got %q", m.homeReadID)
+	}
+	m, _ = m.Update(keyRunes("k"))
+	m, _ = m.Update(keyRunes("k"))
+	if m.homeReadID != "196" {
+		t.Fatalf("expected the read position to advance to 196, got %q", m.homeReadID)
+	}
+	stale := m.markerSeq
+	m, _ = m.Update(keyRunes("k"))
+	if _, cmd := m.Update(markerSaveMsg{timeline: app.MarkerHome, seq: stale}); cmd != nil {
+		t.Fatalf("expected a superseded save to be dropped")
+	}
+	m, cmd := m.Update(markerSaveMsg{timeline: app.MarkerHome, seq: m.markerSeq})
+	if cmd == nil {
+		t.Fatalf("expected the settled position to be saved")
+	}
+	m, _ = m.Update(cmd())
+	if len(*saved) != 1 || (*saved)[0] != "home:197" || m.pagingNotice != "" {
+		t.Fatalf("unexpected saves: %v", *saved)
+	}
+}
+
+func TestUpdateMarkers_KeepsUnreadCountOnOtherTabs(t *testing.T) {
+	m, _ := newMarkedFollowing(t, "195")
+	m, _ = m.Update(keyRunes("t"))
+	if m.feedSource == sourceFollowing {
+		t.Fatalf("expected to leave the following tab")
+	}
+	if !strings.Contains(m.renderTabs(), "following (5 new)") {
+		t.Fatalf("expected the unread count to stay on the inactive tab: %s", m.renderTabs())
+	}
+}
+
+func TestUpdateMarkers_CountsHomeUnreadWhenStartingOnAnotherTab(t *testing.T) {
+	m := New(pagedHome{}, stubAccount{}, "terminalrant", "terminalrant").WithMarkers(stubMarkers{saved: &[]string{}})
+	m, cmd := m.Update(MarkersLoadedMsg{Markers: map[app.MarkerTimeline]app.Marker{app.MarkerHome: {LastReadID: "190"}}})
+	if cmd == nil {
+		t.Fatalf("expected the home unread count to be fetched")
+	}
+	m, _ = m.Update(m.fetchHomeUnread()())
+	if m.homeUnread != 10 || !strings.Contains(m.renderTabs(), "following (10 new)") {
+		t.Fatalf("expected 10 unread home posts in the tab bar, got %d: %s", m.homeUnread, m.renderTabs())
+	}
+}
+
+// pagedNotifications serves pages of notifications with ids counting down
+// from 300; odd ids are mentions, even ids follows.
+type pagedNotifications struct{}
+
+func (pagedNotifications) Notifications(_ context.Context, limit int, maxID string) ([]app.Notification, string, error) {
+	top := 300
+	if maxID != "" {
+		top, _ = strconv.Atoi(maxID)
+		top--
+	}
+	out := make([]app.Notification, 0, limit)
+	for id := top; id > top-limit && id > 0; id-- {
+		n := app.Notification{ID: strconv.Itoa(id), Type: "follow", Account: app.AccountSummary{ID: "a1", Username: "alice"}}
+		if id%2 == 1 {
+			n.Type = "mention"
+			n.Rant = &domain.Rant{ID: "r" + n.ID, AccountID: "a1", Username: "alice", Content: "hi @me"}
+		}
+		out = append(out, n)
+	}
+	return out, strconv.Itoa(top - limit + 1), nil
+}
+
+func newMarkedNotifications(t *testing.T, readID string) (Model, *[]string) {
+	t.Helper()
+	saved := &[]string{}
+	m := New(pagedHome{}, stubAccount{}, "terminalrant", "terminalrant").
+		WithMarkers(stubMarkers{saved: saved}).
+		WithNotifications(pagedNotifications{})
+	m, _ = m.Update(MarkersLoadedMsg{Markers: map[app.MarkerTimeline]app.Marker{app.MarkerNotifications: {LastReadID: readID}}})
+	m, _ = m.Update(m.fetchNotificationsUnread()())
+	m, cmd := m.Update(keyRunes("A"))
+	for i := 0; cmd != nil && i <= maxRestorePages; i++ {
+		msg, ok := cmd().(NotificationsPageMsg)
+		if !ok {
+			break
+		}
+		m, cmd = m.Update(msg)
+	}
+	if !m.showNotifications {
+		t.Fatalf("expected the notifications dialog to open")
+	}
+	return m, saved
+}
+
+func TestUpdateMarkers_RestoresNotificationsPosition(t *testing.T) {
+	m, _ := newMarkedNotifications(t, "295")
+	if n, ok := m.selectedNotification(); !ok || n.ID != "295" || m.restoreNotifications {
+		t.Fatalf("expected cursor on the last read notification, got %#v", n)
+	}
+	if m.notificationDividerIndex() != 5 {
+		t.Fatalf("expected the divider over the sixth notification, got %d", m.notificationDividerIndex())
+	}
+	m.width, m.height = 100, 200
+	if !strings.Contains(m.View(), "read up to here") {
+		t.Fatalf("expected the read divider in the dialog")
+	}
+
+	m, _ = m.Update(keyRunes("q"))
+	if m.showNotifications || !strings.Contains(m.renderTabs(), "5 new notifications (A)") {
+		t.Fatalf("expected the unread count in the tab bar: %s", m.renderTabs())
+	}
+}
+
+func TestUpdateMarkers_CountsUnreadNotificationsOnLoad(t *testing.T) {
+	m := New(pagedHome{}, stubAccount{}, "terminalrant", "terminalrant").
+		WithMarkers(stubMarkers{saved: &[]string{}}).
+		WithNotifications(pagedNotifications{})
+	m, _ = m.Update(MarkersLoadedMsg{Markers: map[app.MarkerTimeline]app.Marker{app.MarkerNotifications: {LastReadID: "288"}}})
+	m, _ = m.Update(m.fetchNotificationsUnread()())
+	if m.notificationsUnread != 12 || !strings.Contains(m.renderTabs(), "12 new notifications") {
+		t.Fatalf("expected 12 unread notifications in the tab bar, got %d: %s", m.notificationsUnread, m.renderTabs())
+	}
+}
+
+func TestUpdateMarkers_PagesOlderToRestoreNotifications(t *testing.T) {
+	m, _ := newMarkedNotifications(t, "250")
+	if n, ok := m.selectedNotification(); !ok || n.ID != "250" || m.notificationPages != 1 {
+		t.Fatalf("expected one older page to reach 250, got %#v after %d pages", n, m.notificationPages)
+	}
+}
+
+func TestUpdateMarkers_SavesNotificationsPosition(t *testing.T) {
+	m, saved := newMarkedNotifications(t, "295")
+
+	m, _ = m.Update(keyRunes("j"))
+	if m.notificationsReadID != "295" {
+		t.Fatalf("expected moving to older notifications to keep the read position, got %q", m.notificationsReadID)
+	}
+	m, _ = m.Update(keyRunes("k"))
+	m, _ = m.Update(keyRunes("k"))
+	if m.notificationsReadID != "296" {
+		t.Fatalf("expected the read position to advance to 296, got %q", m.notificationsReadID)
+	}
+	if _, cmd := m.Update(markerSaveMsg{timeline: app.MarkerNotifications, seq: m.notificationSaveSeq - 1}); cmd != nil {
+		t.Fatalf("expected a superseded save to be dropped")
+	}
+	m, cmd := m.Update(markerSaveMsg{timeline: app.MarkerNotifications, seq: m.notificationSaveSeq})
+	if cmd == nil {
+		t.Fatalf("expected the settled position to be saved")
+	}
+	m, _ = m.Update(cmd())
+	if len(*saved) != 1 || (*saved)[0] != "notifications:296" {
+		t.Fatalf("unexpected saves: %v", *saved)
+	}
+
+	m, _ = m.Update(keyRunes("k"))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showNotifications || !m.showDetail || m.detailRant().ID != "r297" {
+		t.Fatalf("expected enter to open the mentioning post, got detail %v on %q", m.showDetail, m.detailRant().ID)
+	}
+}
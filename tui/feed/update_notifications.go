@@ -0,0 +1,228 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// notificationsPageSize is how many notifications are requested per page.
+const notificationsPageSize = 40
+
+// WithNotifications enables the notifications dialog. Its read position is
+// saved with the markers given to WithMarkers.
+func (m Model) WithNotifications(notifications app.NotificationService) Model {
+	m.notifications = notifications
+	return m
+}
+
+// openNotifications lists the user's notifications, restoring the cursor to
+// the last read one.
+func (m Model) openNotifications() (Model, tea.Cmd) {
+	if m.notifications == nil {
+		return m, nil
+	}
+	m.showNotifications = true
+	m.notificationList = nil
+	m.notificationCursor = 0
+	m.notificationsNext = ""
+	m.notificationsErr = nil
+	m.restoreNotifications = m.notificationsReadID != ""
+	m.notificationPages = 0
+	return m, m.loadNotifications("")
+}
+
+// closeNotifications keeps the unread count of what was loaded for the tab
+// bar and drops the list.
+func (m *Model) closeNotifications() {
+	if len(m.notificationList) > 0 {
+		m.notificationsUnread = m.unreadNotifications()
+	}
+	m.showNotifications = false
+	m.notificationList = nil
+	m.notificationsLoading = false
+	m.restoreNotifications = false
+	m.notificationsSeq++
+}
+
+// loadNotifications requests the page after maxID. A newer request makes
+// older results stale.
+func (m *Model) loadNotifications(maxID string) tea.Cmd {
+	m.notificationsLoading = true
+	m.notificationsSeq++
+	svc, seq := m.notifications, m.notificationsSeq
+	return func() tea.Msg {
+		items, next, err := svc.Notifications(context.Background(), notificationsPageSize, maxID)
+		return NotificationsPageMsg{Seq: seq, Notifications: items, Next: next, Err: err}
+	}
+}
+
+func (m Model) selectedNotification() (app.Notification, bool) {
+	if m.notificationCursor < 0 || m.notificationCursor >= len(m.notificationList) {
+		return app.Notification{}, false
+	}
+	return m.notificationList[m.notificationCursor], true
+}
+
+// unreadNotifications is how many loaded notifications are newer than the
+// read position.
+func (m Model) unreadNotifications() int {
+	if m.notificationsReadID == "" {
+		return 0
+	}
+	n := 0
+	for _, item := range m.notificationList {
+		if idNewer(item.ID, m.notificationsReadID) {
+			n++
+		}
+	}
+	return n
+}
+
+// notificationDividerIndex is the newest read notification when unread ones
+// sit above it, or -1; the dialog draws a divider over it.
+func (m Model) notificationDividerIndex() int {
+	if m.notificationsReadID == "" {
+		return -1
+	}
+	for i, item := range m.notificationList {
+		if !idNewer(item.ID, m.notificationsReadID) {
+			if i == 0 {
+				return -1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// fetchNotificationsUnread counts the newest notifications past the read
+// position for the tab bar.
+func (m Model) fetchNotificationsUnread() tea.Cmd {
+	if m.notifications == nil || m.notificationsReadID == "" {
+		return nil
+	}
+	svc, readID := m.notifications, m.notificationsReadID
+	return func() tea.Msg {
+		items, _, err := svc.Notifications(context.Background(), notificationsPageSize, "")
+		n := 0
+		for _, item := range items {
+			if idNewer(item.ID, readID) {
+				n++
+			}
+		}
+		return notificationsUnreadMsg{count: n, err: err}
+	}
+}
+
+// restoreNotificationPosition moves the cursor to the last read
+// notification, paging older until it turns up.
+func (m *Model) restoreNotificationPosition() tea.Cmd {
+	if !m.restoreNotifications || m.notificationsLoading {
+		return nil
+	}
+	for i, item := range m.notificationList {
+		if !idNewer(item.ID, m.notificationsReadID) {
+			m.restoreNotifications = false
+			m.notificationCursor = i
+			return nil
+		}
+	}
+	if m.notificationsNext == "" || m.notificationPages >= maxRestorePages {
+		// Everything loaded is unread; leave the cursor at the top.
+		m.restoreNotifications = false
+		return nil
+	}
+	m.notificationPages++
+	return m.loadNotifications(m.notificationsNext)
+}
+
+// advanceNotificationsRead marks the selected notification, and everything
+// older, read when it is newer than the saved position, and schedules a save.
+func (m *Model) advanceNotificationsRead() tea.Cmd {
+	if m.markers == nil || !m.markersLoaded || m.restoreNotifications {
+		return nil
+	}
+	n, ok := m.selectedNotification()
+	if !ok || n.ID == "" || !idNewer(n.ID, m.notificationsReadID) {
+		return nil
+	}
+	m.notificationsReadID = n.ID
+	m.notificationSaveSeq++
+	seq := m.notificationSaveSeq
+	return tea.Tick(markerSaveDelay, func(time.Time) tea.Msg {
+		return markerSaveMsg{timeline: app.MarkerNotifications, seq: seq}
+	})
+}
+
+func (m Model) handleNotificationsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.Notifications):
+		m.closeNotifications()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		if m.notificationCursor > 0 {
+			m.notificationCursor--
+		}
+		return m, m.advanceNotificationsRead()
+	case key.Matches(msg, m.keys.Down):
+		if m.notificationCursor < len(m.notificationList)-1 {
+			m.notificationCursor++
+			return m, m.advanceNotificationsRead()
+		}
+		if m.notificationsNext != "" && !m.notificationsLoading {
+			return m, m.loadNotifications(m.notificationsNext)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		n, ok := m.selectedNotification()
+		if !ok {
+			return m, nil
+		}
+		m.closeNotifications()
+		if n.Rant != nil {
+			return m.openRantDetail(*n.Rant)
+		}
+		return m.openProfile(n.Account.ID, false)
+	case key.Matches(msg, m.keys.OpenProfile):
+		n, ok := m.selectedNotification()
+		if !ok {
+			return m, nil
+		}
+		m.closeNotifications()
+		return m.openProfile(n.Account.ID, false)
+	}
+	return m, nil
+}
+
+func (m Model) handleNotificationsMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case NotificationsPageMsg:
+		if !m.showNotifications || msg.Seq != m.notificationsSeq {
+			return m, nil
+		}
+		m.notificationsLoading = false
+		m.notificationsErr = msg.Err
+		if msg.Err != nil {
+			m.restoreNotifications = false
+			return m, nil
+		}
+		m.notificationList = append(m.notificationList, msg.Notifications...)
+		m.notificationsNext = msg.Next
+		if cmd := m.restoreNotificationPosition(); cmd != nil {
+			return m, cmd
+		}
+		return m, m.advanceNotificationsRead()
+
+	case notificationsUnreadMsg:
+		if msg.err == nil {
+			m.notificationsUnread = msg.count
+		}
+		return m, nil
+	}
+	return m, nil
+}
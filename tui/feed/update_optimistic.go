@@ -33,9 +33,73 @@ func (m Model) handleOptimisticMsg(msg tea.Msg) (Model, tea.Cmd) {
 		m.startIndex = 0 // Scroll to top
 		m.scrollLine = 0
 		return m, nil
+	case AddOptimisticThreadMsg:
+		if m.feedSource != sourceTerminalRant {
+			return m, nil
+		}
+		items := make([]RantItem, 0, len(msg.Parts))
+		for i, part := range msg.Parts {
+			items = append(items, RantItem{
+				Rant: domain.Rant{
+					ID:        msg.LocalIDs[i],
+					Content:   part,
+					Author:    "You",
+					Username:  "you",
+					IsOwn:     true,
+					CreatedAt: time.Now(),
+				},
+				Status: StatusPendingCreate,
+			})
+		}
+		// Keep reading order: the first post of the thread on top.
+		m.rants = append(items, m.rants...)
+		m.cursor = 0
+		m.startIndex = 0
+		m.scrollLine = 0
+		return m, nil
+
+	case ThreadPartResultMsg:
+		for i, ri := range m.rants {
+			if ri.Rant.ID != msg.LocalID {
+				continue
+			}
+			if msg.Err != nil {
+				ri.Status = StatusFailed
+				ri.Err = msg.Err
+			} else {
+				ri.Rant = msg.Rant
+				ri.Status = StatusNormal
+			}
+			m.rants[i] = ri
+			return m, nil
+		}
+		if msg.Err == nil {
+			m.reconcileReplyResult(msg.LocalID, msg.Rant)
+		}
+		return m, nil
+
+	case ThreadAbortedMsg:
+		unsent := make(map[string]bool, len(msg.LocalIDs))
+		for _, id := range msg.LocalIDs {
+			unsent[id] = true
+		}
+		for i, ri := range m.rants {
+			if unsent[ri.Rant.ID] {
+				ri.Status = StatusFailed
+				ri.Err = msg.Err
+				m.rants[i] = ri
+			}
+		}
+		m.dropLocalReplies(unsent)
+		return m, nil
+
 	case AddOptimisticReplyMsg:
+		localID := msg.LocalID
+		if localID == "" {
+			localID = fmt.Sprintf("local-reply-%d", time.Now().UnixNano())
+		}
 		reply := domain.Rant{
-			ID:          fmt.Sprintf("local-reply-%d", time.Now().UnixNano()),
+			ID:          localID,
 			Content:     msg.Content,
 			Author:      "You",
 			Username:    "you",
@@ -1,6 +1,7 @@
 package feed
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/CrestNiraj12/terminalrant/domain"
@@ -34,3 +35,33 @@ func TestDeleteOptimisticRant_RemovesItemImmediately(t *testing.T) {
 		t.Fatalf("expected optimistic delete to remove item immediately, got %#v", updated.rants)
 	}
 }
+
+func TestOptimisticThread_ShowsChainAndMarksUnsentOnFailure(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "old"}, Status: StatusNormal}}
+
+	ids := []string{"t0", "t1", "t2"}
+	m, _ = m.Update(AddOptimisticThreadMsg{LocalIDs: ids, Parts: []string{"one (1/3)", "two (2/3)", "three (3/3)"}})
+	if len(m.rants) != 4 || m.rants[0].Rant.ID != "t0" || m.rants[2].Rant.ID != "t2" {
+		t.Fatalf("expected thread on top in reading order, got %#v", m.rants)
+	}
+	for _, ri := range m.rants[:3] {
+		if ri.Status != StatusPendingCreate {
+			t.Fatalf("expected thread posts pending")
+		}
+	}
+
+	m, _ = m.Update(ThreadPartResultMsg{LocalID: "t0", Rant: domain.Rant{ID: "100", Content: "one (1/3)"}})
+	m, _ = m.Update(ThreadPartResultMsg{LocalID: "t1", Err: errors.New("boom")})
+	m, _ = m.Update(ThreadAbortedMsg{LocalIDs: []string{"t2"}, Err: errors.New("not sent")})
+
+	if m.rants[0].Rant.ID != "100" || m.rants[0].Status != StatusNormal {
+		t.Fatalf("expected first post reconciled, got %#v", m.rants[0])
+	}
+	if m.rants[1].Status != StatusFailed || m.rants[2].Status != StatusFailed {
+		t.Fatalf("expected failed and unsent posts marked failed")
+	}
+	if m.rants[2].Err == nil || m.rants[2].Err.Error() != "not sent" {
+		t.Fatalf("expected unsent post to carry the abort reason")
+	}
+}
@@ -0,0 +1,109 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// profileTabCount is the number of profile tabs, for cycling through them.
+const profileTabCount = 3
+
+// switchProfileTab moves delta tabs along and loads the accounts of the new
+// tab.
+func (m Model) switchProfileTab(delta int) (Model, tea.Cmd) {
+	if m.profileLoading || m.profile.ID == "" {
+		return m, nil
+	}
+	m.profileTab = profileTab((int(m.profileTab) + delta + profileTabCount) % profileTabCount)
+	m.profileCursor = 0
+	m.profileStart = 0
+	m.detailScrollLine = 0
+	m.cancelFollowConfirm()
+	switch m.profileTab {
+	case profileTabFollowers:
+		return m, m.profileAccounts.reset(m, listFollowers, m.profile.ID)
+	case profileTabFollowing:
+		return m, m.profileAccounts.reset(m, listFollowing, m.profile.ID)
+	}
+	m.profileAccounts = accountList{seq: m.profileAccounts.seq}
+	return m, nil
+}
+
+// handleProfileAccountsKey handles switching profile tabs and moving through
+// the followers and following lists. It reports false for keys left to the
+// profile view, such as following the profile owner from the card.
+func (m Model) handleProfileAccountsKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.NextTab):
+		m, cmd := m.switchProfileTab(1)
+		return m, cmd, true
+	case key.Matches(msg, m.keys.PrevTab):
+		m, cmd := m.switchProfileTab(-1)
+		return m, cmd, true
+	}
+	if m.profileTab == profileTabPosts {
+		return m, nil, false
+	}
+
+	l := &m.profileAccounts
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.detailScrollLine > 0 {
+			m.detailScrollLine--
+		}
+		if m.profileCursor == 0 {
+			return m, nil, true
+		}
+		if l.cursor == 0 {
+			m.profileCursor = 0
+			return m, nil, true
+		}
+		l.move(m, -1)
+		m.profileCursor = l.cursor + 1
+		return m, nil, true
+
+	case key.Matches(msg, m.keys.Down):
+		if m.profileCursor == 0 {
+			if m.detailScrollLine < m.profileScrollGate() || len(l.items) == 0 {
+				m.detailScrollLine++
+				return m, nil, true
+			}
+			l.cursor = 0
+			m.profileCursor = 1
+			m.detailScrollLine++
+			return m, nil, true
+		}
+		before := l.cursor
+		cmd := l.move(m, 1)
+		if l.cursor != before {
+			m.detailScrollLine++
+		}
+		m.profileCursor = l.cursor + 1
+		return m, cmd, true
+
+	case key.Matches(msg, m.keys.Select) || key.Matches(msg, m.keys.OpenProfile):
+		if m.profileCursor == 0 {
+			return m, nil, true
+		}
+		a, ok := l.selected()
+		if !ok {
+			return m, nil, true
+		}
+		m, cmd := m.openProfile(a.ID, false)
+		return m, cmd, true
+
+	case key.Matches(msg, m.keys.FollowUser):
+		if m.profileCursor == 0 {
+			return m, nil, false
+		}
+		if a, ok := l.selected(); ok {
+			m.confirmFollowAccount(a)
+		}
+		return m, nil, true
+
+	case key.Matches(msg, m.keys.Like):
+		// Nothing to like in an account list.
+		return m, nil, true
+	}
+	return m, nil, false
+}
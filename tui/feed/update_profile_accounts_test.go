@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+// followAccount serves one page of followers and two pages of following.
+type followAccount struct {
+	stubAccount
+}
+
+func (followAccount) Followers(context.Context, string, int, string) ([]app.AccountSummary, string, error) {
+	return []app.AccountSummary{{ID: "7", Username: "alice", DisplayName: "Alice"}}, "", nil
+}
+
+func (followAccount) Following(_ context.Context, _ string, _ int, maxID string) ([]app.AccountSummary, string, error) {
+	if maxID == "" {
+		return []app.AccountSummary{{ID: "8", Username: "bob"}}, "p2", nil
+	}
+	return []app.AccountSummary{{ID: "9", Username: "carol"}}, "", nil
+}
+
+func TestUpdateProfileAccounts_TabsPageAndFollow(t *testing.T) {
+	m := New(stubTimeline{}, followAccount{}, "terminalrant", "terminalrant")
+	m.showProfile = true
+	m.profile = appProfile("42", "dave")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.profileTab != profileTabFollowers || cmd == nil {
+		t.Fatalf("expected followers tab to load")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.profileAccounts.items) != 1 || m.profileAccounts.items[0].ID != "7" {
+		t.Fatalf("unexpected followers: %#v", m.profileAccounts.items)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Followers") || !strings.Contains(view, "@alice") {
+		t.Fatalf("expected follower in profile view:\n%s", view)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(cmd())
+	if m.profileTab != profileTabFollowing || m.profileAccounts.items[0].ID != "8" {
+		t.Fatalf("expected following list, got %#v", m.profileAccounts.items)
+	}
+
+	// Step off the card, then past the last account to load the next page.
+	for m.profileCursor == 0 {
+		m, _ = m.Update(keyRunes("j"))
+	}
+	m, cmd = m.Update(keyRunes("j"))
+	if cmd == nil {
+		t.Fatalf("expected next page request")
+	}
+	m, _ = m.Update(cmd())
+	m, _ = m.Update(keyRunes("j"))
+	if a, ok := m.profileAccounts.selected(); !ok || a.ID != "9" || m.profileCursor != 2 {
+		t.Fatalf("expected carol selected, got %#v cursor=%d", a, m.profileCursor)
+	}
+
+	m, _ = m.Update(keyRunes("f"))
+	if !m.confirmFollow || m.followAccountID != "9" || !m.followTarget {
+		t.Fatalf("expected follow confirmation for carol")
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Follow @carol? (y/n)") {
+		t.Fatalf("expected follow prompt:\n%s", view)
+	}
+	m, cmd = m.Update(keyRunes("y"))
+	if msg, ok := cmd().(FollowToggleMsg); !ok || msg.AccountID != "9" || !msg.Follow {
+		t.Fatalf("expected FollowToggleMsg for carol, got %#v", msg)
+	}
+
+	m, cmd = m.Update(keyRunes("z"))
+	if !m.showProfile || m.profileTab != profileTabPosts || cmd == nil {
+		t.Fatalf("expected carol's profile to open on the posts tab")
+	}
+}
@@ -35,7 +35,10 @@ func (m Model) handleProfileBlockFollowMsg(msg tea.Msg) (Model, tea.Cmd) {
 		if msg.Err != nil {
 			return m, nil
 		}
-		maps.Copy(m.followingByID, msg.Following)
+		maps.Copy(m.relationships, msg.Relationships)
+		for id, rel := range msg.Relationships {
+			m.followingByID[id] = rel.Following
+		}
 		return m, nil
 
 	case ProfileLoadedMsg:
@@ -69,19 +72,29 @@ func (m Model) handleProfileBlockFollowMsg(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		if strings.TrimSpace(msg.AccountID) != "" {
-			m.followingByID[msg.AccountID] = msg.Follow
-			if msg.Follow {
+			was := m.isFollowing(msg.AccountID)
+			following := m.applyFollowResult(msg)
+			if following {
 				m.addRecentFollow(msg.AccountID)
 			} else {
 				m.removeRecentFollow(msg.AccountID)
 			}
-			if m.showProfile && m.profile.ID == msg.AccountID {
-				if msg.Follow {
+			// A sent or withdrawn request changes no counts.
+			if m.showProfile && m.profile.ID == msg.AccountID && was != following {
+				if following {
 					m.profile.Followers++
 				} else if m.profile.Followers > 0 {
 					m.profile.Followers--
 				}
 			}
+			if m.showProfile && m.profileIsOwn && m.profile.ID != msg.AccountID && was != following {
+				// Followed from a list on the user's own profile.
+				if following {
+					m.profile.Following++
+				} else if m.profile.Following > 0 {
+					m.profile.Following--
+				}
+			}
 		}
 		m.followingDirty = true
 		if !msg.Follow {
@@ -108,7 +121,11 @@ func (m Model) handleProfileBlockFollowMsg(msg tea.Msg) (Model, tea.Cmd) {
 		m.loadingBlocked = false
 		m.blockedErr = msg.Err
 		m.blockedUsers = msg.Users
-		if m.blockedCursor >= len(m.blockedUsers) {
+		m.domainBlocks = msg.Domains
+		for _, d := range msg.Domains {
+			m.blockedDomains[strings.ToLower(d)] = true
+		}
+		if m.blockedCursor >= len(m.blockedUsers)+len(m.domainBlocks) {
 			m.blockedCursor = 0
 		}
 		return m, nil
@@ -136,6 +153,33 @@ func (m Model) handleProfileBlockFollowMsg(msg tea.Msg) (Model, tea.Cmd) {
 		m.pagingNotice = "Unblocked @" + msg.Username
 		return m, nil
 
+	case DomainBlockResultMsg:
+		key := strings.ToLower(msg.Domain)
+		if msg.Block {
+			if msg.Err != nil {
+				delete(m.blockedDomains, key)
+			}
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.blockedErr = msg.Err
+			return m, nil
+		}
+		m.blockedErr = nil
+		delete(m.blockedDomains, key)
+		kept := make([]string, 0, len(m.domainBlocks))
+		for _, d := range m.domainBlocks {
+			if strings.ToLower(d) != key {
+				kept = append(kept, d)
+			}
+		}
+		m.domainBlocks = kept
+		if m.blockedCursor >= len(m.blockedUsers)+len(m.domainBlocks) && m.blockedCursor > 0 {
+			m.blockedCursor--
+		}
+		m.pagingNotice = "Unblocked domain " + msg.Domain
+		return m, nil
+
 	}
 
 	return m, nil
@@ -0,0 +1,237 @@
+package feed
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// WithRemoteTimelines lets the feed browse other instances, opening each
+// one through open.
+func (m Model) WithRemoteTimelines(open func(instanceURL string) app.TimelineService) Model {
+	m.remoteTimelines = open
+	return m
+}
+
+// feedTimeline is the service the feed tabs load from: the browsed instance
+// while browsing, otherwise the user's own.
+func (m Model) feedTimeline() app.TimelineService {
+	if m.remote != nil {
+		return m.remote
+	}
+	return m.timeline
+}
+
+// timelineFor is the service that knows the status id.
+func (m Model) timelineFor(id string) app.TimelineService {
+	if m.remote != nil && !m.resolvedIDs[id] {
+		return m.remote
+	}
+	return m.timeline
+}
+
+// isRemoteRant reports whether r was loaded from the browsed instance and
+// so carries ids the user's instance does not know.
+func (m Model) isRemoteRant(r domain.Rant) bool {
+	return m.remote != nil && !m.resolvedIDs[r.ID]
+}
+
+// parseInstanceURL accepts a bare host or a URL and returns the instance
+// base URL and host.
+func parseInstanceURL(raw string) (string, string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", false
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", "", false
+	}
+	host := strings.ToLower(u.Host)
+	return u.Scheme + "://" + host, host, true
+}
+
+func (m Model) openInstanceInput() (Model, tea.Cmd) {
+	if m.remote != nil {
+		return m.leaveRemote()
+	}
+	if m.remoteTimelines == nil {
+		m.pagingNotice = "Browsing other instances is unavailable."
+		return m, nil
+	}
+	m.instanceInput = true
+	m.instanceBuffer = ""
+	return m, nil
+}
+
+func (m Model) handleInstanceInputKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.instanceInput = false
+		m.instanceBuffer = ""
+		return m, nil
+	case key.Matches(msg, m.keys.Submit):
+		m.instanceInput = false
+		instanceURL, host, ok := parseInstanceURL(m.instanceBuffer)
+		m.instanceBuffer = ""
+		if !ok {
+			m.pagingNotice = "Not an instance address."
+			return m, nil
+		}
+		return m.browseRemote(instanceURL, host)
+	case msg.String() == "backspace":
+		if r := []rune(m.instanceBuffer); len(r) > 0 {
+			m.instanceBuffer = string(r[:len(r)-1])
+		}
+		return m, nil
+	}
+	if len(msg.Runes) > 0 {
+		m.instanceBuffer += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// browseRemote switches the feed to the local timeline of another instance.
+func (m Model) browseRemote(instanceURL, host string) (Model, tea.Cmd) {
+	m.remote = m.remoteTimelines(instanceURL)
+	m.remoteHost = host
+	m.remoteReturn = m.feedSource
+	m.resolvedIDs = make(map[string]bool)
+	m.threadCache = make(map[string]threadData)
+	m.closeDetail()
+	m.feedSource = sourceLocal
+	m.prepareSourceChange()
+	m.pagingNotice = "Browsing " + host + " (read-only)"
+	m.feedReqSeq++
+	return m, m.fetchRants(m.feedReqSeq)
+}
+
+// leaveRemote goes back to the tab that was open before browsing.
+func (m Model) leaveRemote() (Model, tea.Cmd) {
+	m.remote = nil
+	m.remoteHost = ""
+	m.resolvedIDs = make(map[string]bool)
+	m.threadCache = make(map[string]threadData)
+	m.closeDetail()
+	m.feedSource = m.remoteReturn
+	if m.feedSource == sourceCustomHashtag && !m.hasCustomTab() {
+		m.feedSource = sourceTerminalRant
+	}
+	m.prepareSourceChange()
+	m.pagingNotice = "Feed: " + m.sourceLabel()
+	m.feedReqSeq++
+	return m, tea.Batch(m.fetchRants(m.feedReqSeq), m.emitPrefsChanged())
+}
+
+func (m *Model) closeDetail() {
+	m.showDetail = false
+	m.focusedRant = nil
+	m.viewStack = nil
+	m.replies = nil
+	m.replyAll = nil
+	m.ancestors = nil
+	m.detailCursor = 0
+	m.detailStart = 0
+	m.detailScrollLine = 0
+}
+
+// needsResolving reports whether msg acts on the selected rant through the
+// user's account.
+func (m Model) needsResolving(msg tea.KeyMsg) bool {
+	for _, b := range []key.Binding{
+		m.keys.Like, m.keys.Reply, m.keys.ReplyInline, m.keys.FollowUser,
+		m.keys.BlockUser, m.keys.Report, m.keys.OpenProfile, m.keys.Interactions,
+	} {
+		if key.Matches(msg, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSelected looks up the selected remote rant on the user's instance
+// before msg acts on it. ok is false when msg needs no resolving, including
+// while a prompt or confirmation takes the key.
+func (m Model) resolveSelected(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.remote == nil || m.IsDialogOpen() || !m.needsResolving(msg) {
+		return m, nil, false
+	}
+	r := m.getSelectedRant()
+	if r.ID == "" || !m.isRemoteRant(r) {
+		return m, nil, false
+	}
+	if r.URL == "" {
+		m.pagingNotice = "Cannot find this post on your instance."
+		return m, nil, true
+	}
+	m.pagingNotice = "Looking up post on your instance..."
+	timeline, remoteID, statusURL := m.timeline, r.ID, r.URL
+	return m, func() tea.Msg {
+		resolved, err := timeline.ResolveStatus(context.Background(), statusURL)
+		return RantResolvedMsg{RemoteID: remoteID, Rant: resolved, Key: msg, Err: err}
+	}, true
+}
+
+// markResolved records rants as coming from the user's instance.
+func (m *Model) markResolved(rants []domain.Rant) {
+	for _, r := range rants {
+		if r.ID != "" {
+			m.resolvedIDs[r.ID] = true
+		}
+	}
+}
+
+func (m Model) handleRemoteMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case RantResolvedMsg:
+		return m.handleRantResolvedMsg(msg)
+	}
+	return m, nil
+}
+
+// handleRantResolvedMsg swaps the remote rant for its resolved copy and
+// replays the key that needed it.
+func (m Model) handleRantResolvedMsg(msg RantResolvedMsg) (Model, tea.Cmd) {
+	if m.remote == nil {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.pagingNotice = "Lookup failed: " + msg.Err.Error()
+		return m, nil
+	}
+	resolved := msg.Rant
+	m.resolvedIDs[resolved.ID] = true
+	swap := func(r *domain.Rant) {
+		if r.ID == msg.RemoteID {
+			*r = resolved
+		}
+	}
+	for i := range m.rants {
+		swap(&m.rants[i].Rant)
+	}
+	for i := range m.replyAll {
+		swap(&m.replyAll[i])
+	}
+	for i := range m.replies {
+		swap(&m.replies[i])
+	}
+	for i := range m.ancestors {
+		swap(&m.ancestors[i])
+	}
+	if m.focusedRant != nil && m.focusedRant.ID == msg.RemoteID {
+		focused := resolved
+		m.focusedRant = &focused
+	}
+	m.pagingNotice = ""
+	next, cmd := m.handleKeyMsg(msg.Key)
+	return next, tea.Batch(cmd, next.fetchRelationshipsForRants([]domain.Rant{resolved}))
+}
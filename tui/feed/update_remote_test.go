@@ -0,0 +1,127 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+type remoteTimeline struct {
+	stubTimeline
+}
+
+func (remoteTimeline) FetchLocalPage(context.Context, int, string) ([]domain.Rant, error) {
+	return []domain.Rant{{ID: "r1", AccountID: "ra1", Username: "alice@remote.example", URL: "https://remote.example/@alice/r1"}}, nil
+}
+
+type resolvingTimeline struct {
+	stubTimeline
+	resolved []string
+}
+
+func (t *resolvingTimeline) ResolveStatus(_ context.Context, statusURL string) (domain.Rant, error) {
+	t.resolved = append(t.resolved, statusURL)
+	return domain.Rant{ID: "h1", AccountID: "ha1", Username: "alice@remote.example", URL: statusURL}, nil
+}
+
+func TestUpdateRemote_BrowsesAndResolvesBeforeInteracting(t *testing.T) {
+	home := &resolvingTimeline{}
+	var openedURL string
+	m := New(home, stubAccount{}, "terminalrant", "terminalrant").WithRemoteTimelines(func(instanceURL string) app.TimelineService {
+		openedURL = instanceURL
+		return remoteTimeline{}
+	})
+
+	m, _ = m.Update(keyRunes("O"))
+	if !m.instanceInput || !m.IsDialogOpen() {
+		t.Fatalf("expected instance input to open")
+	}
+	m, _ = m.Update(keyRunes("Remote.example"))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.remote == nil || m.remoteHost != "remote.example" || openedURL != "https://remote.example" || m.feedSource != sourceLocal || cmd == nil {
+		t.Fatalf("expected to browse remote.example, got host=%q url=%q", m.remoteHost, openedURL)
+	}
+	if order := m.tabOrder(); len(order) != 3 || order[0] != sourceLocal || order[1] != sourceFederated {
+		t.Fatalf("unexpected remote tabs: %v", order)
+	}
+	if m.emitPrefsChanged() != nil {
+		t.Fatalf("expected remote tabs not to be remembered")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.rants) != 1 || m.rants[0].Rant.ID != "r1" {
+		t.Fatalf("expected remote local timeline, got %#v", m.rants)
+	}
+	if !strings.Contains(m.renderTabs(), "browsing remote.example") {
+		t.Fatalf("expected browse banner in tabs: %s", m.renderTabs())
+	}
+
+	// Action keys typed into a prompt are text, not lookups.
+	m, _ = m.Update(keyRunes("H"))
+	prefill := m.hashtagBuffer
+	for _, r := range "golang" {
+		if m, cmd = m.Update(keyRunes(string(r))); cmd != nil {
+			t.Fatalf("expected %q to be typed without a lookup", r)
+		}
+	}
+	if m.hashtagBuffer != prefill+"golang" || len(home.resolved) != 0 {
+		t.Fatalf("expected the prompt to keep every key, got %q (resolved %v)", m.hashtagBuffer, home.resolved)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m, cmd = m.Update(keyRunes("l"))
+	if cmd == nil {
+		t.Fatalf("expected like to resolve the post first")
+	}
+	resolved, ok := cmd().(RantResolvedMsg)
+	if !ok || resolved.RemoteID != "r1" || len(home.resolved) != 1 || home.resolved[0] != "https://remote.example/@alice/r1" {
+		t.Fatalf("unexpected resolve: %#v", resolved)
+	}
+	m, cmd = m.Update(resolved)
+	if m.rants[0].Rant.ID != "h1" || m.isRemoteRant(m.rants[0].Rant) {
+		t.Fatalf("expected the rant to be swapped for its resolved copy, got %#v", m.rants[0].Rant)
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected the like to be replayed")
+	}
+	liked := false
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if like, ok := c().(LikeRantMsg); ok && like.ID == "h1" {
+			liked = true
+		}
+	}
+	if !liked {
+		t.Fatalf("expected the like to target the resolved id")
+	}
+
+	m, _ = m.Update(keyRunes("O"))
+	if m.remote != nil || m.feedSource != sourceTerminalRant || len(m.resolvedIDs) != 0 {
+		t.Fatalf("expected O to leave browse mode")
+	}
+}
+
+func TestParseInstanceURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"mastodon.social":             "https://mastodon.social",
+		" https://fosstodon.org/@me ": "https://fosstodon.org",
+		"http://localhost:3000":       "http://localhost:3000",
+	} {
+		got, _, ok := parseInstanceURL(in)
+		if !ok || got != want {
+			t.Fatalf("parseInstanceURL(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "ftp://example.com", "https://"} {
+		if _, _, ok := parseInstanceURL(in); ok {
+			t.Fatalf("expected %q to be rejected", in)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package feed
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+// reportCommentLimit is the longest comment Mastodon accepts on a report.
+const reportCommentLimit = 1000
+
+// openReport starts a report of r's author with r attached. The author's
+// other recent rants and the instance rules load in the background.
+func (m Model) openReport(r domain.Rant) (Model, tea.Cmd) {
+	if r.AccountID == "" || r.IsOwn {
+		m.pagingNotice = "Cannot report this post."
+		return m, nil
+	}
+	m.showReport = true
+	m.reportAccountID = r.AccountID
+	m.reportUsername = r.Username
+	m.reportCategory = 0
+	m.reportRules = nil
+	m.reportRuleIDs = make(map[string]bool)
+	m.reportPosts = []domain.Rant{r}
+	m.reportStatusIDs = map[string]bool{r.ID: r.ID != ""}
+	m.reportComment = ""
+	m.reportEditing = false
+	m.reportForward = false
+	m.reportCursor = 0
+	m.reportLoading = true
+	m.reportSubmitting = false
+	m.reportErr = nil
+	m.reportSeq++
+	seq := m.reportSeq
+	return m, tea.Batch(
+		m.fetchReportPosts(r.AccountID, seq),
+		func() tea.Msg { return RequestReportRulesMsg{Seq: seq} },
+	)
+}
+
+func (m *Model) closeReport() {
+	m.showReport = false
+	m.reportRules = nil
+	m.reportPosts = nil
+	m.reportEditing = false
+	m.reportLoading = false
+	m.reportSubmitting = false
+	m.reportSeq++
+}
+
+func (m Model) reportCategoryValue() app.ReportCategory {
+	return app.ReportCategories[m.reportCategory]
+}
+
+// reportRuleRows is how many rule rows follow the category row. Rules only
+// apply to violation reports.
+func (m Model) reportRuleRows() int {
+	if m.reportCategoryValue() != app.ReportViolation {
+		return 0
+	}
+	return len(m.reportRules)
+}
+
+func (m Model) reportRows() int {
+	return 1 + m.reportRuleRows() + len(m.reportPosts)
+}
+
+// reportIsRemote reports whether the author lives on another instance, so
+// the report can be forwarded there.
+func (m Model) reportIsRemote() bool {
+	return strings.Contains(m.reportUsername, "@")
+}
+
+func (m *Model) cycleReportCategory(delta int) {
+	n := len(app.ReportCategories)
+	m.reportCategory = (m.reportCategory + delta + n) % n
+	m.reportCursor = min(m.reportCursor, m.reportRows()-1)
+}
+
+// toggleReportRow flips the rule or post under the cursor; on the category
+// row it moves to the next category.
+func (m *Model) toggleReportRow() {
+	row := m.reportCursor
+	if row == 0 {
+		m.cycleReportCategory(1)
+		return
+	}
+	row--
+	if row < m.reportRuleRows() {
+		id := m.reportRules[row].ID
+		m.reportRuleIDs[id] = !m.reportRuleIDs[id]
+		return
+	}
+	row -= m.reportRuleRows()
+	if row < len(m.reportPosts) {
+		id := m.reportPosts[row].ID
+		m.reportStatusIDs[id] = !m.reportStatusIDs[id]
+	}
+}
+
+// buildReport assembles the report from the dialog, keeping the order in
+// which rules and posts are listed.
+func (m Model) buildReport() (app.Report, error) {
+	r := app.Report{
+		AccountID: m.reportAccountID,
+		Category:  m.reportCategoryValue(),
+		Comment:   strings.TrimSpace(m.reportComment),
+		Forward:   m.reportForward && m.reportIsRemote(),
+	}
+	for _, p := range m.reportPosts {
+		if m.reportStatusIDs[p.ID] {
+			r.StatusIDs = append(r.StatusIDs, p.ID)
+		}
+	}
+	if r.Category == app.ReportViolation {
+		for _, rule := range m.reportRules {
+			if m.reportRuleIDs[rule.ID] {
+				r.RuleIDs = append(r.RuleIDs, rule.ID)
+			}
+		}
+		if len(r.RuleIDs) == 0 {
+			return app.Report{}, errors.New("pick at least one rule that was broken")
+		}
+	}
+	return r, nil
+}
+
+func (m Model) handleReportCommentKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel, m.keys.Submit):
+		m.reportEditing = false
+	case msg.String() == "backspace":
+		if r := []rune(m.reportComment); len(r) > 0 {
+			m.reportComment = string(r[:len(r)-1])
+		}
+	default:
+		if len(msg.Runes) > 0 && len([]rune(m.reportComment))+len(msg.Runes) <= reportCommentLimit {
+			m.reportComment += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleReportKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.reportEditing {
+		return m.handleReportCommentKey(msg)
+	}
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit):
+		m.closeReport()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		if m.reportCursor > 0 {
+			m.reportCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.reportCursor < m.reportRows()-1 {
+			m.reportCursor++
+		}
+	case key.Matches(msg, m.keys.PickPrev):
+		if m.reportCursor == 0 {
+			m.cycleReportCategory(-1)
+		}
+	case key.Matches(msg, m.keys.PickNext):
+		if m.reportCursor == 0 {
+			m.cycleReportCategory(1)
+		}
+	case key.Matches(msg, m.keys.Check):
+		m.toggleReportRow()
+	case key.Matches(msg, m.keys.Comment):
+		m.reportEditing = true
+	case key.Matches(msg, m.keys.Forward):
+		if m.reportIsRemote() {
+			m.reportForward = !m.reportForward
+		}
+	case key.Matches(msg, m.keys.Select):
+		if m.reportSubmitting {
+			return m, nil
+		}
+		report, err := m.buildReport()
+		if err != nil {
+			m.reportErr = err
+			return m, nil
+		}
+		m.reportErr = nil
+		m.reportSubmitting = true
+		username := m.reportUsername
+		return m, func() tea.Msg { return SubmitReportMsg{Report: report, Username: username} }
+	}
+	return m, nil
+}
+
+func (m Model) handleReportMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ReportRulesLoadedMsg:
+		if !m.showReport || msg.Seq != m.reportSeq {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.reportErr = msg.Err
+			return m, nil
+		}
+		m.reportRules = msg.Rules
+		return m, nil
+
+	case ReportPostsLoadedMsg:
+		if !m.showReport || msg.Seq != m.reportSeq {
+			return m, nil
+		}
+		m.reportLoading = false
+		if msg.Err != nil {
+			m.reportErr = msg.Err
+			return m, nil
+		}
+		// The reported rant stays first. Only the author's own rants can be
+		// attached to a report of them.
+		for _, p := range msg.Posts {
+			if p.ID == "" || p.AccountID != m.reportAccountID || m.reportHasPost(p.ID) {
+				continue
+			}
+			m.reportPosts = append(m.reportPosts, p)
+		}
+		return m, nil
+
+	case ReportResultMsg:
+		if !m.showReport || msg.AccountID != m.reportAccountID {
+			return m, nil
+		}
+		m.reportSubmitting = false
+		if msg.Err != nil {
+			m.reportErr = msg.Err
+			return m, nil
+		}
+		m.closeReport()
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) reportHasPost(id string) bool {
+	for _, p := range m.reportPosts {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
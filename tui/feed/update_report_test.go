@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+type reportingAccount struct {
+	stubAccount
+}
+
+func (reportingAccount) PostsByAccount(context.Context, string, int, string) ([]domain.Rant, error) {
+	return []domain.Rant{
+		{ID: "r1", AccountID: "a1", Content: "the reported post"},
+		{ID: "r2", AccountID: "a1", Content: "an older post"},
+		{ID: "r3", AccountID: "a2", Content: "a boost of someone else"},
+	}, nil
+}
+
+func TestUpdateReport_BuildsAndSubmitsReport(t *testing.T) {
+	m := New(stubTimeline{}, reportingAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "r1", AccountID: "a1", Username: "bob@remote.example", Content: "the reported post"}}}
+
+	m, cmd := m.Update(keyRunes("!"))
+	if !m.showReport || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected report dialog to open and load")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected posts and rules to load together")
+	}
+	for _, c := range batch {
+		m, _ = m.Update(c())
+	}
+	m, _ = m.Update(ReportRulesLoadedMsg{Seq: m.reportSeq, Rules: []app.Rule{{ID: "7", Text: "No harassment"}}})
+	if len(m.reportPosts) != 2 || m.reportPosts[0].ID != "r1" || !m.reportStatusIDs["r1"] || m.reportStatusIDs["r2"] {
+		t.Fatalf("expected the selected post first and attached, got %#v", m.reportPosts)
+	}
+
+	// spam -> legal -> violation shows the rules.
+	m, _ = m.Update(keyRunes("l"))
+	m, _ = m.Update(keyRunes("l"))
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.reportErr == nil {
+		t.Fatalf("expected a violation report without rules to be refused")
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Report @bob@remote.example", "Breaks server rules", "[ ] 1. No harassment", "[x] the reported post", "Forward to remote.example: no"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in report dialog:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(keyRunes("j"))
+	m, _ = m.Update(keyRunes(" "))
+	m, _ = m.Update(keyRunes("j"))
+	m, _ = m.Update(keyRunes("j"))
+	m, _ = m.Update(keyRunes("x"))
+	m, _ = m.Update(keyRunes("c"))
+	m, _ = m.Update(keyRunes("q"))
+	if !m.showReport || m.reportComment != "q" {
+		t.Fatalf("expected typing to go to the comment, got %q", m.reportComment)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m, _ = m.Update(keyRunes("abuse"))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(keyRunes("f"))
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.reportSubmitting {
+		t.Fatalf("expected report to be submitted")
+	}
+	submit, ok := cmd().(SubmitReportMsg)
+	want := app.Report{
+		AccountID: "a1",
+		StatusIDs: []string{"r1", "r2"},
+		Category:  app.ReportViolation,
+		RuleIDs:   []string{"7"},
+		Comment:   "abuse",
+		Forward:   true,
+	}
+	if !ok || !reflect.DeepEqual(submit.Report, want) {
+		t.Fatalf("unexpected report: %#v", submit.Report)
+	}
+
+	m, _ = m.Update(ReportResultMsg{AccountID: "a1", Username: "bob@remote.example"})
+	if m.showReport || m.IsDialogOpen() {
+		t.Fatalf("expected dialog to close after a successful report")
+	}
+}
+
+func TestUpdateReport_RefusesOwnPosts(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "r1", AccountID: "me", IsOwn: true}}}
+
+	m, cmd := m.Update(keyRunes("!"))
+	if m.showReport || cmd != nil || m.pagingNotice == "" {
+		t.Fatalf("expected own post not to be reportable")
+	}
+}
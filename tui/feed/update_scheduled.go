@@ -0,0 +1,217 @@
+package feed
+
+import (
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
+)
+
+func (m Model) openScheduled() (Model, tea.Cmd) {
+	m.showScheduled = true
+	m.loadingScheduled = true
+	m.scheduledErr = nil
+	m.scheduled = nil
+	m.scheduledCursor = 0
+	m.scheduledNext = ""
+	m.confirmCancelScheduled = false
+	m.rescheduleInput = false
+	m.rescheduleBuffer = ""
+	return m, m.loadScheduled("")
+}
+
+// loadScheduled requests the page after maxID. A newer request makes older
+// results stale.
+func (m *Model) loadScheduled(maxID string) tea.Cmd {
+	m.loadingScheduled = true
+	m.scheduledSeq++
+	seq := m.scheduledSeq
+	return func() tea.Msg { return RequestScheduledPostsMsg{Seq: seq, MaxID: maxID} }
+}
+
+func (m *Model) closeScheduled() {
+	m.showScheduled = false
+	m.scheduled = nil
+	m.loadingScheduled = false
+	m.scheduledSeq++
+	m.confirmCancelScheduled = false
+	m.rescheduleInput = false
+	m.rescheduleBuffer = ""
+}
+
+func (m Model) selectedScheduledIndex() int {
+	if m.scheduledCursor < 0 || m.scheduledCursor >= len(m.scheduled) {
+		return -1
+	}
+	return m.scheduledCursor
+}
+
+func (m Model) handleScheduledKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.rescheduleInput {
+		switch {
+		case key.Matches(msg, m.keys.Cancel):
+			m.rescheduleInput = false
+			m.rescheduleBuffer = ""
+			return m, nil
+		case key.Matches(msg, m.keys.Submit):
+			idx := m.selectedScheduledIndex()
+			if idx < 0 {
+				m.rescheduleInput = false
+				return m, nil
+			}
+			at, err := common.ParseScheduleTime(m.rescheduleBuffer, time.Now())
+			if err != nil {
+				m.scheduledErr = err
+				return m, nil
+			}
+			m.rescheduleInput = false
+			m.rescheduleBuffer = ""
+			m.scheduledErr = nil
+			id := m.scheduled[idx].ID
+			return m, func() tea.Msg { return RescheduleScheduledMsg{ID: id, At: at} }
+		case msg.String() == "backspace":
+			if len(m.rescheduleBuffer) > 0 {
+				r := []rune(m.rescheduleBuffer)
+				m.rescheduleBuffer = string(r[:len(r)-1])
+			}
+			return m, nil
+		}
+		if len(msg.Runes) > 0 {
+			m.rescheduleBuffer += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit):
+		m.closeScheduled()
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		m.confirmCancelScheduled = false
+		if m.scheduledCursor > 0 {
+			m.scheduledCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		m.confirmCancelScheduled = false
+		if m.scheduledCursor < len(m.scheduled)-1 {
+			m.scheduledCursor++
+			return m, nil
+		}
+		if m.scheduledNext != "" && !m.loadingScheduled {
+			return m, m.loadScheduled(m.scheduledNext)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Reschedule):
+		if m.selectedScheduledIndex() < 0 {
+			return m, nil
+		}
+		m.confirmCancelScheduled = false
+		m.rescheduleInput = true
+		m.rescheduleBuffer = ""
+		return m, nil
+	case key.Matches(msg, m.keys.Delete):
+		if m.selectedScheduledIndex() < 0 {
+			return m, nil
+		}
+		m.confirmCancelScheduled = true
+		return m, nil
+	case key.Matches(msg, m.keys.Yes):
+		idx := m.selectedScheduledIndex()
+		if !m.confirmCancelScheduled || idx < 0 {
+			return m, nil
+		}
+		m.confirmCancelScheduled = false
+		id := m.scheduled[idx].ID
+		return m, func() tea.Msg { return CancelScheduledMsg{ID: id} }
+	case key.Matches(msg, m.keys.No):
+		m.confirmCancelScheduled = false
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleScheduledMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ScheduledPostsLoadedMsg:
+		if !m.showScheduled || msg.Seq != m.scheduledSeq {
+			return m, nil
+		}
+		m.loadingScheduled = false
+		m.scheduledErr = msg.Err
+		if msg.Err != nil {
+			return m, nil
+		}
+		// Pages follow the server's order rather than the publish time, so
+		// keep the whole list soonest first and the cursor on its post.
+		selected := ""
+		if idx := m.selectedScheduledIndex(); idx >= 0 {
+			selected = m.scheduled[idx].ID
+		}
+		m.scheduled = append(m.scheduled, msg.Posts...)
+		m.scheduledNext = msg.Next
+		sortScheduled(m.scheduled)
+		m.scheduledCursor = 0
+		for i := range m.scheduled {
+			if m.scheduled[i].ID == selected {
+				m.scheduledCursor = i
+				break
+			}
+		}
+		return m, nil
+
+	case RescheduleResultMsg:
+		if msg.Err != nil {
+			m.scheduledErr = msg.Err
+			return m, nil
+		}
+		m.scheduledErr = nil
+		for i := range m.scheduled {
+			if m.scheduled[i].ID == msg.Post.ID {
+				m.scheduled[i].ScheduledAt = msg.Post.ScheduledAt
+				break
+			}
+		}
+		// Keep the list soonest first and the cursor on the moved post.
+		sortScheduled(m.scheduled)
+		for i := range m.scheduled {
+			if m.scheduled[i].ID == msg.Post.ID {
+				m.scheduledCursor = i
+				break
+			}
+		}
+		m.pagingNotice = "Rescheduled for " + common.FormatScheduleTime(msg.Post.ScheduledAt)
+		return m, nil
+
+	case CancelScheduledResultMsg:
+		m.confirmCancelScheduled = false
+		if msg.Err != nil {
+			m.scheduledErr = msg.Err
+			return m, nil
+		}
+		m.scheduledErr = nil
+		filtered := make([]app.ScheduledPost, 0, len(m.scheduled))
+		for _, p := range m.scheduled {
+			if p.ID == msg.ID {
+				continue
+			}
+			filtered = append(filtered, p)
+		}
+		m.scheduled = filtered
+		if m.scheduledCursor >= len(m.scheduled) && m.scheduledCursor > 0 {
+			m.scheduledCursor--
+		}
+		m.pagingNotice = "Scheduled post cancelled"
+		return m, nil
+	}
+	return m, nil
+}
+
+// sortScheduled orders posts soonest first.
+func sortScheduled(posts []app.ScheduledPost) {
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].ScheduledAt.Before(posts[j].ScheduledAt) })
+}
@@ -0,0 +1,144 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+)
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestUpdateKey_ManageScheduled_OpensDialogAndRequestsList(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+
+	updated, cmd := m.Update(keyRunes("S"))
+	if !updated.showScheduled || !updated.loadingScheduled {
+		t.Fatalf("expected scheduled posts dialog to open and load")
+	}
+	if !updated.IsDialogOpen() {
+		t.Fatalf("expected scheduled posts dialog to count as open dialog")
+	}
+	if cmd == nil {
+		t.Fatalf("expected request command for scheduled posts")
+	}
+	if _, ok := cmd().(RequestScheduledPostsMsg); !ok {
+		t.Fatalf("expected RequestScheduledPostsMsg")
+	}
+}
+
+func TestUpdateScheduled_CancelRequiresConfirmation(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m, _ = m.Update(keyRunes("S"))
+	m, _ = m.Update(ScheduledPostsLoadedMsg{Seq: m.scheduledSeq, Posts: []app.ScheduledPost{
+		{ID: "s1", ScheduledAt: time.Now().Add(time.Hour), Content: "first"},
+		{ID: "s2", ScheduledAt: time.Now().Add(2 * time.Hour), Content: "second"},
+	}})
+
+	m, _ = m.Update(keyRunes("j"))
+	m, cmd := m.Update(keyRunes("d"))
+	if cmd != nil || !m.confirmCancelScheduled {
+		t.Fatalf("expected cancel confirmation before any request")
+	}
+	m, cmd = m.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatalf("expected cancel command after confirmation")
+	}
+	msg, ok := cmd().(CancelScheduledMsg)
+	if !ok || msg.ID != "s2" {
+		t.Fatalf("expected CancelScheduledMsg for s2, got %#v", msg)
+	}
+
+	m, _ = m.Update(CancelScheduledResultMsg{ID: "s2"})
+	if len(m.scheduled) != 1 || m.scheduledCursor != 0 {
+		t.Fatalf("expected cancelled post removed and cursor clamped, got %d posts cursor %d", len(m.scheduled), m.scheduledCursor)
+	}
+}
+
+func TestUpdateScheduled_RescheduleParsesInputAndResorts(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m, _ = m.Update(keyRunes("S"))
+	soon := time.Now().Add(time.Hour)
+	m, _ = m.Update(ScheduledPostsLoadedMsg{Seq: m.scheduledSeq, Posts: []app.ScheduledPost{
+		{ID: "s1", ScheduledAt: soon, Content: "first"},
+		{ID: "s2", ScheduledAt: soon.Add(time.Hour), Content: "second"},
+	}})
+
+	m, _ = m.Update(keyRunes("r"))
+	if !m.rescheduleInput {
+		t.Fatalf("expected reschedule input to open")
+	}
+	m, cmd := m.Update(keyRunes("nonsense"))
+	if cmd != nil {
+		t.Fatalf("expected no command while typing")
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.scheduledErr == nil || !m.rescheduleInput {
+		t.Fatalf("expected parse error to keep input open")
+	}
+
+	m.rescheduleBuffer = "in 5h"
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected reschedule command")
+	}
+	req, ok := cmd().(RescheduleScheduledMsg)
+	if !ok || req.ID != "s1" || req.At.Before(soon.Add(time.Hour)) {
+		t.Fatalf("unexpected reschedule request %#v", req)
+	}
+
+	m, _ = m.Update(RescheduleResultMsg{Post: app.ScheduledPost{ID: "s1", ScheduledAt: req.At}})
+	if m.scheduled[1].ID != "s1" || m.scheduledCursor != 1 {
+		t.Fatalf("expected rescheduled post to move after s2 with cursor following it")
+	}
+}
+
+func TestUpdateScheduled_LoadsNextPagePastTheEnd(t *testing.T) {
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m, cmd := m.Update(keyRunes("S"))
+	first, ok := cmd().(RequestScheduledPostsMsg)
+	if !ok || first.MaxID != "" {
+		t.Fatalf("expected a request for the first page, got %#v", first)
+	}
+	soon := time.Now().Add(time.Hour)
+	m, _ = m.Update(ScheduledPostsLoadedMsg{Seq: first.Seq, Next: "s2", Posts: []app.ScheduledPost{
+		{ID: "s1", ScheduledAt: soon, Content: "first"},
+		{ID: "s2", ScheduledAt: soon.Add(2 * time.Hour), Content: "second"},
+	}})
+
+	m, _ = m.Update(keyRunes("j"))
+	m, cmd = m.Update(keyRunes("j"))
+	if cmd == nil {
+		t.Fatalf("expected j past the end to request the next page")
+	}
+	next, ok := cmd().(RequestScheduledPostsMsg)
+	if !ok || next.MaxID != "s2" || next.Seq == first.Seq {
+		t.Fatalf("unexpected next page request %#v", next)
+	}
+	if _, cmd = m.Update(keyRunes("j")); cmd != nil {
+		t.Fatalf("expected no second request while a page loads")
+	}
+
+	// A stale page from the first request is dropped.
+	m, _ = m.Update(ScheduledPostsLoadedMsg{Seq: first.Seq, Posts: []app.ScheduledPost{{ID: "stale"}}})
+	if len(m.scheduled) != 2 {
+		t.Fatalf("expected stale page ignored, got %d posts", len(m.scheduled))
+	}
+
+	m, _ = m.Update(ScheduledPostsLoadedMsg{Seq: next.Seq, Posts: []app.ScheduledPost{
+		{ID: "s3", ScheduledAt: soon.Add(time.Hour), Content: "between"},
+	}})
+	if len(m.scheduled) != 3 || m.scheduled[1].ID != "s3" || m.scheduledNext != "" {
+		t.Fatalf("expected the page merged soonest first, got %#v next=%q", m.scheduled, m.scheduledNext)
+	}
+	if m.scheduled[m.scheduledCursor].ID != "s2" {
+		t.Fatalf("expected the cursor to stay on s2, got %s", m.scheduled[m.scheduledCursor].ID)
+	}
+	if _, cmd = m.Update(keyRunes("j")); cmd != nil {
+		t.Fatalf("expected no request after the last page")
+	}
+}
@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openTrends shows trending hashtags; tab switches to trending links.
+func (m Model) openTrends() (Model, tea.Cmd) {
+	m.showTrends = true
+	return m.loadTrends(trendsTabTags)
+}
+
+func (m *Model) closeTrends() {
+	m.showTrends = false
+	m.trendTags = nil
+	m.trendLinks = nil
+	m.trendsLoading = false
+	m.trendsErr = nil
+	m.trendsSeq++
+}
+
+// loadTrends switches to tab and fetches its list afresh.
+func (m Model) loadTrends(tab trendsTab) (Model, tea.Cmd) {
+	m.trendsTab = tab
+	m.trendsCursor = 0
+	m.trendTags = nil
+	m.trendLinks = nil
+	m.trendsErr = nil
+	m.trendsLoading = true
+	m.trendsSeq++
+	return m, m.fetchTrends(tab, m.trendsSeq)
+}
+
+func (m Model) trendsLen() int {
+	if m.trendsTab == trendsTabLinks {
+		return len(m.trendLinks)
+	}
+	return len(m.trendTags)
+}
+
+func (m Model) handleTrendsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.Trends):
+		m.closeTrends()
+		return m, nil
+	case key.Matches(msg, m.keys.NextTab, m.keys.PrevTab):
+		if m.trendsTab == trendsTabTags {
+			return m.loadTrends(trendsTabLinks)
+		}
+		return m.loadTrends(trendsTabTags)
+	case key.Matches(msg, m.keys.Up):
+		if m.trendsCursor > 0 {
+			m.trendsCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		if m.trendsCursor < m.trendsLen()-1 {
+			m.trendsCursor++
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Refresh):
+		return m.loadTrends(m.trendsTab)
+	case key.Matches(msg, m.keys.Select, m.keys.Open):
+		if m.trendsCursor >= m.trendsLen() {
+			return m, nil
+		}
+		if m.trendsTab == trendsTabLinks {
+			return m, openURL(m.trendLinks[m.trendsCursor].URL)
+		}
+		tag := m.trendTags[m.trendsCursor].Name
+		m.closeTrends()
+		return m.switchToHashtag(tag)
+	}
+	return m, nil
+}
+
+func (m Model) handleTrendsMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case TrendsLoadedMsg:
+		if !m.showTrends || msg.Seq != m.trendsSeq || msg.Tab != m.trendsTab {
+			return m, nil
+		}
+		m.trendsLoading = false
+		m.trendsErr = msg.Err
+		m.trendTags = msg.Tags
+		m.trendLinks = msg.Links
+		return m, nil
+	}
+	return m, nil
+}
@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+)
+
+type trendingTimeline struct {
+	stubTimeline
+}
+
+func (trendingTimeline) TrendingTags(context.Context, int) ([]app.TrendingTag, error) {
+	return []app.TrendingTag{{Name: "golang", Uses: []int{0, 7, 14}, Accounts: 9}}, nil
+}
+
+func (trendingTimeline) TrendingLinks(context.Context, int) ([]app.TrendingLink, error) {
+	return []app.TrendingLink{{
+		URL:         "https://example.com/go",
+		Title:       "Go 2 is out",
+		Description: "Release notes",
+		Provider:    "Example News",
+		Uses:        []int{1, 2},
+	}}, nil
+}
+
+func TestUpdateTrends_TagsAndLinks(t *testing.T) {
+	m := New(trendingTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
+	m.rants = []RantItem{{Rant: domain.Rant{ID: "root", Content: "hello"}}}
+
+	m, cmd := m.Update(keyRunes("N"))
+	if !m.showTrends || !m.IsDialogOpen() || cmd == nil {
+		t.Fatalf("expected trends dialog to open and load")
+	}
+	m, _ = m.Update(cmd())
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"#golang", "▁▄█", "21 posts by 9 people"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in trending tags:\n%s", want, view)
+		}
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	stale := TrendsLoadedMsg{Tab: trendsTabTags, Seq: m.trendsSeq - 1, Tags: []app.TrendingTag{{Name: "stale"}}}
+	m, _ = m.Update(stale)
+	m, _ = m.Update(cmd())
+	view = ansi.Strip(m.View())
+	for _, want := range []string{"Go 2 is out", "Example News • ", "3 posts", "Release notes"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in trending links:\n%s", want, view)
+		}
+	}
+	if m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || !m.showTrends {
+		t.Fatalf("expected enter to open the link and keep the dialog")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(cmd())
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showTrends || m.feedSource != sourceCustomHashtag || m.hashtag != "golang" || cmd == nil {
+		t.Fatalf("expected enter on a tag to open its feed")
+	}
+}
@@ -19,6 +19,51 @@ func (m Model) View() string {
 		return applyHorizontalPan(out, m.hScroll, m.width)
 	}
 
+	if m.showScheduled {
+		out = m.withKeyDialog(m.renderScheduledView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showDrafts {
+		out = m.withKeyDialog(m.renderDraftsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showInteractions {
+		out = m.withKeyDialog(m.renderInteractionsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showHistory {
+		out = m.withKeyDialog(m.renderHistoryView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showTrends {
+		out = m.withKeyDialog(m.renderTrendsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showReport {
+		out = m.withKeyDialog(m.renderReportView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showFollowedTags {
+		out = m.withKeyDialog(m.renderFollowedTagsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showFollowRequests {
+		out = m.withKeyDialog(m.renderFollowRequestsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
+	if m.showNotifications {
+		out = m.withKeyDialog(m.renderNotificationsView())
+		return applyHorizontalPan(out, m.hScroll, m.width)
+	}
+
 	if m.showProfile {
 		out = m.withKeyDialog(m.renderProfileView())
 		return applyHorizontalPan(out, m.hScroll, m.width)
@@ -38,6 +83,12 @@ func (m Model) View() string {
 	if m.hashtagInput {
 		b.WriteString(m.renderHashtagInputBar() + "\n")
 	}
+	if m.instanceInput {
+		b.WriteString(m.renderInstanceInputBar() + "\n")
+	}
+	if m.linkInput {
+		b.WriteString(m.renderLinkInputBar() + "\n")
+	}
 	b.WriteString(m.helpView())
 	out = m.withKeyDialog(b.String())
 	return applyHorizontalPan(out, m.hScroll, m.width)
@@ -134,6 +185,24 @@ func (m Model) renderHashtagInputBar() string {
 		Render(" Set hashtag: #" + m.hashtagBuffer + " (enter: apply, esc: cancel) ")
 }
 
+func (m Model) renderInstanceInputBar() string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#111111")).
+		Background(lipgloss.Color("#FFB454")).
+		Bold(true).
+		Padding(0, 1).
+		Render(" Browse instance: " + m.instanceBuffer + " (enter: open, esc: cancel) ")
+}
+
+func (m Model) renderLinkInputBar() string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#111111")).
+		Background(lipgloss.Color("#FFB454")).
+		Bold(true).
+		Padding(0, 1).
+		Render(" Open URL: " + m.linkBuffer + " (enter: open, esc: cancel) ")
+}
+
 func (m Model) renderFeedList(visibleIndices []int, cardWidth, bodyWidth int, showPreviewPanel bool) string {
 	viewHeight := m.feedViewportHeight()
 	spans := m.feedVisibleSpans(visibleIndices)
@@ -149,9 +218,13 @@ func (m Model) renderFeedList(visibleIndices []int, cardWidth, bodyWidth int, sh
 		startPos = m.feedStartPosFromScrollLine(spans, m.scrollLine)
 	}
 
+	dividerID := m.readDividerID()
 	var listBuilder strings.Builder
 	for pos := startPos; pos < len(spans); pos++ {
 		idx := spans[pos].idx
+		if dividerID != "" && m.rants[idx].Rant.ID == dividerID {
+			listBuilder.WriteString(renderReadDivider(cardWidth) + "\n")
+		}
 		listBuilder.WriteString(m.renderFeedCard(idx, cardWidth, bodyWidth))
 		listBuilder.WriteString("\n")
 	}
@@ -183,6 +256,15 @@ func (m Model) renderFeedList(visibleIndices []int, cardWidth, bodyWidth int, sh
 	return lipgloss.JoinHorizontal(lipgloss.Top, listPane, "  ", previewPane)
 }
 
+// renderReadDivider separates unread posts from the ones already read.
+func renderReadDivider(width int) string {
+	label := " read up to here "
+	side := max((width-len(label))/2, 2)
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF8700")).
+		Render(strings.Repeat("─", side) + label + strings.Repeat("─", side))
+}
+
 func (m Model) feedGutter(showUp, showDown bool, visibleCount int) []string {
 	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
 	gutter := make([]string, visibleCount)
@@ -201,11 +283,11 @@ func (m Model) feedGutter(showUp, showDown bool, visibleCount int) []string {
 func (m Model) renderFeedCard(i, cardWidth, bodyWidth int) string {
 	rantItem := m.rants[i]
 	rant := rantItem.Rant
-	author := renderAuthor(rant.Username, rant.IsOwn, m.isFollowing(rant.AccountID))
+	author := renderAuthor(rant.Username, rant.IsOwn, m.isFollowing(rant.AccountID)) + m.followsYouBadge(rant.AccountID)
 	if rant.IsOwn {
 		author += common.OwnBadgeStyle.Render("(you)")
 	}
-	timestamp := common.TimestampStyle.Render(rant.CreatedAt.Format("Jan 02 15:04"))
+	timestamp := common.TimestampStyle.Render(rant.CreatedAt.Format("Jan 02 15:04")) + editedMarker(rant)
 
 	replyIndicator := ""
 	if rant.InReplyToID != "" && rant.InReplyToID != "<nil>" && rant.InReplyToID != "0" {
@@ -286,17 +368,13 @@ func (m Model) renderFeedCard(i, cardWidth, bodyWidth int) string {
 		}
 		itemContent = itemSelected.Render(itemContent)
 		if m.confirmDelete {
-			itemContent += "\n" + common.ConfirmStyle.Render("  Delete this rant? (y/n)")
+			itemContent += "\n" + common.ConfirmStyle.Render("  "+m.deletePrompt("rant"))
 		}
 		if m.confirmBlock {
-			itemContent += "\n" + common.ConfirmStyle.Render(fmt.Sprintf("  Block @%s? (y/n)", m.blockUsername))
+			itemContent += "\n" + common.ConfirmStyle.Render("  "+m.blockPrompt())
 		}
 		if m.confirmFollow {
-			action := "Follow"
-			if !m.followTarget {
-				action = "Unfollow"
-			}
-			itemContent += "\n" + common.ConfirmStyle.Render(fmt.Sprintf("  %s @%s? (y/n)", action, m.followUsername))
+			itemContent += "\n" + common.ConfirmStyle.Render("  "+m.followPrompt())
 		}
 		return itemContent
 	}
@@ -331,6 +409,10 @@ func (m Model) emptyFeedMessage(hadData bool) string {
 		return "No posts from people you follow yet."
 	case sourceTrending:
 		return "Trending is quiet right now."
+	case sourceLocal:
+		return "No public posts from this server yet."
+	case sourceFederated:
+		return "The federated timeline is empty."
 	case sourceCustomHashtag:
 		tag := strings.TrimSpace(strings.TrimPrefix(m.hashtag, "#"))
 		if tag == "" {
@@ -11,16 +11,21 @@ import (
 )
 
 func (m Model) renderDetailView() string {
-	if len(m.rants) == 0 {
+	if len(m.rants) == 0 && m.focusedRant == nil {
 		return "No rant selected."
 	}
-	r := m.rants[m.cursor].Rant
-	status := m.rants[m.cursor].Status
-	err := m.rants[m.cursor].Err
+	var (
+		r      domain.Rant
+		status RantStatus
+		err    error
+	)
 	if m.focusedRant != nil {
 		r = *m.focusedRant
 		status = StatusNormal // Focused rants from thread are usually normal
-		err = nil
+	} else {
+		r = m.rants[m.cursor].Rant
+		status = m.rants[m.cursor].Status
+		err = m.rants[m.cursor].Err
 	}
 	postWidth := 74
 	// Keep detail post width stable regardless of which item is selected.
@@ -64,21 +69,21 @@ func (m Model) renderDetailView() string {
 		Width(postWidth)
 
 	var cardContent strings.Builder
-	headerAuthor := renderAuthor(r.Username, r.IsOwn, m.isFollowing(r.AccountID))
+	headerAuthor := renderAuthor(r.Username, r.IsOwn, m.isFollowing(r.AccountID)) + m.followsYouBadge(r.AccountID)
 	if r.IsOwn {
 		headerAuthor += common.OwnBadgeStyle.Render("(you)")
 	}
 	cardContent.WriteString(headerAuthor + " " + common.MetadataStyle.Render("("+r.Author+")") + "\n")
 	if m.confirmFollow {
-		action := "Follow"
-		if !m.followTarget {
-			action = "Unfollow"
-		}
-		cardContent.WriteString(common.ConfirmStyle.Render(fmt.Sprintf("%s @%s? (y/n)", action, m.followUsername)) + "\n")
+		cardContent.WriteString(common.ConfirmStyle.Render(m.followPrompt()) + "\n")
 	}
-	cardContent.WriteString(common.TimestampStyle.Render(r.CreatedAt.Format("Monday, Jan 02, 2006 at 15:04")) + "\n")
+	stamp := r.CreatedAt.Format("Monday, Jan 02, 2006 at 15:04")
+	if r.IsEdited() {
+		stamp += " (edited " + r.EditedAt.Format("Jan 02 15:04") + " • R: history)"
+	}
+	cardContent.WriteString(common.TimestampStyle.Render(stamp) + "\n")
 	if m.confirmDelete {
-		cardContent.WriteString(common.ConfirmStyle.Render("Delete this post? (y/n)") + "\n")
+		cardContent.WriteString(common.ConfirmStyle.Render(m.deletePrompt("post")) + "\n")
 	}
 
 	// Parent Context inside card (minimalist)
@@ -100,7 +105,23 @@ func (m Model) renderDetailView() string {
 	content := common.ContentStyle.Width(contentWidth).Render(displayContent)
 	cardContent.WriteString(content + "\n\n")
 	if len(tags) > 0 {
-		cardContent.WriteString(renderAllTags(tags) + "\n\n")
+		selected := -1
+		if m.tagSelect {
+			selected = m.tagCursor
+		}
+		cardContent.WriteString(renderAllTags(tags, m.followedTags, selected) + "\n")
+		if m.tagSelect && selected < len(tags) {
+			action := "follow"
+			if m.followedTags[tagKey(tags[selected])] {
+				action = "unfollow"
+			}
+			hint := "←/→: pick tag • f: " + action + " " + tags[selected] + " • esc: done"
+			cardContent.WriteString(common.MetadataStyle.Render(hint) + "\n")
+			if m.tagListErr != nil {
+				cardContent.WriteString(common.ErrorStyle.Render("Error: "+m.tagListErr.Error()) + "\n")
+			}
+		}
+		cardContent.WriteString("\n")
 	}
 
 	// Metadata: Likes and Replies
@@ -215,17 +236,14 @@ func (m Model) renderDetailView() string {
 			// Calculate depth based on relationship to focused rant
 			depth := 0
 			if r.InReplyToID != "" && r.InReplyToID != "<nil>" && r.InReplyToID != "0" && r.InReplyToID != r.ID {
-				threadRootID := m.rants[m.cursor].Rant.ID
-				if m.focusedRant != nil {
-					threadRootID = m.focusedRant.ID
-				}
+				threadRootID := m.currentThreadRootID()
 				if r.InReplyToID != threadRootID {
 					depth = 1 // Level 2
 				}
 			}
 
 			author := renderAuthor(r.Username, r.IsOwn, m.isFollowing(r.AccountID))
-			timestamp := common.TimestampStyle.Render(r.CreatedAt.Format("Jan 02 15:04"))
+			timestamp := common.TimestampStyle.Render(r.CreatedAt.Format("Jan 02 15:04")) + editedMarker(r)
 			replyContentClean, _ := splitContentAndTags(r.Content)
 			if strings.TrimSpace(replyContentClean) == "" && len(r.Media) > 0 {
 				replyContentClean = "(media post)"
@@ -270,7 +288,7 @@ func (m Model) renderDetailView() string {
 		}
 	}
 	if m.confirmBlock {
-		b.WriteString("\n" + common.ConfirmStyle.Render(fmt.Sprintf("  Block @%s? (y/n)", m.blockUsername)))
+		b.WriteString("\n" + common.ConfirmStyle.Render("  "+m.blockPrompt()))
 	}
 	b.WriteString("\n\n" + m.helpView())
 
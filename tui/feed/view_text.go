@@ -83,7 +83,9 @@ func renderCompactTags(tags []string, max int) string {
 	return strings.Join(parts, " ")
 }
 
-func renderAllTags(tags []string) string {
+// renderAllTags renders hashtag capsules. Followed tags get a check mark and
+// the capsule at selected, if any, is highlighted; pass -1 for none.
+func renderAllTags(tags []string, followed map[string]bool, selected int) string {
 	if len(tags) == 0 {
 		return ""
 	}
@@ -93,8 +95,19 @@ func renderAllTags(tags []string) string {
 		Padding(0, 1).
 		Faint(true)
 	parts := make([]string, 0, len(tags))
-	for _, t := range tags {
-		parts = append(parts, capStyle.Render(t))
+	for i, t := range tags {
+		style := capStyle
+		label := t
+		if followed[tagKey(t)] {
+			label = "✓ " + t
+			style = style.Foreground(lipgloss.Color("#8BD5CA"))
+		}
+		if i == selected {
+			style = style.Faint(false).Bold(true).
+				Foreground(lipgloss.Color("#1E1E1E")).
+				Background(lipgloss.Color("#FF8700"))
+		}
+		parts = append(parts, style.Render(label))
 	}
 	return strings.Join(parts, " ")
 }
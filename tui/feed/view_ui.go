@@ -5,10 +5,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 	"github.com/CrestNiraj12/terminalrant/tui/common"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func (m Model) helpView() string {
@@ -23,6 +26,8 @@ func (m Model) helpView() string {
 			"o: open profile",
 			"v/V: edit profile",
 			"f: follow",
+			"tab: followers/following",
+			"F: follow requests",
 			"B: blocked",
 			"esc/q: back",
 			"?: all keys",
@@ -75,90 +80,125 @@ func (m Model) renderKeyDialog() string {
 		core        []string
 		includeMove bool
 	)
+	k := m.keys
 	if m.showProfile {
 		includeMove = true
 		core = []string{
-			"enter           open selected post detail",
-			"i               toggle profile image preview",
-			"I               open profile image in browser",
-			"o               open profile URL in browser",
-			"v / V           edit profile via editor / inline",
-			"f               follow/unfollow profile owner",
-			"B               show blocked users",
-			"esc / q         back",
+			keyLine("open selected post detail", k.Select),
+			keyLine("toggle profile image preview", k.ToggleMedia),
+			keyLine("open profile image in browser", k.OpenMedia),
+			keyLine("open profile URL in browser", k.Open),
+			keyLine("edit profile via editor / inline", k.EditProfile, k.EditProfileInline),
+			keyLine("follow/unfollow profile owner or selected account", k.FollowUser),
+			keyLine("posts / followers / following", k.NextTab, k.PrevTab),
+			keyLine("open selected account profile", k.OpenProfile),
+			keyLine("pending follow requests (own profile)", k.FollowRequests),
+			keyLine("show blocked users", k.ManageBlocks),
+			keyLine("show scheduled posts", k.ManageScheduled),
+			keyLine("show saved drafts", k.ManageDrafts),
+			keyLine("back", k.Back, k.Quit),
 		}
 	} else if m.showDetail {
 		includeMove = true
 		core = []string{
-			"enter           open selected reply thread",
-			"l               like/dislike selected post",
-			"f               follow/unfollow selected user",
-			"z               open selected user profile",
-			"Z               open own profile",
-			"i               toggle image previews",
-			"I               open selected media",
-			"c / C           reply via editor / inline",
-			"x / X           hide post / toggle hidden posts",
-			"b               block selected user",
-			"B               show blocked users",
-			"u               open parent post",
-			"r               refresh replies",
-			"o               open post URL",
-			"v               edit profile",
-			"g               open creator GitHub",
-			"h               scroll to top of post",
-			"H               go to feed home",
-			"esc / q         back",
+			keyLine("open selected reply thread", k.Select),
+			keyLine("like/dislike selected post", k.Like),
+			keyLine("follow/unfollow selected user", k.FollowUser),
+			keyLine("open selected user profile", k.OpenProfile),
+			keyLine("open own profile", k.OpenOwnProfile),
+			keyLine("toggle image previews", k.ToggleMedia),
+			keyLine("open selected media", k.OpenMedia),
+			keyLine("reply via editor / inline", k.Reply, k.ReplyInline),
+			keyLine("hide post / toggle hidden posts", k.HidePost, k.ShowHidden),
+			keyLine("block selected user", k.BlockUser),
+			keyLine("report selected post", k.Report),
+			keyLine("block selected user's domain", k.BlockDomain),
+			keyLine("show blocked users", k.ManageBlocks),
+			keyLine("open parent post", k.ParentPost),
+			keyLine("edit history of selected post", k.History),
+			keyLine("who liked / boosted selected post", k.Interactions),
+			keyLine("pick a hashtag to follow/unfollow", k.FollowedTags),
+			keyLine("refresh replies", k.Refresh),
+			keyLine("open post URL", k.Open),
+			keyLine("edit profile", k.EditProfile),
+			keyLine("open creator GitHub", k.GitHub),
+			keyLine("scroll to top of post", k.Home),
+			keyLine("go to feed home", k.SetHashtag),
+			keyLine("back", k.Back, k.Quit),
 		}
 		if m.canDeleteRant(m.getSelectedRant()) {
-			core = append(core, "d               delete selected post")
+			core = append(core,
+				keyLine("delete selected post", k.Delete),
+				keyLine("delete & redraft via editor / inline", k.Redraft, k.RedraftInline),
+			)
 		}
 	} else if len(m.rants) > 0 {
 		includeMove = true
 		core = []string{
-			"enter           open detail",
-			"t / T           next/prev tab",
-			"i               toggle image previews",
-			"I               open selected media",
-			"H               set hashtag feed tag",
-			"p / P           new rant via editor / inline",
-			"v               edit profile",
-			"c / C           reply via editor / inline",
-			"l               like/dislike selected post",
-			"f               follow/unfollow selected user",
-			"z               open selected user profile",
-			"Z               open own profile",
-			"x / X           hide post / toggle hidden posts",
-			"b               block selected user",
-			"B               show blocked users",
-			"r               refresh timeline",
-			"o               open post URL",
-			"g               open creator GitHub",
-			"h               jump to top",
-			"q               quit",
+			keyLine("open detail", k.Select),
+			keyLine("next/prev tab", k.SwitchFeed, k.PrevFeed),
+			keyLine("toggle image previews", k.ToggleMedia),
+			keyLine("open selected media", k.OpenMedia),
+			keyLine("set hashtag feed tag", k.SetHashtag),
+			keyLine("manage followed hashtags", k.FollowedTags),
+			keyLine("trending hashtags & links", k.Trends),
+			keyLine("notifications", k.Notifications),
+			keyLine("browse another instance / leave", k.BrowseInstance),
+			keyLine("open post or profile by URL", k.OpenLink),
+			keyLine("new rant via editor / inline", k.NewEditor, k.NewInline),
+			keyLine("edit profile", k.EditProfile),
+			keyLine("reply via editor / inline", k.Reply, k.ReplyInline),
+			keyLine("like/dislike selected post", k.Like),
+			keyLine("follow/unfollow selected user", k.FollowUser),
+			keyLine("open selected user profile", k.OpenProfile),
+			keyLine("open own profile", k.OpenOwnProfile),
+			keyLine("hide post / toggle hidden posts", k.HidePost, k.ShowHidden),
+			keyLine("block selected user", k.BlockUser),
+			keyLine("report selected post", k.Report),
+			keyLine("block selected user's domain", k.BlockDomain),
+			keyLine("show blocked users", k.ManageBlocks),
+			keyLine("show scheduled posts", k.ManageScheduled),
+			keyLine("show saved drafts", k.ManageDrafts),
+			keyLine("refresh timeline", k.Refresh),
+			keyLine("open post URL", k.Open),
+			keyLine("open creator GitHub", k.GitHub),
+			keyLine("jump to top", k.Home),
+			keyLine("quit", k.Quit),
 		}
 		r := m.rants[m.cursor].Rant
 		if r.IsOwn {
-			core = append(core, "e / E           edit via editor / inline", "d               delete selected post")
+			core = append(core,
+				keyLine("edit via editor / inline", k.Edit, k.EditInline),
+				keyLine("delete selected post", k.Delete),
+				keyLine("delete & redraft via editor / inline", k.Redraft, k.RedraftInline),
+			)
 		}
 	} else {
 		core = []string{
-			"p / P           new rant via editor / inline",
-			"t / T           next/prev tab",
-			"i               toggle image previews",
-			"I               open selected media",
-			"H               set hashtag feed tag",
-			"v               edit profile",
-			"Z               open own profile",
-			"B               show blocked users",
-			"r               refresh timeline",
-			"g               open creator GitHub",
-			"q               quit",
-		}
-	}
-	lines := buildKeyDialogLines(core, includeMove)
-
-	body := "Keyboard Shortcuts\n\n" + strings.Join(lines, "\n") + "\n\nPress ?, esc, q, or enter to close."
+			keyLine("new rant via editor / inline", k.NewEditor, k.NewInline),
+			keyLine("next/prev tab", k.SwitchFeed, k.PrevFeed),
+			keyLine("toggle image previews", k.ToggleMedia),
+			keyLine("open selected media", k.OpenMedia),
+			keyLine("set hashtag feed tag", k.SetHashtag),
+			keyLine("manage followed hashtags", k.FollowedTags),
+			keyLine("trending hashtags & links", k.Trends),
+			keyLine("notifications", k.Notifications),
+			keyLine("browse another instance / leave", k.BrowseInstance),
+			keyLine("open post or profile by URL", k.OpenLink),
+			keyLine("edit profile", k.EditProfile),
+			keyLine("open own profile", k.OpenOwnProfile),
+			keyLine("show blocked users", k.ManageBlocks),
+			keyLine("show scheduled posts", k.ManageScheduled),
+			keyLine("show saved drafts", k.ManageDrafts),
+			keyLine("refresh timeline", k.Refresh),
+			keyLine("open creator GitHub", k.GitHub),
+			keyLine("quit", k.Quit),
+		}
+	}
+	lines := buildKeyDialogLines(k, core, includeMove)
+
+	body := "Keyboard Shortcuts\n\n" + strings.Join(lines, "\n") +
+		"\n\nPress " + keyNames(k.ToggleHints, k.Back, k.Quit, k.Select) + " to close."
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("#FF8700")).
@@ -167,32 +207,35 @@ func (m Model) renderKeyDialog() string {
 		Render(body)
 }
 
-func buildKeyDialogLines(core []string, includeMove bool) []string {
+func buildKeyDialogLines(k common.KeyMap, core []string, includeMove bool) []string {
 	out := make([]string, 0, len(core)+5)
 	if includeMove {
-		out = append(out, "j/k or up/down  move focus")
+		out = append(out, keyLine("move focus", k.Up, k.Down))
 	}
-	out = append(out, "left/right      pan horizontally")
+	out = append(out, keyLine("pan horizontally", k.Left, k.Right))
 	out = append(out, core...)
-	out = append(out, "ctrl+c          force quit", "?               toggle this dialog")
+	out = append(out, keyLine("force quit", k.ForceQuit), keyLine("toggle this dialog", k.ToggleHints))
 	return out
 }
 
-func (m Model) renderTabs() string {
-	tabs := []struct {
-		label  string
-		source feedSource
-	}{
-		{label: domain.AppHashTag, source: sourceTerminalRant},
-		{label: "trending", source: sourceTrending},
-		{label: "following", source: sourceFollowing},
-	}
-	if m.hasCustomTab() {
-		tabs = append(tabs, struct {
-			label  string
-			source feedSource
-		}{label: "#" + m.hashtag, source: sourceCustomHashtag})
+// keyLine is one row of the key dialog: the current keys of the bindings,
+// then what they do.
+func keyLine(desc string, bindings ...key.Binding) string {
+	return fmt.Sprintf("%-15s %s", keyNames(bindings...), desc)
+}
+
+// keyNames lists the keys of the bindings as their help shows them.
+func keyNames(bindings ...key.Binding) string {
+	names := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if h := b.Help().Key; h != "" {
+			names = append(names, h)
+		}
 	}
+	return strings.Join(names, " / ")
+}
+
+func (m Model) renderTabs() string {
 	active := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#111111")).
 		Background(lipgloss.Color("#FFB454")).
@@ -203,14 +246,25 @@ func (m Model) renderTabs() string {
 		Background(lipgloss.Color("#2B2B2B")).
 		Padding(0, 1)
 
-	rendered := make([]string, 0, len(tabs))
-	for _, t := range tabs {
-		if m.feedSource == t.source {
-			rendered = append(rendered, active.Render(t.label))
+	order := m.tabOrder()
+	rendered := make([]string, 0, len(order)+1)
+	if m.remote != nil {
+		rendered = append(rendered, common.MetadataStyle.Render("browsing "+m.remoteHost+" (read-only, O: leave)"))
+	}
+	for _, source := range order {
+		label := m.tabLabel(source)
+		if n := m.tabUnread(source); n > 0 {
+			label += fmt.Sprintf(" (%d new)", n)
+		}
+		if m.feedSource == source {
+			rendered = append(rendered, active.Render(label))
 		} else {
-			rendered = append(rendered, inactive.Render(t.label))
+			rendered = append(rendered, inactive.Render(label))
 		}
 	}
+	if m.notificationsUnread > 0 {
+		rendered = append(rendered, common.MetadataStyle.Render(fmt.Sprintf("%d new notifications (%s)", m.notificationsUnread, keyNames(m.keys.Notifications))))
+	}
 	return lipgloss.NewStyle().MarginLeft(2).PaddingTop(1).Render(strings.Join(rendered, " "))
 }
 
@@ -222,9 +276,10 @@ func (m Model) renderBlockedUsersDialog() string {
 	} else if m.blockedErr != nil {
 		body.WriteString(common.ErrorStyle.Render("Error: " + m.blockedErr.Error()))
 		body.WriteString("\n")
-	} else if len(m.blockedUsers) == 0 {
-		body.WriteString("No blocked users.\n")
 	} else {
+		if len(m.blockedUsers) == 0 {
+			body.WriteString("No blocked users.\n")
+		}
 		for i, u := range m.blockedUsers {
 			prefix := "  "
 			if i == m.blockedCursor {
@@ -236,35 +291,707 @@ func (m Model) renderBlockedUsersDialog() string {
 			}
 			body.WriteString(prefix + name + "\n")
 		}
+		body.WriteString("\nBlocked Domains\n\n")
+		if len(m.domainBlocks) == 0 {
+			body.WriteString("No blocked domains.\n")
+		}
+		for i, d := range m.domainBlocks {
+			prefix := "  "
+			if len(m.blockedUsers)+i == m.blockedCursor {
+				prefix = "▶ "
+			}
+			body.WriteString(prefix + d + "\n")
+		}
 	}
-	if m.confirmUnblock {
+	if m.confirmUnblock && m.unblockDomain != "" {
+		body.WriteString("\n" + common.ConfirmStyle.Render(fmt.Sprintf("Unblock domain %s? (y/n)", m.unblockDomain)))
+	} else if m.confirmUnblock {
 		body.WriteString("\n" + common.ConfirmStyle.Render(fmt.Sprintf("Unblock @%s? (y/n)", m.unblockTarget.Username)))
 	}
 	body.WriteString("\n\nj/k: move • u: unblock • esc/q: close")
-	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FF8700")).
-		Padding(1, 2).
-		Margin(1, 2).
-		Width(74).
-		Render(body.String())
+	return body.String()
 }
 
-func (m Model) renderBlockedView() string {
-	var b strings.Builder
+// renderDialogPage renders a full-screen dialog: the app title, a breadcrumb
+// from the current feed to crumb, and body framed in the dialog box.
+func (m Model) renderDialogPage(crumb, body string) string {
 	title := common.AppTitleStyle.Padding(1, 0, 0, 1).Render(domain.DisplayAppTitle())
 	tagline := common.TaglineStyle.Render("<Why leave terminal to rant!!>")
 	hashtag := common.HashtagStyle.Margin(0, 0, 1, 2).Render(m.sourceLabel())
 	crumbStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).MarginBottom(1)
-	separator := crumbStyle.Render(" > ")
-	blockedCrumb := crumbStyle.Render("Blocked Users")
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#FF8700")).
+		Padding(1, 2).
+		Margin(1, 2).
+		Width(74)
 
+	var b strings.Builder
 	b.WriteString(title + tagline + "\n")
-	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Bottom, hashtag, separator, blockedCrumb) + "\n\n")
-	b.WriteString(m.renderBlockedUsersDialog())
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Bottom, hashtag, crumbStyle.Render(" > "), crumbStyle.Render(crumb)) + "\n\n")
+	b.WriteString(box.Render(body))
+	return b.String()
+}
+
+func (m Model) renderBlockedView() string {
+	return m.renderDialogPage("Blocked Users", m.renderBlockedUsersDialog())
+}
+
+func (m Model) renderScheduledPostsDialog() string {
+	var body strings.Builder
+	body.WriteString("Scheduled Posts\n\n")
+	if len(m.scheduled) == 0 && m.loadingScheduled {
+		body.WriteString(m.spinner.View() + " Loading scheduled posts...\n")
+	} else if len(m.scheduled) == 0 && m.scheduledErr == nil {
+		body.WriteString("No scheduled posts. Press ctrl+t in the inline composer to schedule one.\n")
+	} else {
+		start, end := listWindow(len(m.scheduled), m.scheduledCursor, m.listRows())
+		if start > 0 {
+			body.WriteString(windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			p := m.scheduled[i]
+			prefix := "  "
+			if i == m.scheduledCursor {
+				prefix = "▶ "
+			}
+			line := common.FormatScheduleTime(p.ScheduledAt) + "  " + ansi.Truncate(strings.Join(strings.Fields(p.Content), " "), 44, "…")
+			if p.InReplyToID != "" {
+				line += common.MetadataStyle.Render("  (reply)")
+			}
+			if p.MediaCount > 0 {
+				line += common.MetadataStyle.Render(fmt.Sprintf("  +%d media", p.MediaCount))
+			}
+			body.WriteString(prefix + line + "\n")
+		}
+		if end < len(m.scheduled) {
+			body.WriteString(windowMarker("▼", len(m.scheduled)-end) + "\n")
+		}
+		switch {
+		case m.loadingScheduled:
+			body.WriteString(m.spinner.View() + " Loading more...\n")
+		case m.scheduledNext != "":
+			body.WriteString(common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
+	}
+	if m.scheduledErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.scheduledErr.Error()) + "\n")
+	}
+	if m.rescheduleInput {
+		body.WriteString("\n" + common.ConfirmStyle.Render("New time: "+m.rescheduleBuffer+"█"))
+		body.WriteString("\n\nenter: reschedule • esc: cancel")
+	} else {
+		if m.confirmCancelScheduled {
+			if idx := m.selectedScheduledIndex(); idx >= 0 {
+				body.WriteString("\n" + common.ConfirmStyle.Render(fmt.Sprintf("Cancel post scheduled for %s? (y/n)", common.FormatScheduleTime(m.scheduled[idx].ScheduledAt))))
+			}
+		}
+		body.WriteString("\n\nj/k: move • r: reschedule • d: cancel post • esc/q: close")
+	}
+	return body.String()
+}
+
+func (m Model) renderScheduledView() string {
+	return m.renderDialogPage("Scheduled Posts", m.renderScheduledPostsDialog())
+}
+
+func (m Model) renderDraftsDialog() string {
+	var body strings.Builder
+	body.WriteString("Drafts\n\n")
+	if m.loadingDrafts {
+		body.WriteString(m.spinner.View() + " Loading drafts...\n")
+	} else if len(m.drafts) == 0 && m.draftsErr == nil {
+		body.WriteString("No drafts. Cancelled or failed rants are kept here.\n")
+	} else {
+		for i, d := range m.drafts {
+			prefix := "  "
+			if i == m.draftCursor {
+				prefix = "▶ "
+			}
+			label := "new"
+			switch {
+			case d.IsEdit():
+				label = "edit"
+			case d.IsReply():
+				label = "reply"
+				if d.ParentAuthor != "" {
+					label = "reply @" + d.ParentAuthor
+				}
+			}
+			when := d.UpdatedAt.Local().Format("Jan 02 15:04")
+			snippet := ansi.Truncate(strings.Join(strings.Fields(d.Content), " "), 40, "…")
+			body.WriteString(prefix + common.MetadataStyle.Render(when+"  "+label) + "  " + snippet + "\n")
+		}
+	}
+	if m.draftsErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.draftsErr.Error()) + "\n")
+	}
+	if m.confirmDiscardDraft {
+		body.WriteString("\n" + common.ConfirmStyle.Render("Discard this draft? (y/n)"))
+	}
+	body.WriteString("\n\nj/k: move • enter/P: resume inline • p: resume in editor • c: duplicate • d: discard • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderDraftsView() string {
+	return m.renderDialogPage("Drafts", m.renderDraftsDialog())
+}
+
+func (m Model) renderHistoryDialog() string {
+	var body strings.Builder
+	body.WriteString("Edit History\n\n")
+	if m.loadingHistory {
+		body.WriteString(m.spinner.View() + " Loading revisions...\n")
+	} else if len(m.history) == 0 && m.historyErr == nil {
+		body.WriteString("No revisions.\n")
+	} else {
+		for i, rev := range m.history {
+			prefix := "  "
+			if i == m.historyCursor {
+				prefix = "▶ "
+			}
+			label := revisionLabel(i)
+			if i > 0 && i == len(m.history)-1 {
+				label += " (current)"
+			}
+			when := rev.CreatedAt.Local().Format("Jan 02, 2006 15:04")
+			body.WriteString(prefix + common.MetadataStyle.Render(when) + "  " + label + "\n")
+		}
+		if m.historyCursor >= 0 && m.historyCursor < len(m.history) {
+			body.WriteString("\n" + m.renderRevisionDiff(m.historyCursor) + "\n")
+		}
+	}
+	if m.historyErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.historyErr.Error()) + "\n")
+	}
+	mode := "line diff"
+	if m.historyByLine {
+		mode = "word diff"
+	}
+	body.WriteString("\nj/k: choose revision • w: " + mode + " • esc/q: close")
+	return body.String()
+}
+
+// renderRevisionDiff shows what revision i changed from the one before it;
+// the original is shown as is.
+func (m Model) renderRevisionDiff(i int) string {
+	rev := m.history[i]
+	if i == 0 {
+		return common.MetadataStyle.Render("Original text:") + "\n" + common.ContentStyle.Render(rev.Content)
+	}
+	prev := m.history[i-1]
+	var b strings.Builder
+	b.WriteString(common.MetadataStyle.Render(fmt.Sprintf("Changes from %s:", revisionLabel(i-1))) + "\n")
+	if m.historyByLine {
+		for _, op := range common.LineDiff(prev.Content, rev.Content) {
+			for _, line := range strings.Split(strings.TrimSuffix(op.Text, "\n"), "\n") {
+				switch op.Kind {
+				case common.DiffInsert:
+					b.WriteString(common.DiffInsertStyle.Render("+ "+line) + "\n")
+				case common.DiffDelete:
+					b.WriteString(common.DiffDeleteStyle.Render("- "+line) + "\n")
+				default:
+					b.WriteString(common.ContentStyle.Render("  "+line) + "\n")
+				}
+			}
+		}
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	for _, op := range common.WordDiff(prev.Content, rev.Content) {
+		style := common.ContentStyle
+		switch op.Kind {
+		case common.DiffInsert:
+			style = common.DiffInsertStyle
+		case common.DiffDelete:
+			style = common.DiffDeleteStyle
+		}
+		// Style each line on its own so multi-line pieces are not padded.
+		lines := strings.Split(op.Text, "\n")
+		for j, line := range lines {
+			if line != "" {
+				b.WriteString(style.Render(line))
+			}
+			if j < len(lines)-1 {
+				b.WriteString("\n")
+			}
+		}
+	}
 	return b.String()
 }
 
+func (m Model) renderInteractionsDialog() string {
+	l := m.interactions
+	var body strings.Builder
+	liked, boosted := "Liked by", "Boosted by"
+	active := lipgloss.NewStyle().Bold(true).Underline(true)
+	if l.kind == listRebloggedBy {
+		boosted = active.Render(boosted)
+		liked = common.MetadataStyle.Render(liked)
+	} else {
+		liked = active.Render(liked)
+		boosted = common.MetadataStyle.Render(boosted)
+	}
+	body.WriteString(liked + "   " + boosted + "\n\n")
+	if len(l.items) == 0 && l.loading {
+		body.WriteString(m.spinner.View() + " Loading accounts...\n")
+	} else if len(l.items) == 0 && l.err == nil {
+		body.WriteString("Nobody yet.\n")
+	} else {
+		start, end := l.window(m.listRows())
+		if start > 0 {
+			body.WriteString(windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			a := l.items[i]
+			prefix := "  "
+			if i == l.cursor {
+				prefix = "▶ "
+			}
+			line := prefix + renderAuthor(a.Username, false, m.isFollowing(a.ID))
+			if a.DisplayName != "" {
+				line += " " + common.MetadataStyle.Render("("+a.DisplayName+")")
+			}
+			body.WriteString(line + "\n")
+		}
+		if end < len(l.items) {
+			body.WriteString(windowMarker("▼", len(l.items)-end) + "\n")
+		}
+		switch {
+		case l.loading:
+			body.WriteString(m.spinner.View() + " Loading more...\n")
+		case l.next != "":
+			body.WriteString(common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
+	}
+	if l.err != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+l.err.Error()) + "\n")
+	}
+	if m.confirmFollow {
+		body.WriteString("\n" + common.ConfirmStyle.Render(m.followPrompt()))
+	}
+	body.WriteString("\n\nj/k: move • tab: liked/boosted • enter/z: profile • f: follow • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderInteractionsView() string {
+	return m.renderDialogPage("Liked & Boosted By", m.renderInteractionsDialog())
+}
+
+func (m Model) renderTrendsDialog() string {
+	var body strings.Builder
+	tags, links := "Hashtags", "Links"
+	active := lipgloss.NewStyle().Bold(true).Underline(true)
+	if m.trendsTab == trendsTabLinks {
+		links = active.Render(links)
+		tags = common.MetadataStyle.Render(tags)
+	} else {
+		tags = active.Render(tags)
+		links = common.MetadataStyle.Render(links)
+	}
+	body.WriteString("Trending   " + tags + "   " + links + "\n\n")
+	switch {
+	case m.trendsLoading:
+		body.WriteString(m.spinner.View() + " Loading trends...\n")
+	case m.trendsErr != nil:
+		body.WriteString(common.ErrorStyle.Render("Error: "+m.trendsErr.Error()) + "\n")
+	case m.trendsLen() == 0:
+		body.WriteString("Nothing trending right now.\n")
+	case m.trendsTab == trendsTabLinks:
+		for i, l := range m.trendLinks {
+			prefix := "  "
+			if i == m.trendsCursor {
+				prefix = "▶ "
+			}
+			title := strings.Join(strings.Fields(l.Title), " ")
+			if title == "" {
+				title = l.URL
+			}
+			body.WriteString(prefix + lipgloss.NewStyle().Bold(true).Render(ansi.Truncate(title, 60, "…")) + "\n")
+			meta := common.Sparkline(l.Uses) + " " + fmt.Sprintf("%d posts", sumInts(l.Uses))
+			if l.Provider != "" {
+				meta = l.Provider + " • " + meta
+			}
+			body.WriteString("    " + common.MetadataStyle.Render(meta) + "\n")
+			if l.Description != "" {
+				body.WriteString("    " + common.ContentStyle.Render(ansi.Truncate(strings.Join(strings.Fields(l.Description), " "), 62, "…")) + "\n")
+			}
+		}
+	default:
+		for i, t := range m.trendTags {
+			prefix := "  "
+			if i == m.trendsCursor {
+				prefix = "▶ "
+			}
+			line := prefix + common.HashtagStyle.Render(ansi.Truncate("#"+t.Name, 30, "…"))
+			line += "  " + common.Sparkline(t.Uses)
+			line += "  " + common.MetadataStyle.Render(fmt.Sprintf("%d posts by %d people", sumInts(t.Uses), t.Accounts))
+			body.WriteString(line + "\n")
+		}
+	}
+	hint := "enter: open feed"
+	if m.trendsTab == trendsTabLinks {
+		hint = "enter/o: open link"
+	}
+	body.WriteString("\n\nj/k: move • tab: hashtags/links • " + hint + " • r: refresh • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderTrendsView() string {
+	return m.renderDialogPage("Trends", m.renderTrendsDialog())
+}
+
+// reportCategoryLabels names app.ReportCategories in the report dialog.
+var reportCategoryLabels = map[app.ReportCategory]string{
+	app.ReportSpam:      "Spam",
+	app.ReportLegal:     "Illegal content",
+	app.ReportViolation: "Breaks server rules",
+	app.ReportOther:     "Something else",
+}
+
+func (m Model) renderReportDialog() string {
+	var body strings.Builder
+	body.WriteString("Report @" + m.reportUsername + "\n\n")
+
+	pointer := func(row int) string {
+		if row == m.reportCursor && !m.reportEditing {
+			return "▶ "
+		}
+		return "  "
+	}
+	check := func(on bool) string {
+		if on {
+			return "[x] "
+		}
+		return "[ ] "
+	}
+
+	category := reportCategoryLabels[m.reportCategoryValue()]
+	body.WriteString(pointer(0) + "Category: " + lipgloss.NewStyle().Bold(true).Render("‹ "+category+" ›") + "\n")
+
+	row := 1
+	if m.reportCategoryValue() == app.ReportViolation {
+		body.WriteString("\n" + common.MetadataStyle.Render("Rules broken") + "\n")
+		if len(m.reportRules) == 0 {
+			body.WriteString("    " + common.MetadataStyle.Render("No rules listed by this server.") + "\n")
+		}
+		for i, rule := range m.reportRules {
+			text := ansi.Truncate(strings.Join(strings.Fields(rule.Text), " "), 58, "…")
+			body.WriteString(pointer(row) + check(m.reportRuleIDs[rule.ID]) + fmt.Sprintf("%d. %s", i+1, text) + "\n")
+			row++
+		}
+	}
+
+	body.WriteString("\n" + common.MetadataStyle.Render("Posts to attach") + "\n")
+	for _, p := range m.reportPosts {
+		text := strings.Join(strings.Fields(p.Content), " ")
+		if text == "" {
+			text = "(no text)"
+		}
+		body.WriteString(pointer(row) + check(m.reportStatusIDs[p.ID]) + ansi.Truncate(text, 58, "…") + "\n")
+		row++
+	}
+	if m.reportLoading {
+		body.WriteString("  " + m.spinner.View() + " Loading more posts...\n")
+	}
+
+	comment := m.reportComment
+	if m.reportEditing {
+		comment += "▏"
+	} else if comment == "" {
+		comment = common.MetadataStyle.Render("(none)")
+	}
+	body.WriteString("\nComment: " + comment + "\n")
+	if m.reportIsRemote() {
+		forward := "no"
+		if m.reportForward {
+			forward = "yes"
+		}
+		host := m.reportUsername[strings.LastIndex(m.reportUsername, "@")+1:]
+		body.WriteString("Forward to " + host + ": " + forward + "\n")
+	}
+
+	if m.reportSubmitting {
+		body.WriteString("\n" + m.spinner.View() + " Sending report...\n")
+	}
+	if m.reportErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.reportErr.Error()) + "\n")
+	}
+
+	hint := "j/k: move • h/l: category • space: toggle • c: comment"
+	if m.reportIsRemote() {
+		hint += " • f: forward"
+	}
+	hint += " • enter: send • esc/q: cancel"
+	if m.reportEditing {
+		hint = "type your comment • enter/esc: done"
+	}
+	body.WriteString("\n\n" + hint)
+	return body.String()
+}
+
+func (m Model) renderReportView() string {
+	return m.renderDialogPage("Report", m.renderReportDialog())
+}
+
+func sumInts(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func (m Model) renderFollowedTagsDialog() string {
+	var body strings.Builder
+	body.WriteString("Followed Hashtags\n\n")
+	if len(m.tagList) == 0 && m.tagListLoading {
+		body.WriteString(m.spinner.View() + " Loading hashtags...\n")
+	} else if len(m.tagList) == 0 && m.tagListErr == nil {
+		body.WriteString("You don't follow any hashtags.\n")
+		body.WriteString(common.MetadataStyle.Render("Follow one with # in a post's detail view.") + "\n")
+	} else {
+		start, end := listWindow(len(m.tagList), m.tagListCursor, m.listRows())
+		if start > 0 {
+			body.WriteString(windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			t := m.tagList[i]
+			prefix := "  "
+			if i == m.tagListCursor {
+				prefix = "▶ "
+			}
+			line := prefix + common.HashtagStyle.Render("#"+t.Name)
+			if !t.Following {
+				line += " " + common.MetadataStyle.Render("(unfollowed)")
+			}
+			body.WriteString(line + "\n")
+		}
+		if end < len(m.tagList) {
+			body.WriteString(windowMarker("▼", len(m.tagList)-end) + "\n")
+		}
+		switch {
+		case m.tagListLoading:
+			body.WriteString(m.spinner.View() + " Loading more...\n")
+		case m.tagListNext != "":
+			body.WriteString(common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
+	}
+	if m.tagListErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.tagListErr.Error()) + "\n")
+	}
+	body.WriteString("\n\nj/k: move • enter: open feed • f/u: follow/unfollow • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderFollowedTagsView() string {
+	return m.renderDialogPage("Followed Hashtags", m.renderFollowedTagsDialog())
+}
+
+func (m Model) renderFollowRequestsDialog() string {
+	l := m.followRequests
+	var body strings.Builder
+	body.WriteString("Follow Requests\n\n")
+	if len(l.items) == 0 && l.loading {
+		body.WriteString(m.spinner.View() + " Loading requests...\n")
+	} else if len(l.items) == 0 && l.err == nil {
+		body.WriteString("No pending follow requests.\n")
+	} else {
+		start, end := l.window(m.listRows())
+		if start > 0 {
+			body.WriteString(windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			a := l.items[i]
+			prefix := "  "
+			if i == l.cursor {
+				prefix = "▶ "
+			}
+			line := prefix + renderAuthor(a.Username, false, m.isFollowing(a.ID))
+			if a.DisplayName != "" {
+				line += " " + common.MetadataStyle.Render("("+a.DisplayName+")")
+			}
+			body.WriteString(line + "\n")
+		}
+		if end < len(l.items) {
+			body.WriteString(windowMarker("▼", len(l.items)-end) + "\n")
+		}
+		switch {
+		case l.loading:
+			body.WriteString(m.spinner.View() + " Loading more...\n")
+		case l.next != "":
+			body.WriteString(common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
+	}
+	if l.err != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+l.err.Error()) + "\n")
+	}
+	if m.confirmRejectRequest {
+		if a, ok := l.selected(); ok {
+			body.WriteString("\n" + common.ConfirmStyle.Render("Reject follow request from @"+a.Username+"? (y/n)"))
+		}
+	}
+	body.WriteString("\n\nj/k: move • a: accept • r: reject • enter/z: profile • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderFollowRequestsView() string {
+	return m.renderDialogPage("Follow Requests", m.renderFollowRequestsDialog())
+}
+
+// notificationLabels describes each notification type in the dialog.
+var notificationLabels = map[string]string{
+	"mention":        "mentioned you",
+	"status":         "posted",
+	"reblog":         "boosted",
+	"favourite":      "liked",
+	"follow":         "followed you",
+	"follow_request": "requested to follow you",
+	"poll":           "poll ended",
+	"update":         "edited a post",
+}
+
+func (m Model) renderNotificationsDialog() string {
+	var body strings.Builder
+	body.WriteString("Notifications\n\n")
+	if len(m.notificationList) == 0 && m.notificationsLoading {
+		body.WriteString(m.spinner.View() + " Loading notifications...\n")
+	} else if len(m.notificationList) == 0 && m.notificationsErr == nil {
+		body.WriteString("No notifications.\n")
+	} else {
+		divider := m.notificationDividerIndex()
+		start, end := listWindow(len(m.notificationList), m.notificationCursor, m.listRows())
+		if start > 0 {
+			body.WriteString(windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			n := m.notificationList[i]
+			if i == divider {
+				body.WriteString(renderReadDivider(60) + "\n")
+			}
+			prefix := "  "
+			if i == m.notificationCursor {
+				prefix = "▶ "
+			}
+			label, ok := notificationLabels[n.Type]
+			if !ok {
+				label = n.Type
+			}
+			line := prefix + renderAuthor(n.Account.Username, false, m.isFollowing(n.Account.ID)) + " " + common.MetadataStyle.Render(label)
+			if n.Rant != nil {
+				line += "  " + ansi.Truncate(strings.Join(strings.Fields(n.Rant.Content), " "), 40, "…")
+			}
+			body.WriteString(line + "\n")
+		}
+		if end < len(m.notificationList) {
+			body.WriteString(windowMarker("▼", len(m.notificationList)-end) + "\n")
+		}
+		switch {
+		case m.notificationsLoading:
+			body.WriteString(m.spinner.View() + " Loading more...\n")
+		case m.notificationsNext != "":
+			body.WriteString(common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
+	}
+	if m.notificationsErr != nil {
+		body.WriteString("\n" + common.ErrorStyle.Render("Error: "+m.notificationsErr.Error()) + "\n")
+	}
+	body.WriteString("\n\nj/k: move • enter: open post or profile • z: profile • esc/q: close")
+	return body.String()
+}
+
+func (m Model) renderNotificationsView() string {
+	return m.renderDialogPage("Notifications", m.renderNotificationsDialog())
+}
+
+// followPrompt asks to confirm the pending follow change.
+func (m Model) followPrompt() string {
+	switch {
+	case m.followTarget:
+		return "Follow @" + m.followUsername + "? (y/n)"
+	case m.isRequested(m.followAccountID):
+		return "Cancel follow request to @" + m.followUsername + "? (y/n)"
+	}
+	return "Unfollow @" + m.followUsername + "? (y/n)"
+}
+
+// relationshipBadges lists what the profile view shows about the user's
+// relationship with accountID beyond following.
+func (m Model) relationshipBadges(accountID string) string {
+	rel, ok := m.relationship(accountID)
+	if !ok {
+		return ""
+	}
+	var badges []string
+	if rel.FollowedBy {
+		badges = append(badges, "follows you")
+	}
+	if rel.Requested {
+		badges = append(badges, "requested")
+	}
+	if rel.Muting {
+		badges = append(badges, "muted")
+	}
+	if rel.Blocking {
+		badges = append(badges, "blocked")
+	}
+	if rel.DomainBlocking {
+		badges = append(badges, "domain blocked")
+	}
+	if rel.Following && !rel.ShowingReblogs {
+		badges = append(badges, "boosts hidden")
+	}
+	if rel.Notifying {
+		badges = append(badges, "notifying")
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return common.MetadataStyle.Render("[" + strings.Join(badges, "] [") + "]")
+}
+
+// followsYouBadge marks authors who follow the user on feed cards.
+func (m Model) followsYouBadge(accountID string) string {
+	if rel, ok := m.relationship(accountID); ok && rel.FollowedBy {
+		return common.MetadataStyle.Render(" follows you")
+	}
+	return ""
+}
+
+// deletePrompt asks to confirm the pending delete of a noun.
+func (m Model) deletePrompt(noun string) string {
+	if m.redraftRant != nil {
+		return "Delete and redraft this " + noun + "? (y/n)"
+	}
+	return "Delete this " + noun + "? (y/n)"
+}
+
+// blockPrompt asks to confirm the pending block of a user or their domain.
+func (m Model) blockPrompt() string {
+	if m.blockDomain != "" {
+		return "Block everything from " + m.blockDomain + "? (y/n)"
+	}
+	return "Block @" + m.blockUsername + "? (y/n)"
+}
+
+// editedMarker flags rants changed after posting.
+func editedMarker(r domain.Rant) string {
+	if !r.IsEdited() {
+		return ""
+	}
+	return common.TimestampStyle.Render(" (edited)")
+}
+
+func revisionLabel(i int) string {
+	if i == 0 {
+		return "original"
+	}
+	return fmt.Sprintf("edit %d", i)
+}
+
+func (m Model) renderHistoryView() string {
+	return m.renderDialogPage("Edit History", m.renderHistoryDialog())
+}
+
 func (m Model) renderProfileView() string {
 	var b strings.Builder
 	title := common.AppTitleStyle.Padding(1, 0, 0, 1).Render(domain.DisplayAppTitle())
@@ -325,15 +1052,31 @@ func (m Model) renderProfileView() string {
 	card.WriteString(common.MetadataStyle.Render(
 		fmt.Sprintf("Posts %d  Followers %d  Following %d", m.profile.PostsCount, m.profile.Followers, m.profile.Following),
 	) + "\n\n")
+	if m.profileIsOwn && (m.profile.Locked || m.profile.FollowRequests > 0) {
+		requests := fmt.Sprintf("Follow requests: %d pending (F)", m.profile.FollowRequests)
+		if m.profile.FollowRequests > 0 {
+			card.WriteString(common.ConfirmStyle.Render(requests) + "\n\n")
+		} else {
+			card.WriteString(common.MetadataStyle.Render(requests) + "\n\n")
+		}
+	}
 	if !m.profileIsOwn && strings.TrimSpace(m.profile.ID) != "" {
 		followLabel := "not following"
 		if m.isFollowing(m.profile.ID) {
 			followLabel = "following"
+		} else if m.isRequested(m.profile.ID) {
+			followLabel = "requested"
 		}
 		card.WriteString(common.MetadataStyle.Render("Follow: "+followLabel) + "\n")
+		if badges := m.relationshipBadges(m.profile.ID); badges != "" {
+			card.WriteString(badges + "\n")
+		}
+		if rel, ok := m.relationship(m.profile.ID); ok && rel.Note != "" {
+			card.WriteString(common.MetadataStyle.Render("Note: "+rel.Note) + "\n")
+		}
 		card.WriteString(common.MetadataStyle.Render("Keymap: f follow/unfollow") + "\n")
-		if m.confirmFollow {
-			card.WriteString(common.ConfirmStyle.Render("Unfollow? (y/n)") + "\n")
+		if m.confirmFollow && m.followAccountID == m.profile.ID {
+			card.WriteString(common.ConfirmStyle.Render(m.followPrompt()) + "\n")
 		}
 		card.WriteString("\n")
 	}
@@ -343,14 +1086,50 @@ func (m Model) renderProfileView() string {
 	var body strings.Builder
 	body.WriteString(cardStyle.Render(card.String()))
 
-	body.WriteString("\n\n  " + lipgloss.NewStyle().Bold(true).Underline(true).Render("Posts") + "\n")
+	body.WriteString("\n\n  " + m.renderProfileTabs() + "\n")
+	if m.profileTab == profileTabPosts {
+		body.WriteString(m.renderProfilePosts(contentWidth))
+	} else {
+		body.WriteString(m.renderProfileAccounts())
+	}
+	body.WriteString("\n\n" + m.helpView())
+	if hasProfilePreview {
+		left := clampLinesToWidth(body.String(), postWidth+8)
+		leftHeight := max(lipgloss.Height(left), 1)
+		preview := clipLines(profilePreviewPanel, leftHeight)
+		previewPane := lipgloss.NewStyle().
+			MaxHeight(leftHeight).
+			Render(preview)
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", previewPane))
+	} else {
+		b.WriteString(body.String())
+	}
+	return m.renderDetailViewport(b.String())
+}
+
+// renderProfileTabs renders the Posts / Followers / Following tab header.
+func (m Model) renderProfileTabs() string {
+	labels := []string{"Posts", "Followers", "Following"}
+	active := lipgloss.NewStyle().Bold(true).Underline(true)
+	for i, label := range labels {
+		if profileTab(i) == m.profileTab {
+			labels[i] = active.Render(label)
+		} else {
+			labels[i] = common.MetadataStyle.Render(label)
+		}
+	}
+	return strings.Join(labels, "   ")
+}
+
+func (m Model) renderProfilePosts(contentWidth int) string {
+	var body strings.Builder
 	if len(m.profilePosts) == 0 {
 		body.WriteString("\n  No posts.\n")
 	} else {
 		for i := 0; i < len(m.profilePosts); i++ {
 			p := m.profilePosts[i]
 			author := renderAuthor(p.Username, p.IsOwn, m.isFollowing(p.AccountID))
-			ts := common.TimestampStyle.Render(p.CreatedAt.Format("Jan 02 15:04"))
+			ts := common.TimestampStyle.Render(p.CreatedAt.Format("Jan 02 15:04")) + editedMarker(p)
 			content, _ := splitContentAndTags(p.Content)
 			content = strings.TrimSpace(content)
 			if content == "" && len(p.Media) > 0 {
@@ -382,19 +1161,54 @@ func (m Model) renderProfileView() string {
 			body.WriteString("\n" + item + "\n")
 		}
 	}
-	body.WriteString("\n\n" + m.helpView())
-	if hasProfilePreview {
-		left := clampLinesToWidth(body.String(), postWidth+8)
-		leftHeight := max(lipgloss.Height(left), 1)
-		preview := clipLines(profilePreviewPanel, leftHeight)
-		previewPane := lipgloss.NewStyle().
-			MaxHeight(leftHeight).
-			Render(preview)
-		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", previewPane))
+	return body.String()
+}
+
+// renderProfileAccounts renders the followers or following list. The card
+// takes cursor 0, so account i sits at profileCursor i+1.
+func (m Model) renderProfileAccounts() string {
+	l := m.profileAccounts
+	var body strings.Builder
+	if len(l.items) == 0 && l.loading {
+		body.WriteString("\n  " + m.spinner.View() + " Loading accounts...\n")
+	} else if len(l.items) == 0 && l.err == nil {
+		body.WriteString("\n  Nobody yet.\n")
 	} else {
-		b.WriteString(body.String())
+		body.WriteString("\n")
+		start, end := l.window(m.listRows())
+		if start > 0 {
+			body.WriteString("  " + windowMarker("▲", start) + "\n")
+		}
+		for i := start; i < end; i++ {
+			a := l.items[i]
+			line := renderAuthor(a.Username, false, m.isFollowing(a.ID))
+			if a.DisplayName != "" {
+				line += " " + common.MetadataStyle.Render("("+a.DisplayName+")")
+			}
+			if m.profileCursor == i+1 {
+				line = lipgloss.NewStyle().Background(lipgloss.Color("#333333")).Foreground(lipgloss.Color("#FFFFFF")).Render("▶ " + line)
+			} else {
+				line = "  " + line
+			}
+			body.WriteString("  " + line + "\n")
+		}
+		if end < len(l.items) {
+			body.WriteString("  " + windowMarker("▼", len(l.items)-end) + "\n")
+		}
+		switch {
+		case l.loading:
+			body.WriteString("  " + m.spinner.View() + " Loading more...\n")
+		case l.next != "":
+			body.WriteString("  " + common.MetadataStyle.Render("j past the end to load more") + "\n")
+		}
 	}
-	return m.renderDetailViewport(b.String())
+	if l.err != nil {
+		body.WriteString("\n  " + common.ErrorStyle.Render("Error: "+l.err.Error()) + "\n")
+	}
+	if m.confirmFollow && m.followAccountID != m.profile.ID {
+		body.WriteString("\n  " + common.ConfirmStyle.Render(m.followPrompt()) + "\n")
+	}
+	return body.String()
 }
 
 func (m Model) renderProfileAvatarPreviewPanel() string {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
 )
 
 func TestView_RendersExpectedModeSections(t *testing.T) {
@@ -120,6 +121,23 @@ func TestRenderKeyDialog_AllModes(t *testing.T) {
 	}
 }
 
+func TestRenderKeyDialog_ShowsRemappedKeys(t *testing.T) {
+	keys, err := common.DefaultKeyMap().Remap(map[string][]string{"like": {"+"}, "back": {"ctrl+g"}})
+	if err != nil {
+		t.Fatalf("remap: %v", err)
+	}
+	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant").WithKeys(keys)
+	m.rants = []RantItem{{Rant: makeRant("x", time.Now(), "acct-a"), Status: StatusNormal}}
+	m.showDetail = true
+	out := m.renderKeyDialog()
+	if !strings.Contains(out, "+               like/dislike selected post") {
+		t.Fatalf("expected remapped like key in dialog: %q", out)
+	}
+	if !strings.Contains(out, "ctrl+g / q      back") || !strings.Contains(out, "Press ? / ctrl+g / q / enter to close.") {
+		t.Fatalf("expected remapped back key in dialog: %q", out)
+	}
+}
+
 func TestRenderBlockedUsersDialog_States(t *testing.T) {
 	m := New(stubTimeline{}, stubAccount{}, "terminalrant", "terminalrant")
 	m.loadingBlocked = true
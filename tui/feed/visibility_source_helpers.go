@@ -1,10 +1,12 @@
 package feed
 
 import (
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/CrestNiraj12/terminalrant/app"
 	"github.com/CrestNiraj12/terminalrant/domain"
 )
 
@@ -115,8 +117,50 @@ func (m Model) isFollowing(accountID string) bool {
 	return m.followingByID[accountID]
 }
 
+// relationship returns the looked-up relationship with accountID, if any.
+func (m Model) relationship(accountID string) (app.Relationship, bool) {
+	rel, ok := m.relationships[strings.TrimSpace(accountID)]
+	return rel, ok
+}
+
+// isRequested reports a pending follow request to accountID.
+func (m Model) isRequested(accountID string) bool {
+	rel, _ := m.relationship(accountID)
+	return rel.Requested
+}
+
+// followTargetFor reports whether f on accountID should follow, rather than
+// unfollow or withdraw a pending request.
+func (m Model) followTargetFor(accountID string) bool {
+	return !m.isFollowing(accountID) && !m.isRequested(accountID)
+}
+
+// applyFollowResult records the relationship after a follow change and
+// reports whether the user now follows the account.
+func (m *Model) applyFollowResult(msg FollowToggleResultMsg) bool {
+	rel := msg.Relationship
+	if rel.ID == "" {
+		// Without the server's answer, assume the change went through.
+		rel, _ = m.relationship(msg.AccountID)
+		rel.ID = msg.AccountID
+		rel.Following = msg.Follow
+		rel.Requested = false
+	}
+	m.relationships[msg.AccountID] = rel
+	m.followingByID[msg.AccountID] = rel.Following
+	return rel.Following
+}
+
 func (m Model) sourceLabel() string {
-	switch m.feedSource {
+	if m.remote != nil {
+		return m.remoteHost + " " + m.tabLabel(m.feedSource)
+	}
+	return m.tabLabel(m.feedSource)
+}
+
+// tabLabel names a feed source in the tab bar.
+func (m Model) tabLabel(source feedSource) string {
+	switch source {
 	case sourceTerminalRant:
 		return domain.AppHashTag
 	case sourceTrending:
@@ -125,6 +169,10 @@ func (m Model) sourceLabel() string {
 		return "following"
 	case sourceCustomHashtag:
 		return "#" + m.hashtag
+	case sourceLocal:
+		return "local"
+	case sourceFederated:
+		return "federated"
 	default:
 		return domain.AppHashTag
 	}
@@ -138,6 +186,10 @@ func (m Model) sourcePersistValue() string {
 		return "following"
 	case sourceCustomHashtag:
 		return "custom"
+	case sourceLocal:
+		return "local"
+	case sourceFederated:
+		return "federated"
 	default:
 		return "terminalrant"
 	}
@@ -153,12 +205,20 @@ func parseFeedSource(v string) feedSource {
 		return sourceFollowing
 	case "custom":
 		return sourceCustomHashtag
+	case "local":
+		return sourceLocal
+	case "federated":
+		return sourceFederated
 	default:
 		return sourceTerminalRant
 	}
 }
 
 func (m Model) emitPrefsChanged() tea.Cmd {
+	if m.remote != nil {
+		// Tabs picked while browsing another instance are not remembered.
+		return nil
+	}
 	hashtag := strings.TrimSpace(strings.TrimPrefix(m.hashtag, "#"))
 	if hashtag == "" {
 		hashtag = "terminalrant"
@@ -176,8 +236,29 @@ func (m Model) hasCustomTab() bool {
 	return !strings.EqualFold(strings.TrimSpace(m.hashtag), strings.TrimSpace(m.defaultHashtag))
 }
 
+// WithTabs limits and orders the feed tabs, by name as in the saved UI
+// state. Empty keeps every tab; a start tab that is not listed gives way to
+// the first one.
+func (m Model) WithTabs(names []string) Model {
+	m.tabs = nil
+	for _, name := range names {
+		m.tabs = append(m.tabs, parseFeedSource(name))
+	}
+	if len(m.tabs) > 0 && m.feedSource != sourceCustomHashtag && !slices.Contains(m.tabs, m.feedSource) {
+		m.feedSource = m.tabs[0]
+	}
+	return m
+}
+
 func (m Model) tabOrder() []feedSource {
-	order := []feedSource{sourceTerminalRant, sourceTrending, sourceFollowing}
+	order := []feedSource{sourceTerminalRant, sourceTrending, sourceFollowing, sourceLocal, sourceFederated}
+	if len(m.tabs) > 0 {
+		order = slices.Clone(m.tabs)
+	}
+	if m.remote != nil {
+		// Another instance only offers what it shows anonymously.
+		order = []feedSource{sourceLocal, sourceFederated, sourceTerminalRant}
+	}
 	if m.hasCustomTab() {
 		order = append(order, sourceCustomHashtag)
 	}
@@ -211,6 +292,24 @@ func (m Model) isAtVisibleFeedEnd() bool {
 	return visible[len(visible)-1] == m.cursor
 }
 
+// switchToHashtag makes tag the custom hashtag tab and loads it, or the
+// default tab when tag is the default hashtag.
+func (m Model) switchToHashtag(tag string) (Model, tea.Cmd) {
+	m.hashtag = tag
+	if strings.EqualFold(tag, m.defaultHashtag) {
+		m.feedSource = sourceTerminalRant
+	} else {
+		m.feedSource = sourceCustomHashtag
+	}
+	m.prepareSourceChange()
+	m.pagingNotice = "Switched to #" + tag
+	m.feedReqSeq++
+	return m, tea.Batch(
+		m.fetchRants(m.feedReqSeq),
+		m.emitPrefsChanged(),
+	)
+}
+
 func (m *Model) prepareSourceChange() {
 	m.loadingMore = false
 	m.cursor = 0
@@ -267,7 +366,7 @@ func (m Model) IsInDetailView() bool {
 
 // IsDialogOpen reports whether a modal/overlay should capture quit/back keys.
 func (m Model) IsDialogOpen() bool {
-	return m.showAllHints || m.showBlocked || m.showProfile || m.hashtagInput || m.confirmBlock || m.confirmDelete || m.confirmFollow
+	return m.showAllHints || m.showBlocked || m.showScheduled || m.showDrafts || m.showHistory || m.showInteractions || m.showFollowRequests || m.showFollowedTags || m.showNotifications || m.showTrends || m.showReport || m.tagSelect || m.showProfile || m.hashtagInput || m.instanceInput || m.linkInput || m.confirmBlock || m.confirmDelete || m.confirmFollow
 }
 
 // SelectedRant returns the currently highlighted rant, if any.
@@ -334,3 +433,8 @@ func organizeThreadReplies(focusedID string, descendants []domain.Rant) []domain
 
 	return flatResults
 }
+
+// ActiveHashtag returns the custom hashtag the feed follows, without '#'.
+func (m Model) ActiveHashtag() string {
+	return m.hashtag
+}
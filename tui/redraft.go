@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/tui/common"
+	"github.com/CrestNiraj12/terminalrant/tui/feed"
+)
+
+var errRedraftNoDrafts = errors.New("redraft needs the drafts store to keep the original text")
+
+// redraftReadyMsg reports a rant deleted for redrafting; draft holds its text.
+type redraftReadyMsg struct {
+	rant      domain.Rant
+	draft     app.Draft
+	useInline bool
+	err       error
+}
+
+// redraft saves the source text of an own rant as a draft, with its
+// visibility, content warning and media, then deletes the rant. The draft is
+// saved first so the text survives a failed repost, and removed again when
+// the delete fails. Mastodon keeps the media of a deleted status for a while
+// so it can be attached to the new one.
+func (a App) redraft(msg feed.RedraftRantMsg) tea.Cmd {
+	post, timeline, store := a.deps.Post, a.deps.Timeline, a.deps.Drafts
+	hashtags := a.postTags("")
+	tags := domain.ParseHashtags(hashtags)
+	r := msg.Rant
+	return func() tea.Msg {
+		fail := func(err error) tea.Msg { return redraftReadyMsg{rant: r, err: err} }
+		if store == nil {
+			return fail(errRedraftNoDrafts)
+		}
+		src, err := post.Source(context.Background(), r.ID)
+		if err != nil {
+			return fail(err)
+		}
+		d := app.Draft{
+			Content:     common.StripHashtags(strings.TrimSpace(src.Text), tags),
+			Hashtags:    hashtags,
+			Visibility:  src.Visibility,
+			SpoilerText: strings.TrimSpace(src.SpoilerText),
+			MediaIDs:    src.MediaIDs,
+		}
+		if isReplyTo(r.InReplyToID) {
+			d.ParentID = r.InReplyToID
+			// The parent only adds context to the composer; a reply still
+			// works without it.
+			if parent, err := timeline.FetchStatus(context.Background(), r.InReplyToID); err == nil {
+				d.ParentAuthor = parent.Username
+				d.ParentSummary = replySummary(parent)
+				d.Hashtags = a.postTags(parent.Content)
+			}
+		}
+		d, err = store.SaveDraft(d)
+		if err != nil {
+			return fail(fmt.Errorf("saving draft: %w", err))
+		}
+		if err := post.Delete(context.Background(), r.ID); err != nil {
+			_ = store.DeleteDraft(d.ID)
+			return fail(err)
+		}
+		return redraftReadyMsg{rant: r, draft: d, useInline: msg.UseInline}
+	}
+}
+
+// handleRedraftReady drops the deleted rant from the feed and reopens its
+// text in the composer. The draft is kept until the new post goes out.
+func (a App) handleRedraftReady(msg redraftReadyMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		a.status = "Redraft failed: " + msg.err.Error()
+		return a, nil
+	}
+	a.feed, _ = a.feed.Update(feed.DeleteResultMsg{ID: msg.rant.ID})
+	a.active = composeView
+	a.status = ""
+	a.compose = a.rantComposer(a.composeForDraft(msg.draft, msg.useInline)).WithDraft(msg.draft)
+	return a, a.compose.Init()
+}
+
+// isReplyTo reports whether an InReplyToID names a parent rant.
+func isReplyTo(id string) bool {
+	return id != "" && id != "<nil>" && id != "0"
+}
+
+// replySummary is the one-line parent context shown when replying to r.
+func replySummary(r domain.Rant) string {
+	return parentSummary(r.Username, r.Content)
+}
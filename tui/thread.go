@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/CrestNiraj12/terminalrant/app"
+	"github.com/CrestNiraj12/terminalrant/domain"
+	"github.com/CrestNiraj12/terminalrant/tui/compose"
+	"github.com/CrestNiraj12/terminalrant/tui/feed"
+)
+
+var errThreadNotSent = errors.New("not sent: an earlier post in the thread failed")
+
+// threadJob is a thread being published as a reply chain, one post at a time.
+type threadJob struct {
+	raw      []string // unnumbered posts, used for the leftover draft
+	parts    []string // numbered posts as published
+	localIDs []string
+	parentID string // rant the first post replies to; empty for a new thread
+	posted   []domain.Rant
+	draftID  string
+	hashtag  string          // Tags appended to every post
+	opts     app.PostOptions // Kept from a redraft; media go on the first post only
+}
+
+// threadPartMsg reports the outcome of publishing parts[index].
+type threadPartMsg struct {
+	job   threadJob
+	index int
+	rant  domain.Rant
+	err   error
+}
+
+type threadDraftSavedMsg struct {
+	err error
+}
+
+// startThread shows the whole chain optimistically and publishes its first post.
+func (a App) startThread(msg compose.DoneMsg) (App, tea.Cmd) {
+	job := threadJob{
+		raw:     msg.Thread,
+		parts:   compose.NumberThread(msg.Thread),
+		draftID: msg.DraftID,
+		hashtag: msg.Hashtag,
+		opts:    msg.Options,
+	}
+	if msg.IsReply {
+		job.parentID = msg.ParentID
+	}
+	stamp := time.Now().UnixNano()
+	for i := range job.parts {
+		job.localIDs = append(job.localIDs, fmt.Sprintf("local-thread-%d-%d", stamp, i))
+	}
+
+	var preCmd tea.Cmd
+	if msg.IsReply {
+		for i, part := range job.parts {
+			a.feed, _ = a.feed.Update(feed.AddOptimisticReplyMsg{
+				LocalID:  job.localIDs[i],
+				ParentID: msg.ParentID,
+				Content:  part,
+			})
+		}
+	} else {
+		a.feed, preCmd = a.feed.Update(feed.SwitchToTerminalRantMsg{})
+		a.feed, _ = a.feed.Update(feed.AddOptimisticThreadMsg{LocalIDs: job.localIDs, Parts: job.parts})
+	}
+	a.status = fmt.Sprintf("Posting thread 1/%d...", len(job.parts))
+	return a, tea.Batch(preCmd, a.postThreadPart(job, 0))
+}
+
+// postThreadPart publishes parts[i] as a reply to the previous post.
+func (a App) postThreadPart(job threadJob, i int) tea.Cmd {
+	return func() tea.Msg {
+		parentID := job.parentID
+		if i > 0 {
+			parentID = job.posted[i-1].ID
+		}
+		opts := job.opts
+		if i > 0 {
+			opts.MediaIDs = nil
+		}
+		var (
+			rant domain.Rant
+			err  error
+		)
+		if parentID == "" {
+			rant, err = a.deps.Post.Post(context.Background(), job.parts[i], job.hashtag, opts)
+		} else {
+			rant, err = a.deps.Post.Reply(context.Background(), parentID, job.parts[i], job.hashtag, opts)
+		}
+		rant.IsOwn = true
+		return threadPartMsg{job: job, index: i, rant: rant, err: err}
+	}
+}
+
+// handleThreadPart reconciles a published post and continues the chain. On
+// failure the chain stops; posts already published stay up and the unsent
+// ones are kept as a draft replying to the last published post.
+func (a App) handleThreadPart(msg threadPartMsg) (App, tea.Cmd) {
+	job, i, n := msg.job, msg.index, len(msg.job.parts)
+	a.feed, _ = a.feed.Update(feed.ThreadPartResultMsg{LocalID: job.localIDs[i], Rant: msg.rant, Err: msg.err})
+
+	if msg.err != nil {
+		a.feed, _ = a.feed.Update(feed.ThreadAbortedMsg{LocalIDs: job.localIDs[i+1:], Err: errThreadNotSent})
+		a.status = fmt.Sprintf("Thread stopped at post %d/%d: %v.", i+1, n, msg.err)
+		if i > 0 {
+			a.status += fmt.Sprintf(" Posts 1-%d are live.", i)
+		}
+		if a.deps.Drafts == nil {
+			a.status += " Unsent posts were not saved."
+			return a, nil
+		}
+		a.status += fmt.Sprintf(" Posts %d-%d saved as a draft (D to open drafts).", i+1, n)
+		return a, a.saveThreadRemainder(job, i)
+	}
+
+	job.posted = append(job.posted, msg.rant)
+	if i+1 < n {
+		a.status = fmt.Sprintf("Posting thread %d/%d...", i+2, n)
+		return a, a.postThreadPart(job, i+1)
+	}
+	a.status = fmt.Sprintf("🔥 Thread posted (%d posts)!", n)
+	return a, a.discardDraft(job.draftID)
+}
+
+// saveThreadRemainder stores the posts from index i on as a draft that
+// continues the chain where it stopped.
+func (a App) saveThreadRemainder(job threadJob, i int) tea.Cmd {
+	if i == 0 && job.draftID != "" {
+		// Nothing was published; the composer's draft already holds it all.
+		return nil
+	}
+	d := app.Draft{
+		ID:          job.draftID,
+		Content:     compose.JoinThread(job.raw[i:]),
+		ParentID:    job.parentID,
+		Hashtags:    job.hashtag,
+		Visibility:  job.opts.Visibility,
+		SpoilerText: job.opts.SpoilerText,
+	}
+	if i == 0 {
+		d.MediaIDs = job.opts.MediaIDs
+	}
+	if i > 0 {
+		last := job.posted[i-1]
+		d.ParentID = last.ID
+		d.ParentAuthor = last.Username
+		d.ParentSummary = parentSummary(last.Username, job.raw[i-1])
+	}
+	return func() tea.Msg {
+		_, err := a.deps.Drafts.SaveDraft(d)
+		return threadDraftSavedMsg{err: err}
+	}
+}
+
+// parentSummary is the one-line parent context stored with a reply draft,
+// cut by runes so multi-byte text is never split.
+func parentSummary(username, content string) string {
+	if r := []rune(content); len(r) > 50 {
+		content = string(r[:47]) + "..."
+	}
+	return fmt.Sprintf("@%s: %s", username, content)
+}